@@ -0,0 +1,343 @@
+// Package bls implements the BLS signatures of draft-irtf-cfrg-bls-signature
+// over BLS12-381, with aggregation, in its three schemes: basic, message
+// augmentation and proof of possession.
+package bls
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/armfazh/tozan-ecc/bls12381"
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// Mode is the protection of a scheme against rogue key attacks on aggregate
+// signatures.
+type Mode byte
+
+const (
+	ModeBasic Mode = iota // Messages of an aggregate must be distinct.
+	ModeAug               // Messages are prefixed with the public key.
+	ModePoP               // Public keys come with a proof of possession.
+)
+
+// Identifiers of the ciphersuites. The MinPk suites have public keys in G1
+// and signatures in G2, and the MinSig suites the converse.
+const (
+	MinPkBasic  = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+	MinPkAug    = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_"
+	MinPkPoP    = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	MinSigBasic = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_"
+	MinSigAug   = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_AUG_"
+	MinSigPoP   = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+const keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+
+// Scheme is a BLS signature scheme given by a ciphersuite.
+type Scheme struct {
+	Mode Mode
+
+	id     string
+	dst    []byte // the ciphersuite identifier
+	popDST []byte
+	minPk  bool
+	pk     *group // group of public keys
+	sig    *group // group of signatures
+}
+
+// group is one of G1 or G2 with its encoding and hash function.
+type group struct {
+	E      C.EllCurve
+	G      C.Point
+	Len    int
+	encode func(C.Point) []byte
+	decode func([]byte) (C.Point, error)
+	hash   func(msg, dst []byte) C.Point
+}
+
+func g1() *group {
+	E, G := bls12381.G1()
+	return &group{E, G, bls12381.G1Len, bls12381.EncodeG1, bls12381.DecodeG1, bls12381.HashToG1}
+}
+
+func g2() *group {
+	E, G := bls12381.G2()
+	return &group{E, G, bls12381.G2Len, bls12381.EncodeG2, bls12381.DecodeG2, bls12381.HashToG2}
+}
+
+// New returns the scheme of a ciphersuite identifier.
+func New(id string) (*Scheme, error) {
+	s := &Scheme{id: id, dst: []byte(id)}
+	switch id {
+	case MinPkBasic, MinPkAug, MinPkPoP:
+		s.minPk, s.pk, s.sig = true, g1(), g2()
+	case MinSigBasic, MinSigAug, MinSigPoP:
+		s.pk, s.sig = g2(), g1()
+	default:
+		return nil, fmt.Errorf("unknown ciphersuite %q", id)
+	}
+	switch id[len(id)-5:] {
+	case "_NUL_":
+		s.Mode = ModeBasic
+	case "_AUG_":
+		s.Mode = ModeAug
+	case "_POP_":
+		s.Mode = ModePoP
+		s.popDST = []byte(strings.Replace(id, "BLS_SIG_", "BLS_POP_", 1))
+	}
+	return s, nil
+}
+
+func (s *Scheme) String() string { return s.id }
+
+// PublicKeyLen and SignatureLen return the lengths of public keys and
+// signatures.
+func (s *Scheme) PublicKeyLen() int { return s.pk.Len }
+func (s *Scheme) SignatureLen() int { return s.sig.Len }
+
+// PublicKey is the encoding of a public key PK=[SK]G.
+type PublicKey []byte
+
+// PrivateKey is a secret scalar SK with its public key.
+type PrivateKey struct {
+	sk     *big.Int
+	public PublicKey
+}
+
+// Public returns the public key.
+func (k *PrivateKey) Public() PublicKey { return append(PublicKey{}, k.public...) }
+
+// Bytes returns the 32-byte big-endian encoding of SK.
+func (k *PrivateKey) Bytes() []byte {
+	b := make([]byte, 32)
+	sk := k.sk.Bytes()
+	copy(b[32-len(sk):], sk)
+	return b
+}
+
+func (s *Scheme) newKey(sk *big.Int) *PrivateKey {
+	return &PrivateKey{sk: sk, public: s.pk.encode(s.pk.E.ScalarMult(s.pk.G, sk))}
+}
+
+// NewPrivateKey parses the 32-byte big-endian encoding of SK.
+func (s *Scheme) NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != 32 {
+		return nil, errors.New("wrong length of private key")
+	}
+	sk := new(big.Int).SetBytes(b)
+	if sk.Sign() == 0 || sk.Cmp(bls12381.Order()) >= 0 {
+		return nil, errors.New("private scalar out of range")
+	}
+	return s.newKey(sk), nil
+}
+
+// KeyGen derives a private key from the secret keying material ikm, of at
+// least 32 bytes, and the optional key information.
+func (s *Scheme) KeyGen(ikm, info []byte) (*PrivateKey, error) {
+	if len(ikm) < 32 {
+		return nil, errors.New("IKM must be at least 32 bytes")
+	}
+	const L = 48 // ceil(3*ceil(log2(r))/16)
+	salt := []byte(keyGenSalt)
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		prk := hkdfExtract(salt, append(append([]byte{}, ikm...), 0))
+		okm := hkdfExpand(prk, append(append([]byte{}, info...), 0, L), L)
+		sk := new(big.Int).SetBytes(okm)
+		if sk.Mod(sk, bls12381.Order()).Sign() != 0 {
+			return s.newKey(sk), nil
+		}
+	}
+}
+
+// GenerateKey returns a private key derived from 32 random bytes.
+func (s *Scheme) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rnd, ikm); err != nil {
+		return nil, err
+	}
+	return s.KeyGen(ikm, nil)
+}
+
+// KeyValidate reports whether pub encodes a point of the group of public
+// keys other than the identity.
+func (s *Scheme) KeyValidate(pub PublicKey) bool {
+	_, err := s.publicPoint(pub)
+	return err == nil
+}
+
+func (s *Scheme) publicPoint(pub PublicKey) (C.Point, error) {
+	P, err := s.pk.decode(pub)
+	if err != nil {
+		return nil, err
+	}
+	if P.IsIdentity() {
+		return nil, errors.New("public key is the identity")
+	}
+	return P, nil
+}
+
+// Sign returns the signature of msg.
+func (s *Scheme) Sign(key *PrivateKey, msg []byte) []byte {
+	if s.Mode == ModeAug {
+		msg = augment(key.public, msg)
+	}
+	return s.coreSign(key.sk, msg, s.dst)
+}
+
+// Verify reports whether sig is a valid signature of msg under pub.
+func (s *Scheme) Verify(pub PublicKey, msg, sig []byte) bool {
+	return s.AggregateVerify([]PublicKey{pub}, [][]byte{msg}, sig)
+}
+
+// Aggregate returns the aggregate of one or more signatures.
+func (s *Scheme) Aggregate(sigs ...[]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	A := s.sig.E.Identity()
+	for _, sig := range sigs {
+		R, err := s.sig.decode(sig)
+		if err != nil {
+			return nil, err
+		}
+		A = s.sig.E.Add(A, R)
+	}
+	return s.sig.encode(A), nil
+}
+
+// AggregateVerify reports whether sig is the aggregate of the signatures of
+// msgs[i] under pubs[i]. In the basic scheme, messages must be distinct.
+func (s *Scheme) AggregateVerify(pubs []PublicKey, msgs [][]byte, sig []byte) bool {
+	if len(pubs) != len(msgs) {
+		return false
+	}
+	switch s.Mode {
+	case ModeBasic:
+		for i := range msgs {
+			for j := i + 1; j < len(msgs); j++ {
+				if bytes.Equal(msgs[i], msgs[j]) {
+					return false
+				}
+			}
+		}
+	case ModeAug:
+		aug := make([][]byte, len(msgs))
+		for i := range msgs {
+			aug[i] = augment(pubs[i], msgs[i])
+		}
+		msgs = aug
+	}
+	return s.coreAggregateVerify(pubs, msgs, sig, s.dst)
+}
+
+// PopProve returns a proof of possession of the private key.
+func (s *Scheme) PopProve(key *PrivateKey) ([]byte, error) {
+	if s.Mode != ModePoP {
+		return nil, errors.New("scheme has no proofs of possession")
+	}
+	return s.coreSign(key.sk, key.public, s.popDST), nil
+}
+
+// PopVerify reports whether proof is a valid proof of possession for pub.
+func (s *Scheme) PopVerify(pub PublicKey, proof []byte) bool {
+	return s.Mode == ModePoP && s.coreAggregateVerify([]PublicKey{pub}, [][]byte{pub}, proof, s.popDST)
+}
+
+// FastAggregateVerify reports whether sig is the aggregate of signatures of
+// the same message under pubs. The proofs of possession of all public keys
+// must have been verified before.
+func (s *Scheme) FastAggregateVerify(pubs []PublicKey, msg, sig []byte) bool {
+	if s.Mode != ModePoP || len(pubs) == 0 {
+		return false
+	}
+	E := s.pk.E
+	P := E.Identity()
+	for _, pub := range pubs {
+		Q, err := s.pk.decode(pub)
+		if err != nil {
+			return false
+		}
+		P = E.Add(P, Q)
+	}
+	if P.IsIdentity() {
+		return false
+	}
+	R, err := s.sig.decode(sig)
+	if err != nil {
+		return false
+	}
+	return s.pairingCheck([]C.Point{P}, []C.Point{s.sig.hash(msg, s.dst)}, R)
+}
+
+// coreSign returns the encoding of [SK]H(msg).
+func (s *Scheme) coreSign(sk *big.Int, msg, dst []byte) []byte {
+	return s.sig.encode(s.sig.E.ScalarMult(s.sig.hash(msg, dst), sk))
+}
+
+// coreAggregateVerify checks that the product of e(PK_i,H(msg_i)) equals
+// e(G,sig), where pairings take their arguments in the order given by the
+// ciphersuite.
+func (s *Scheme) coreAggregateVerify(pubs []PublicKey, msgs [][]byte, sig, dst []byte) bool {
+	if len(pubs) == 0 || len(pubs) != len(msgs) {
+		return false
+	}
+	R, err := s.sig.decode(sig)
+	if err != nil {
+		return false
+	}
+	P := make([]C.Point, len(pubs))
+	H := make([]C.Point, len(pubs))
+	for i := range pubs {
+		if P[i], err = s.publicPoint(pubs[i]); err != nil {
+			return false
+		}
+		H[i] = s.sig.hash(msgs[i], dst)
+	}
+	return s.pairingCheck(P, H, R)
+}
+
+// pairingCheck reports whether the product of e(P_i,H_i) equals e(G,R).
+func (s *Scheme) pairingCheck(P, H []C.Point, R C.Point) bool {
+	P = append(P, s.pk.E.Neg(s.pk.G))
+	H = append(H, R)
+	if s.minPk {
+		return bls12381.PairProduct(P, H).IsIdentity()
+	}
+	return bls12381.PairProduct(H, P).IsIdentity()
+}
+
+func augment(pub PublicKey, msg []byte) []byte {
+	return append(append([]byte{}, pub...), msg...)
+}
+
+func hkdfExtract(salt, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	_, _ = mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+func hkdfExpand(prk, info []byte, n int) []byte {
+	var okm, t []byte
+	for i := byte(1); len(okm) < n; i++ {
+		mac := hmac.New(sha256.New, prk)
+		_, _ = mac.Write(t)
+		_, _ = mac.Write(info)
+		_, _ = mac.Write([]byte{i})
+		t = mac.Sum(nil)
+		okm = append(okm, t...)
+	}
+	return okm[:n]
+}
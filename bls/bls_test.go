@@ -0,0 +1,228 @@
+package bls_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/bls"
+)
+
+var suites = []string{
+	bls.MinPkBasic,
+	bls.MinPkAug,
+	bls.MinPkPoP,
+	bls.MinSigBasic,
+	bls.MinSigAug,
+	bls.MinSigPoP,
+}
+
+func TestVector(t *testing.T) {
+	// From the sign tests of the Ethereum consensus specs, which use the
+	// proof of possession scheme with public keys in G1.
+	s, err := bls.New(bls.MinPkPoP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk, _ := hex.DecodeString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	key, err := s.NewPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"
+	if got := hex.EncodeToString(key.Public()); got != pk {
+		t.Fatalf("got pk: %v\nwant: %v", got, pk)
+	}
+	msg := make([]byte, 32)
+	sig := "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"
+	if got := hex.EncodeToString(s.Sign(key, msg)); got != sig {
+		t.Fatalf("got sig: %v\nwant: %v", got, sig)
+	}
+	if !bytes.Equal(key.Bytes(), sk) {
+		t.Fatal("wrong encoding of private key")
+	}
+}
+
+func TestKeyGenVectors(t *testing.T) {
+	s, _ := bls.New(bls.MinPkBasic)
+	for _, v := range keyGenVectors {
+		ikm, _ := hex.DecodeString(v.ikm)
+		key, err := s.KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := new(big.Int).SetBytes(key.Bytes()).String(); got != v.sk {
+			t.Fatalf("got sk: %v\nwant: %v", got, v.sk)
+		}
+	}
+}
+
+func TestBasicVectors(t *testing.T) {
+	for _, v := range basicVectors {
+		s, err := bls.New(v.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk, _ := hex.DecodeString(v.sk)
+		key, err := s.NewPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, _ := hex.DecodeString(v.msg)
+		sig := s.Sign(key, msg)
+		if got := hex.EncodeToString(sig); got != v.sig {
+			t.Fatalf("%v: got sig: %v\nwant: %v", s, got, v.sig)
+		}
+		if !s.Verify(key.Public(), msg, sig) {
+			t.Fatalf("%v: valid signature rejected", s)
+		}
+	}
+}
+
+func TestSuiteVectors(t *testing.T) {
+	msgs := [][]byte{[]byte("abc"), []byte("abcdef0123456789")}
+	for _, v := range suiteVectors {
+		s, err := bls.New(v.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs := make([]bls.PublicKey, len(msgs))
+		sigs := make([][]byte, len(msgs))
+		for i, seed := range []string{keyGenVectors[1].ikm, keyGenVectors[3].ikm} {
+			ikm, _ := hex.DecodeString(seed)
+			key, err := s.KeyGen(ikm, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubs[i] = key.Public()
+			if got := hex.EncodeToString(pubs[i]); got != v.pk[i] {
+				t.Fatalf("%v: got pk: %v\nwant: %v", s, got, v.pk[i])
+			}
+			sigs[i] = s.Sign(key, msgs[i])
+			if got := hex.EncodeToString(sigs[i]); got != v.sig[i] {
+				t.Fatalf("%v: got sig: %v\nwant: %v", s, got, v.sig[i])
+			}
+			if s.Mode == bls.ModePoP {
+				proof, err := s.PopProve(key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := hex.EncodeToString(proof); got != v.pop[i] {
+					t.Fatalf("%v: got proof: %v\nwant: %v", s, got, v.pop[i])
+				}
+			}
+		}
+		agg, err := s.Aggregate(sigs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(agg); got != v.agg {
+			t.Fatalf("%v: got aggregate: %v\nwant: %v", s, got, v.agg)
+		}
+		if !s.AggregateVerify(pubs, msgs, agg) {
+			t.Fatalf("%v: valid aggregate rejected", s)
+		}
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+	s, _ := bls.New(bls.MinPkBasic)
+	ikm := bytes.Repeat([]byte{0x01}, 32)
+	k1, err := s.KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, _ := s.KeyGen(ikm, nil)
+	k3, _ := s.KeyGen(ikm, []byte("info"))
+	if !bytes.Equal(k1.Bytes(), k2.Bytes()) || bytes.Equal(k1.Bytes(), k3.Bytes()) {
+		t.Fatal("keys must depend only on IKM and info")
+	}
+	if _, err := s.KeyGen(ikm[1:], nil); err == nil {
+		t.Fatal("short IKM accepted")
+	}
+	if !s.KeyValidate(k1.Public()) {
+		t.Fatal("valid public key rejected")
+	}
+	id := make([]byte, s.PublicKeyLen())
+	id[0] = 0xc0
+	if s.KeyValidate(id) {
+		t.Fatal("identity accepted as public key")
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	msgs := [][]byte{[]byte("msg0"), []byte("msg1")}
+	for _, id := range suites {
+		s, err := bls.New(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys := make([]*bls.PrivateKey, len(msgs))
+		pubs := make([]bls.PublicKey, len(msgs))
+		sigs := make([][]byte, len(msgs))
+		for i := range keys {
+			if keys[i], err = s.GenerateKey(rand.Reader); err != nil {
+				t.Fatal(err)
+			}
+			pubs[i] = keys[i].Public()
+			sigs[i] = s.Sign(keys[i], msgs[i])
+			if len(sigs[i]) != s.SignatureLen() {
+				t.Fatalf("%v: wrong length of signature", s)
+			}
+		}
+		if !s.Verify(pubs[0], msgs[0], sigs[0]) {
+			t.Fatalf("%v: valid signature rejected", s)
+		}
+		if s.Verify(pubs[1], msgs[0], sigs[0]) || s.Verify(pubs[0], msgs[1], sigs[0]) {
+			t.Fatalf("%v: invalid signature accepted", s)
+		}
+
+		agg, err := s.Aggregate(sigs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.AggregateVerify(pubs, msgs, agg) {
+			t.Fatalf("%v: valid aggregate rejected", s)
+		}
+		if s.AggregateVerify(pubs, [][]byte{msgs[1], msgs[0]}, agg) || s.AggregateVerify(pubs[:1], msgs[:1], agg) {
+			t.Fatalf("%v: invalid aggregate accepted", s)
+		}
+
+		// Aggregates of a single message.
+		same := [][]byte{msgs[0], msgs[0]}
+		agg, _ = s.Aggregate(sigs[0], s.Sign(keys[1], msgs[0]))
+		switch s.Mode {
+		case bls.ModeBasic:
+			if s.AggregateVerify(pubs, same, agg) {
+				t.Fatalf("%v: repeated messages accepted", s)
+			}
+		case bls.ModeAug:
+			if !s.AggregateVerify(pubs, same, agg) {
+				t.Fatalf("%v: valid aggregate rejected", s)
+			}
+		case bls.ModePoP:
+			for i, key := range keys {
+				proof, err := s.PopProve(key)
+				if err != nil || !s.PopVerify(pubs[i], proof) {
+					t.Fatalf("%v: valid proof of possession rejected: %v", s, err)
+				}
+				if s.PopVerify(pubs[1-i], proof) {
+					t.Fatalf("%v: proof of possession of another key accepted", s)
+				}
+			}
+			if !s.FastAggregateVerify(pubs, msgs[0], agg) || !s.AggregateVerify(pubs, same, agg) {
+				t.Fatalf("%v: valid aggregate rejected", s)
+			}
+			if s.FastAggregateVerify(pubs, msgs[1], agg) || s.FastAggregateVerify(pubs[:1], msgs[0], agg) {
+				t.Fatalf("%v: invalid aggregate accepted", s)
+			}
+		}
+		if s.Mode != bls.ModePoP {
+			if _, err := s.PopProve(keys[0]); err == nil {
+				t.Fatalf("%v: proof of possession outside of the PoP scheme", s)
+			}
+		}
+	}
+}
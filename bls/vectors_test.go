@@ -0,0 +1,195 @@
+package bls_test
+
+import "github.com/armfazh/tozan-ecc/bls"
+
+// keyGenVectors are the master keys of the test cases of EIP-2333, whose
+// derivation from a seed is the KeyGen of the draft with empty key
+// information.
+var keyGenVectors = []struct{ ikm, sk string }{
+	{
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e5349553" +
+			"1f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		"6083874454709270928345386274498605044986640685124978867557563392430687146096",
+	},
+	{
+		"3141592653589793238462643383279502884197169399375105820974944592",
+		"29757020647961307431480504535336562678282505419141012933316116377660817309383",
+	},
+	{
+		"0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+		"27580842291869792442942448775674722299803720648445448686099262467207037398656",
+	},
+	{
+		"d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		"19022158461524446591288038168518313374041767046816487870552872741050760015818",
+	},
+}
+
+// basicVectors are signatures of the reference implementation of the draft
+// (bls_sigs_ref) in the basic schemes. Its keys are given as scalars, since
+// they were derived with a KeyGen that does not hash the salt first.
+var basicVectors = []struct{ id, sk, msg, sig string }{
+	{
+		bls.MinPkBasic,
+		"2bfb7592b68fccd8db54461979d6a0d3d997b1405264b097232c1df29b5fade1",
+		"ff624d0ba02c7b6370c1622eec3fa2186ea681d1659e0a845448e777b75a8e77" +
+			"a77bb26e5733179d58ef9bc8a4e8b6971aef2539f77ab0963a3415bbd6258339" +
+			"bd1bf55de65db520c63f5b8eab3d55debd05e9494212170f5d65b3286b8b6687" +
+			"05b1e2b2b5568610617abb51d2dd0cb450ef59df4b907da90cfa7b268de8c4c2",
+		"b1341b7f4fbaa9228ae3b98b8c070c8758d67e111fc20f11a49fac426384b148" +
+			"722791589aaacb4a1d48ec93fe838bca1217078d6b4ae284d985c1081a622b32" +
+			"e8122612bc0bab3596d052e82b7562fd48f7b2c78ac344ee784fd5f53d5a00ad",
+	},
+	{
+		bls.MinSigBasic,
+		"2bfb7592b68fccd8db54461979d6a0d3d997b1405264b097232c1df29b5fade1",
+		"ff624d0ba02c7b6370c1622eec3fa2186ea681d1659e0a845448e777b75a8e77" +
+			"a77bb26e5733179d58ef9bc8a4e8b6971aef2539f77ab0963a3415bbd6258339" +
+			"bd1bf55de65db520c63f5b8eab3d55debd05e9494212170f5d65b3286b8b6687" +
+			"05b1e2b2b5568610617abb51d2dd0cb450ef59df4b907da90cfa7b268de8c4c2",
+		"8376eaaae4275ee59263ba2a94c3e664c031bc3177eea3333ba893ab33c8df3f" +
+			"2e8825be3ada8ed6184b2e38367113ab",
+	},
+}
+
+// suiteVectors are the public keys, signatures, proofs of possession and
+// aggregate signature of the keys of the second and fourth seeds of
+// keyGenVectors, signing the messages "abc" and "abcdef0123456789"
+// respectively. They were cross-checked against blst.
+var suiteVectors = []struct {
+	id           string
+	pk, sig, pop [2]string
+	agg          string
+}{
+	{
+		id: bls.MinPkBasic,
+		pk: [2]string{
+			"819f9cd0f4a042e778fc7a4008a0f1ea6b0e8e2a9b3ad64846e4e5237322f747" +
+				"7630b8f7dae567c9245af31f5edb700b",
+			"8476e8c8fa0e72c3cc8ea3e28c9476b0a3d687ed9b1919dca6a22c98d381c950" +
+				"0fa158b94ef604f5a8d163e20c674a9d",
+		},
+		sig: [2]string{
+			"b7ef12dae91df625544375ce57f3bc4ef1aa5e3c44e3594b2882cd2004ee609e" +
+				"0f61ddd4169d2972a360a659c38ebe6a0773f9cd64615fab5058e8f5bf462366" +
+				"fa09ee80fe03020cfcdce87ccf6210d72bd9d1f18b56268fa50957d7a9db3cce",
+			"82f95a59679e42b2d460ae376547a709336ddfe92fb87e76d0f5870414837c5e" +
+				"6f3317f648fe5b6d81a6f6a818e4d5b0176816bcdc2da769692dffbcf3010750" +
+				"b4ea07fb81abe89c95a49e074088711e11629559c37ae849f39c7a5c0286d274",
+		},
+		agg: "85c3c9182569b554178c1d8ae9b40670514c19a444dc8b0f8edda368bcb2f5f9" +
+			"f9e2c8469dea38ec36a1f4aabd5782200c59b255b01d9e45f59e66c16fcc29b0" +
+			"f080bbf5dd98ad10f22c4637a3cca8b933101e1284b314717aa664a648104e02",
+	},
+	{
+		id: bls.MinPkAug,
+		pk: [2]string{
+			"819f9cd0f4a042e778fc7a4008a0f1ea6b0e8e2a9b3ad64846e4e5237322f747" +
+				"7630b8f7dae567c9245af31f5edb700b",
+			"8476e8c8fa0e72c3cc8ea3e28c9476b0a3d687ed9b1919dca6a22c98d381c950" +
+				"0fa158b94ef604f5a8d163e20c674a9d",
+		},
+		sig: [2]string{
+			"995830a61bad71890262136851c9c238812212e1b8dfd2b762a4fec563ff1d7f" +
+				"439cdb3602192607c1cc2567c1f7d1d605d06e233a638bd4612bd2f0d452b6a0" +
+				"ebf98abcea276639c6fe5ea90d288610ac6aec86cec9debc9ba03317ce8a87b8",
+			"b513ed50d0c014f1592324237db624857dfbcdf8873d759c2869d24fb31160d7" +
+				"6ff749c605343ffbf0935e091ac421ef012f5fb986becdd9c9abb1bb6ccb2e10" +
+				"4ff22fd7c3731d2b27946f636b2210f3f17c014f2d480dc2b0a0b38755f22538",
+		},
+		agg: "8994523b461073225aff640771d25a51b8ff9657252fa7ba1b3464ca87f3f5e5" +
+			"6f82748fc8deab9bbe31448d60cf08fe09fa0b39835513b6f6cfcec67c57029a" +
+			"c699ee219dcff915438ba073c0e0e9e07a0db6a342a7822bda250e290150bfcf",
+	},
+	{
+		id: bls.MinPkPoP,
+		pk: [2]string{
+			"819f9cd0f4a042e778fc7a4008a0f1ea6b0e8e2a9b3ad64846e4e5237322f747" +
+				"7630b8f7dae567c9245af31f5edb700b",
+			"8476e8c8fa0e72c3cc8ea3e28c9476b0a3d687ed9b1919dca6a22c98d381c950" +
+				"0fa158b94ef604f5a8d163e20c674a9d",
+		},
+		sig: [2]string{
+			"93784c8658b27c3f93a06d5dd7e4e179fa085b131436a1cf473bf5c7a4206102" +
+				"c5631c60a34a59e88a02cbd27799d5530699a866d6bf338c12da9316250407c0" +
+				"229ae9853ab4d32345842a744f72b33bfd15a687442ce8feac4b4c2b56b0e198",
+			"a9f9c947b237bf3f9f171508211870bc138265cc554682da1708f734360cbadf" +
+				"11efdf4b1a5f70acfbd7976835e2ae1f0f222358d9cb66fe4e86c36092e9cc72" +
+				"7ee7f55915295bba395de97385ee533e75a8494426994af150a94f7c4616e7e9",
+		},
+		pop: [2]string{
+			"95204cc131a6b563a47305fd38072226f0b7a038e3143139b444c8337e1e1afd" +
+				"e05c3f2f6ea208fa4aa5c2d3bc1c5b4f04d72c188b2de3b0dd0aa8952f0d241a" +
+				"f48a25ce5692872085ebb8ac3546eaf96b197aa6ad3f965bef989ac2b60a1c2d",
+			"b8e181570f8137ef16680751248fc1b991bb47050f3ae9d7b4191ff90c790e93" +
+				"6e09e4fbfaf2167308b73dc98ccb2cfa01ec7f9ce76a1a35901fd58861c0bc7b" +
+				"fa666a12cd4869211811571378adbbc4996fd35eb9b60c29d3d99450094e7be5",
+		},
+		agg: "8df23707b462cdd513cb327765df40becc908582d1f72a920708d298138dcdcf" +
+			"0c6915f7f5e9552c1460ff9ab11033dd0bd585d41fa37288b25bf3c4abfa7d09" +
+			"e8488ce3152a8600218796a12514fb8cadcdd2e769f94553d1a3c0746e97dbe9",
+	},
+	{
+		id: bls.MinSigBasic,
+		pk: [2]string{
+			"90bb1fe1afd621c521cb5df78b9914457cacd979105b9d23983bc18ed21c212e" +
+				"844af37ea15a27f33e07bab3123d3b5607ffcfc0f7c6bd222f510c6f57d84d66" +
+				"a11fa227c673c7d2c66339f1ee8b5c722818f10e05df64d1d279c959ce4dfa6d",
+			"a8986baabe57889d1de7b17ea65e0f493875d54dff61d24e269992ea8d3e8a49" +
+				"704f20c6751fcd9f20bb65b1f917054a0f3c3a20c8e120e274b742fe0d358b1e" +
+				"6abd99bd4066d882335d62e1fe572aab27659c637812fe4fa5ce5172b4715cec",
+		},
+		sig: [2]string{
+			"b12d961f0f0d3d57910c07d0c211ae3289ad34cc84fed5b33ba8ef51792e63ed" +
+				"27b799d58264fe181cb22011daf8ccaa",
+			"a72bcab1bf484c397d710a453ea15866a5b72c1dae0d3f701f41dbc5bc389101" +
+				"2191fd45450191e460fceaefe288e70d",
+		},
+		agg: "8b5bb2c6994aa9b30280f22b6d064a97ea4421ec2e3759a7c956c58c73a9f3d1" +
+			"4e8c6e2d2f50e8f27f88d0cfe6204b95",
+	},
+	{
+		id: bls.MinSigAug,
+		pk: [2]string{
+			"90bb1fe1afd621c521cb5df78b9914457cacd979105b9d23983bc18ed21c212e" +
+				"844af37ea15a27f33e07bab3123d3b5607ffcfc0f7c6bd222f510c6f57d84d66" +
+				"a11fa227c673c7d2c66339f1ee8b5c722818f10e05df64d1d279c959ce4dfa6d",
+			"a8986baabe57889d1de7b17ea65e0f493875d54dff61d24e269992ea8d3e8a49" +
+				"704f20c6751fcd9f20bb65b1f917054a0f3c3a20c8e120e274b742fe0d358b1e" +
+				"6abd99bd4066d882335d62e1fe572aab27659c637812fe4fa5ce5172b4715cec",
+		},
+		sig: [2]string{
+			"b5480f7e3cbe02c71fec7f8a6126da5b551bb506b73b2a724361ff69329c6811" +
+				"52f51257144555a1fbc4ef325a79161b",
+			"94e1fc2e6942ce5bc760008deb97a79cddd56a73edf0409c59b53adba717acdd" +
+				"573d85fc1af9d047a68550a127fe3512",
+		},
+		agg: "ad06dc63ececd82d158fdd711cf3b41081426cd7929be0b4cfc6334c23bea605" +
+			"c02a57178aaa98292f1ae2bbbb53a331",
+	},
+	{
+		id: bls.MinSigPoP,
+		pk: [2]string{
+			"90bb1fe1afd621c521cb5df78b9914457cacd979105b9d23983bc18ed21c212e" +
+				"844af37ea15a27f33e07bab3123d3b5607ffcfc0f7c6bd222f510c6f57d84d66" +
+				"a11fa227c673c7d2c66339f1ee8b5c722818f10e05df64d1d279c959ce4dfa6d",
+			"a8986baabe57889d1de7b17ea65e0f493875d54dff61d24e269992ea8d3e8a49" +
+				"704f20c6751fcd9f20bb65b1f917054a0f3c3a20c8e120e274b742fe0d358b1e" +
+				"6abd99bd4066d882335d62e1fe572aab27659c637812fe4fa5ce5172b4715cec",
+		},
+		sig: [2]string{
+			"90f9dff386f430c29d8a4a9d25604bb9bdde3eafe6b9eadeed9d8d73d5abc11e" +
+				"5aa9f938b39384de1f6fefb484ff8e9c",
+			"ac3c775d129be8a1904562328e675b5d708700d4241504aa8c45ecc8b43a70cf" +
+				"69e0f7b29d4afae7faa0fe33bda07cb6",
+		},
+		pop: [2]string{
+			"b515b1bac04af4f9db2c4bde947b6bdc61e67b77b6c37a5dd83eab942a6a0710" +
+				"f08d66f18d9dc9bba9f53939c9c0d961",
+			"8115c185de993fe3c25987dc87fc4b749ce9025842e788c6a86f5a38de42d26e" +
+				"eed1e9a324de2b8773395d787b5dff71",
+		},
+		agg: "8c7e2e7f82ba96132f275d646864c3793d10a6b6d379246734fc236c80ec96ec" +
+			"e62f17c9a81a5eebb04d4722b2b529c3",
+	},
+}
@@ -0,0 +1,89 @@
+// Package bls12381 provides the pairing-friendly curve BLS12-381: the groups
+// G1 and G2, their compressed encodings, hashing to them as in RFC 9380, and
+// the optimal ate pairing.
+package bls12381
+
+import (
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+var (
+	p = fromHex("0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+	r = fromHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	// xAbs is the absolute value of the curve parameter x=-0xd201000000010000.
+	xAbs = fromHex("0xd201000000010000")
+
+	fp  = GF.NewFp("BLS12-381", p)
+	fp2 = GF.NewFp2("BLS12-381", p)
+
+	e1, g1 = newG1()
+	e2, g2 = newG2()
+)
+
+// Order returns r, the prime order of G1, G2 and the target group.
+func Order() *big.Int { return new(big.Int).Set(r) }
+
+// G1 returns the curve y^2=x^3+4 over GF(p), and the generator of G1, its
+// subgroup of order r.
+func G1() (C.EllCurve, C.Point) { return e1, g1.Copy() }
+
+// G2 returns the curve y^2=x^3+4(1+u) over GF(p^2), where u^2=-1, and the
+// generator of G2, its subgroup of order r.
+func G2() (C.EllCurve, C.Point) { return e2, g2.Copy() }
+
+func newG1() (C.EllCurve, C.Point) {
+	h := fromHex("0x396c8c005555e1568c00aaab0000aaab")
+	E := C.Weierstrass.New("BLS12-381 G1", fp, fp.Zero(), fp.Elt(4), r, h)
+	G := E.NewPoint(
+		fp.Elt("0x17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"),
+		fp.Elt("0x08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"))
+	return E, G
+}
+
+func newG2() (C.EllCurve, C.Point) {
+	h := fromHex("0x5d543a95414e7f1091d50792876a202cd91de4547085abaa68a205b2e5a7ddfa628f1cb4d9e82ef21537e293a6691ae1616ec6e786f0c70cf1c38e31c7238e5")
+	E := C.Weierstrass.New("BLS12-381 G2", fp2, fp2.Zero(), fp2.Elt([]int{4, 4}), r, h)
+	G := E.NewPoint(
+		fp2.Elt([]string{
+			"0x024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8",
+			"0x13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e",
+		}),
+		fp2.Elt([]string{
+			"0x0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801",
+			"0x0606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		}))
+	return E, G
+}
+
+func fromHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		panic("invalid constant " + s)
+	}
+	return n
+}
+
+// psi is the endomorphism of E2 obtained by conjugating the Frobenius map
+// with the twist. It acts on G2 as multiplication by p.
+func psi(P C.Point) C.Point {
+	if P.IsIdentity() {
+		return P
+	}
+	return e2.NewPoint(fp2.Mul(conj(P.X()), psiX), fp2.Mul(conj(P.Y()), psiY))
+}
+
+var psiX, psiY = fp2.Inv(gamma[2]), fp2.Inv(gamma[3])
+
+// mulX returns [x]P.
+func mulX(E C.EllCurve, P C.Point) C.Point { return E.Neg(E.ScalarMult(P, xAbs)) }
+
+// inG1 reports whether P has order r.
+func inG1(P C.Point) bool { return e1.ScalarMult(P, r).IsIdentity() }
+
+// inG2 reports whether P is in G2, by checking that psi(P)=[x]P, as shown by
+// Scott in "A note on group membership tests for G1, G2 and GT on BLS
+// pairing-friendly curves".
+func inG2(P C.Point) bool { return psi(P).IsEqual(mulX(e2, P)) }
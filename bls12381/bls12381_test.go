@@ -0,0 +1,123 @@
+package bls12381_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/bls12381"
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+func TestEncoding(t *testing.T) {
+	E1, G1 := bls12381.G1()
+	E2, G2 := bls12381.G2()
+	for _, v := range []struct {
+		E      C.EllCurve
+		P      C.Point
+		encode func(C.Point) []byte
+		decode func([]byte) (C.Point, error)
+		want   string
+	}{
+		{E1, G1, bls12381.EncodeG1, bls12381.DecodeG1,
+			"97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"},
+		{E2, G2, bls12381.EncodeG2, bls12381.DecodeG2,
+			"93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+				"024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"},
+		{E1, E1.Identity(), bls12381.EncodeG1, bls12381.DecodeG1, "c" + strings.Repeat("0", 2*bls12381.G1Len-1)},
+		{E2, E2.Identity(), bls12381.EncodeG2, bls12381.DecodeG2, "c" + strings.Repeat("0", 2*bls12381.G2Len-1)},
+	} {
+		b := v.encode(v.P)
+		if got := hex.EncodeToString(b); got != v.want {
+			t.Fatalf("got: %v\nwant: %v", got, v.want)
+		}
+		for _, P := range []C.Point{v.P, v.E.Neg(v.P)} {
+			Q, err := v.decode(v.encode(P))
+			if err != nil || !Q.IsEqual(P) {
+				t.Fatalf("decoding failed: %v", err)
+			}
+		}
+		// Uncompressed encodings, and coordinates out of range, are rejected.
+		b[0] &^= 0x80
+		if _, err := v.decode(b); err == nil {
+			t.Fatal("uncompressed encoding accepted")
+		}
+		b[0] = 0x9f
+		b[1] = 0xff
+		if _, err := v.decode(b); err == nil {
+			t.Fatal("invalid encoding accepted")
+		}
+	}
+
+	// Points of the curves outside of G1 and G2 are rejected.
+	for _, v := range []struct {
+		E      C.EllCurve
+		encode func(C.Point) []byte
+		decode func([]byte) (C.Point, error)
+	}{
+		{E1, bls12381.EncodeG1, bls12381.DecodeG1},
+		{E2, bls12381.EncodeG2, bls12381.DecodeG2},
+	} {
+		for x := 1; ; x++ {
+			if P, err := C.LiftX(v.E, v.E.Field().Elt(x), 0); err == nil {
+				if _, err := v.decode(v.encode(P)); err == nil {
+					t.Fatal("point outside of the subgroup accepted")
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	// Test vectors of Appendix J of RFC 9380, for msg="".
+	P := bls12381.HashToG1(nil, []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_"))
+	x := "52926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1"
+	y := "8ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265"
+	if P.X().Polynomial()[0].Text(16) != x || P.Y().Polynomial()[0].Text(16) != y {
+		t.Fatalf("got: %v\nwant: (0x%v, 0x%v)", P, x, y)
+	}
+
+	Q := bls12381.HashToG2(nil, []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"))
+	want := []string{
+		"141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+		"5cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+		"503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+		"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+	}
+	got := append(Q.X().Polynomial(), Q.Y().Polynomial()...)
+	for i := range want {
+		if got[i].Text(16) != want[i] {
+			t.Fatalf("got: %v\nwant: %v", Q, want)
+		}
+	}
+}
+
+func TestPairing(t *testing.T) {
+	E1, G1 := bls12381.G1()
+	E2, G2 := bls12381.G2()
+	r := bls12381.Order()
+	a, _ := rand.Int(rand.Reader, r)
+	b, _ := rand.Int(rand.Reader, r)
+
+	e := bls12381.Pair(G1, G2)
+	if e.IsIdentity() || !e.Exp(r).IsIdentity() {
+		t.Fatal("pairing must have order r")
+	}
+	// e([a]P,[b]Q) = e(P,Q)^(ab)
+	ab := new(big.Int).Mul(a, b)
+	if !bls12381.Pair(E1.ScalarMult(G1, a), E2.ScalarMult(G2, b)).IsEqual(e.Exp(ab)) {
+		t.Fatal("pairing is not bilinear")
+	}
+	// e(P,Q)e(-P,Q) = 1 and e(P,Q)e(P,Q) = e([2]P,Q)
+	P := []C.Point{G1, E1.Neg(G1), E1.Identity()}
+	Q := []C.Point{G2, G2, G2}
+	if !bls12381.PairProduct(P, Q).IsIdentity() {
+		t.Fatal("product of pairings must be one")
+	}
+	if !e.Mul(e).IsEqual(bls12381.Pair(E1.Double(G1), G2)) {
+		t.Fatal("pairing is not linear in G1")
+	}
+}
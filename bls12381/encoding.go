@@ -0,0 +1,118 @@
+package bls12381
+
+import (
+	"errors"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Points are encoded in the compressed format of the Zcash serialization of
+// BLS12-381: the big-endian x-coordinate, where an element c0+c1*u of
+// GF(p^2) is written as c1||c0, and the three most significant bits are
+// flags.
+const (
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	flagLargest    = 0x20 // y is lexicographically larger than -y
+
+	fpLen = 48
+)
+
+// G1Len and G2Len are the lengths of the encodings of points of G1 and G2.
+const (
+	G1Len = fpLen
+	G2Len = 2 * fpLen
+)
+
+// EncodeG1 returns the compressed encoding of a point of G1.
+func EncodeG1(P C.Point) []byte { return encode(P, G1Len) }
+
+// EncodeG2 returns the compressed encoding of a point of G2.
+func EncodeG2(P C.Point) []byte { return encode(P, G2Len) }
+
+// DecodeG1 parses a compressed encoding of a point of G1. Points on the curve
+// but outside of G1 are rejected.
+func DecodeG1(b []byte) (C.Point, error) { return decode(e1, b, G1Len, inG1) }
+
+// DecodeG2 parses a compressed encoding of a point of G2. Points on the curve
+// but outside of G2 are rejected.
+func DecodeG2(b []byte) (C.Point, error) { return decode(e2, b, G2Len, inG2) }
+
+func encode(P C.Point, n int) []byte {
+	b := make([]byte, n)
+	if P.IsIdentity() {
+		b[0] = flagCompressed | flagInfinity
+		return b
+	}
+	c := P.X().Polynomial()
+	for i, ci := range c {
+		off := (len(c) - 1 - i) * fpLen
+		cb := ci.Bytes()
+		copy(b[off+fpLen-len(cb):off+fpLen], cb)
+	}
+	b[0] |= flagCompressed
+	if isLargest(P.Y()) {
+		b[0] |= flagLargest
+	}
+	return b
+}
+
+func decode(E C.EllCurve, b []byte, n int, inGroup func(C.Point) bool) (C.Point, error) {
+	if len(b) != n {
+		return nil, errors.New("wrong length of point")
+	}
+	if b[0]&flagCompressed == 0 {
+		return nil, errors.New("only compressed points are supported")
+	}
+	largest := b[0]&flagLargest != 0
+	x := append([]byte{}, b...)
+	x[0] &^= flagCompressed | flagInfinity | flagLargest
+	if b[0]&flagInfinity != 0 {
+		if largest || new(big.Int).SetBytes(x).Sign() != 0 {
+			return nil, errors.New("non-canonical encoding of the identity")
+		}
+		return E.Identity(), nil
+	}
+	F := E.Field()
+	c := make([]*big.Int, n/fpLen)
+	for i := range c {
+		off := (len(c) - 1 - i) * fpLen
+		c[i] = new(big.Int).SetBytes(x[off : off+fpLen])
+		if c[i].Cmp(p) >= 0 {
+			return nil, errors.New("coordinate is not a field element")
+		}
+	}
+	X := F.Elt(c[0])
+	if len(c) == 2 {
+		X = F.Elt(c)
+	}
+	W := E.(C.W)
+	y2 := W.EvalRHS(X)
+	if !F.IsSquare(y2) {
+		return nil, errors.New("point is not on the curve")
+	}
+	Y := F.Sqrt(y2)
+	if isLargest(Y) != largest {
+		Y = F.Neg(Y)
+	}
+	P := E.NewPoint(X, Y)
+	if !inGroup(P) {
+		return nil, errors.New("point is not in the subgroup of order r")
+	}
+	return P, nil
+}
+
+// isLargest reports whether y>-y, comparing the coefficients of GF(p^2)
+// from the highest.
+func isLargest(y GF.Elt) bool {
+	half := new(big.Int).Rsh(p, 1) // (p-1)/2
+	c := y.Polynomial()
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].Sign() != 0 {
+			return c[i].Cmp(half) > 0
+		}
+	}
+	return false
+}
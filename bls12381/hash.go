@@ -0,0 +1,205 @@
+package bls12381
+
+import (
+	"crypto"
+	_ "crypto/sha256" // for expand_message_xmd
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/group"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// HashToG1 hashes msg to G1 under the domain separation tag dst, as the
+// suite BLS12381G1_XMD:SHA-256_SSWU_RO_ of RFC 9380.
+func HashToG1(msg, dst []byte) C.Point { return h2c1.hash(msg, dst) }
+
+// HashToG2 hashes msg to G2 under the domain separation tag dst, as the
+// suite BLS12381G2_XMD:SHA-256_SSWU_RO_ of RFC 9380.
+func HashToG2(msg, dst []byte) C.Point { return h2c2.hash(msg, dst) }
+
+// hasher maps to the curve E with the simplified SWU map onto an isogenous
+// curve E' of equation y^2=x^3+A'x+B'.
+type hasher struct {
+	E       C.EllCurve
+	iso     *isogeny // from E' to E
+	A, B, Z GF.Elt   // of E' and the SWU map
+	clear   func(C.Point) C.Point
+}
+
+var h2c1 = &hasher{
+	E: e1,
+	A: fp.Elt("0x144698a3b8e9433d693a02c96d4982b0ea985383ee66a8d8e8981aefd881ac98936f8da0e0f97f5cf428082d584c1d"),
+	B: fp.Elt("0x12e2908d11688030018b12e8753eee3b2016c1f0f24f4070a0b9c14fcef35ef55a23215a316ceaa5d1cc48e98e172be0"),
+	Z: fp.Elt(11),
+	// Multiplies by h_eff=1-x.
+	clear: func(P C.Point) C.Point { return e1.Add(P, e1.Neg(mulX(e1, P))) },
+}
+
+var h2c2 = &hasher{
+	E:     e2,
+	A:     fp2.Elt([]int{0, 240}),
+	B:     fp2.Elt([]int{1012, 1012}),
+	Z:     fp2.Elt([]int{-2, -1}),
+	clear: clearCofactorG2,
+}
+
+func init() {
+	// The 11-isogeny onto E1 and the 3-isogeny onto E2 are recovered from
+	// their kernel polynomials with Kohel's formulas, and composed with the
+	// isomorphism (x,y) -> (l^2x,l^3y). They match the rational maps of
+	// Appendix E of RFC 9380.
+	h2c1.iso = newIsogeny(h2c1.E, h2c1.A, h2c1.B, []GF.Elt{
+		fp.Elt("0x133341fb0962a34cb0504a9c4fada0a5090d38679b4c040d5d1c3afb023a3409fcc0815fea66d8b02bbef9c8b5a66e07"),
+		fp.Elt("0x264908af037bcede00d054cf5d4775e83eb6cf63c76b969f8ed174fb59fcff78d201f46f6cfc4ed6552e59ce75177b0"),
+		fp.Elt("0x1335c502c1f54c49aceea65e87fd7203ba0f626f305fc0cfd606a5dae9f3c8e81a4b3b69600129fabd307c69bf319d39"),
+		fp.Elt("0x94440f65f408a6e930e16e3e92dd17bf60d6e9679a8d3d58593de55ac23703042d609537eb3549aac234d896ca82944"),
+		fp.Elt("0x4afe09d5cf4956a23b6b71f59d2b3407b415a774b7be81bbb6fa99cbc798e0ac98ba725a5bc328016b1c268b4766e85"),
+		fp.One(),
+	}, fp.Elt("0x17a3e1bda8a2d1a38a19241a0ea1e2f25b552d6197903f96bae690ef6be6b1381be22e8a72a9745cd7a1ffffffffb26d"))
+	h2c2.iso = newIsogeny(h2c2.E, h2c2.A, h2c2.B, []GF.Elt{
+		fp2.Elt([]int{6, -6}), fp2.One(),
+	}, fp2.Neg(fp2.Inv(fp2.Elt(3))))
+}
+
+func (h *hasher) hash(msg, dst []byte) C.Point {
+	E := h.E
+	u := hashToField(E.Field(), msg, dst, 2)
+	P := E.Add(h.iso.push(h.mapSSWU(u[0])), h.iso.push(h.mapSSWU(u[1])))
+	return h.clear(P)
+}
+
+// clearCofactorG2 returns [x^2-x-1]P+[x-1]psi(P)+psi^2(2P), as
+// clear_cofactor_bls12381_g2 of RFC 9380. It equals the multiplication by
+// h_eff of the suite.
+func clearCofactorG2(P C.Point) C.Point {
+	E := e2
+	t1 := mulX(E, P)
+	t2 := psi(P)
+	t3 := psi(psi(E.Double(P)))
+	t3 = E.Add(t3, E.Neg(t2))
+	t2 = mulX(E, E.Add(t1, t2))
+	t3 = E.Add(t3, t2)
+	t3 = E.Add(t3, E.Neg(t1))
+	return E.Add(t3, E.Neg(P))
+}
+
+// mapSSWU is the simplified SWU map of RFC 9380 onto E', returned as the
+// coordinates of a point.
+func (h *hasher) mapSSWU(u GF.Elt) (x, y GF.Elt) {
+	F := h.E.Field()
+	A, B, Z := h.A, h.B, h.Z
+	rhs := func(x GF.Elt) GF.Elt { return F.Add(F.Mul(F.Add(F.Sqr(x), A), x), B) }
+	zu2 := F.Mul(Z, F.Sqr(u))
+	tv1 := F.Add(F.Sqr(zu2), zu2)
+	x1 := F.Mul(B, F.Inv(F.Mul(Z, A))) // B/(ZA)
+	if !F.IsZero(tv1) {
+		x1 = F.Mul(F.Neg(F.Mul(B, F.Inv(A))), F.Add(F.One(), F.Inv(tv1))) // (-B/A)(1+1/tv1)
+	}
+	x, y2 := x1, rhs(x1)
+	if !F.IsZero(y2) && !F.IsSquare(y2) {
+		x = F.Mul(zu2, x1)
+		y2 = rhs(x)
+	}
+	y = F.Sqrt(y2)
+	if F.Sgn0(u) != F.Sgn0(y) {
+		y = F.Neg(y)
+	}
+	return x, y
+}
+
+// hashToField returns count elements of F, as hash_to_field of RFC 9380 with
+// expand_message_xmd, SHA-256 and L=64.
+func hashToField(F GF.Field, msg, dst []byte, count int) []GF.Elt {
+	const L = 64
+	m := int(F.Ext())
+	b, err := group.ExpandMessageXMD(crypto.SHA256, msg, dst, count*m*L)
+	if err != nil {
+		panic(err)
+	}
+	u := make([]GF.Elt, count)
+	for i := range u {
+		c := make([]*big.Int, m)
+		for j := range c {
+			off := L * (j + i*m)
+			c[j] = new(big.Int).SetBytes(b[off : off+L])
+		}
+		if m == 1 {
+			u[i] = F.Elt(c[0])
+		} else {
+			u[i] = F.Elt(c)
+		}
+	}
+	return u
+}
+
+// isogeny is the rational map (x,y) -> (xn(x)/xd(x), y*yn(x)/yd(x)).
+type isogeny struct {
+	E              C.EllCurve // codomain
+	xn, xd, yn, yd poly.Poly
+}
+
+// newIsogeny returns the normalized isogeny of odd degree from y^2=x^3+ax+b
+// with the given kernel polynomial, given by its coefficients in ascending
+// order, followed by the isomorphism onto E scaling x by l^2 and y by l^3.
+func newIsogeny(E C.EllCurve, a, b GF.Elt, kernel []GF.Elt, l GF.Elt) *isogeny {
+	F := E.Field()
+	R := poly.NewRing(F)
+	h := R.New(kernel...)
+	d := R.Deg(h)
+	f := R.New(b, a, F.Zero(), F.One())
+	df, dh := R.Derivative(f), R.Derivative(h)
+	// Kohel's formula for the x-coordinate is N/h^2, where
+	// N = ((2d+1)x-2s1)h^2 - 2f'h'h + 4f(h'^2-hh''),
+	// and s1 is the sum of the roots of h.
+	s1 := F.Neg(h[d-1])
+	N := R.Mul(R.New(F.Neg(F.Add(s1, s1)), F.Elt(2*d+1)), R.Sqr(h))
+	N = R.Sub(N, R.Scale(R.Mul(R.Mul(df, dh), h), F.Elt(2)))
+	N = R.Add(N, R.Scale(R.Mul(f, R.Sub(R.Sqr(dh), R.Mul(h, R.Derivative(dh)))), F.Elt(4)))
+	// The y-coordinate is y(N/h^2)' = y(N'h-2Nh')/h^3.
+	yn := R.Sub(R.Mul(R.Derivative(N), h), R.Scale(R.Mul(N, dh), F.Elt(2)))
+	yd := R.Mul(R.Sqr(h), h)
+
+	l2 := F.Sqr(l)
+	l3 := F.Mul(l2, l)
+	m := &isogeny{E: E,
+		xn: R.Scale(N, l2), xd: R.Sqr(h),
+		yn: R.Scale(yn, l3), yd: yd,
+	}
+	// The codomain y^2=x^3+Ax+B of the normalized isogeny has A=a-5t and
+	// B=b-7w, where t and w are given by the power sums pk of the roots of h.
+	e := func(k int) GF.Elt { // elementary symmetric polynomials
+		if k > d {
+			return F.Zero()
+		} else if k%2 == 1 {
+			return F.Neg(h[d-k])
+		}
+		return h[d-k]
+	}
+	p1 := e(1)
+	p2 := F.Sub(F.Mul(e(1), p1), F.Add(e(2), e(2)))
+	p3 := F.Add(F.Sub(F.Mul(e(1), p2), F.Mul(e(2), p1)), F.Mul(F.Elt(3), e(3)))
+	t := F.Add(F.Mul(F.Elt(6), p2), F.Mul(F.Elt(2*d), a))
+	w := F.Add(F.Add(F.Mul(F.Elt(10), p3), F.Mul(F.Mul(F.Elt(6), a), p1)), F.Mul(F.Elt(4*d), b))
+	A := F.Mul(F.Sub(a, F.Mul(F.Elt(5), t)), F.Sqr(l2))
+	B := F.Mul(F.Sub(b, F.Mul(F.Elt(7), w)), F.Sqr(l3))
+	if !E.IsEqual(C.Weierstrass.New("", F, A, B, E.Order(), E.Cofactor())) {
+		panic("isogeny has a wrong codomain")
+	}
+	return m
+}
+
+// push evaluates the isogeny on (x,y), where points of the kernel are sent
+// to the identity.
+func (m *isogeny) push(x, y GF.Elt) C.Point {
+	F := m.E.Field()
+	R := poly.NewRing(F)
+	xd, yd := R.Eval(m.xd, x), R.Eval(m.yd, x)
+	if F.IsZero(xd) || F.IsZero(yd) {
+		return m.E.Identity()
+	}
+	return m.E.NewPoint(
+		F.Mul(R.Eval(m.xn, x), F.Inv(xd)),
+		F.Mul(y, F.Mul(R.Eval(m.yn, x), F.Inv(yd))))
+}
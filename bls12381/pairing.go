@@ -0,0 +1,102 @@
+package bls12381
+
+import (
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Gt is an element of the target group of the pairing, the subgroup of
+// order r of the multiplicative group of GF(p^12).
+type Gt struct{ f fp12 }
+
+// IsIdentity reports whether z is one.
+func (z *Gt) IsIdentity() bool { return z.f.isEqual(fp12One()) }
+
+// IsEqual reports whether z and w are equal.
+func (z *Gt) IsEqual(w *Gt) bool { return z.f.isEqual(w.f) }
+
+// Mul returns z*w.
+func (z *Gt) Mul(w *Gt) *Gt { return &Gt{z.f.mul(w.f)} }
+
+// Exp returns z^n.
+func (z *Gt) Exp(n *big.Int) *Gt {
+	if n.Sign() < 0 {
+		n = new(big.Int).Mod(n, r)
+	}
+	return &Gt{z.f.exp(n)}
+}
+
+// Pair returns the optimal ate pairing e(P,Q) of P in G1 and Q in G2. Its
+// value is the cube of the reduced pairing; see finalExp.
+func Pair(P, Q C.Point) *Gt { return PairProduct([]C.Point{P}, []C.Point{Q}) }
+
+// PairProduct returns the product of the pairings e(P[i],Q[i]) of points P[i]
+// in G1 and Q[i] in G2. It computes a single final exponentiation, so it is
+// cheaper than multiplying the pairings one by one.
+func PairProduct(P, Q []C.Point) *Gt {
+	if len(P) != len(Q) {
+		panic("pairing: mismatched number of points")
+	}
+	f := fp12One()
+	for i := range P {
+		if !P[i].IsIdentity() && !Q[i].IsIdentity() {
+			f = f.mul(miller(P[i], Q[i]))
+		}
+	}
+	return &Gt{finalExp(f)}
+}
+
+// miller returns the Miller function f_{|x|,Q}(P), conjugated since x is
+// negative. Q is kept in affine coordinates on E2, and P is embedded into the
+// twist through the map (x,y) -> (x/w^2, y/w^3).
+func miller(P, Q C.Point) fp12 {
+	F := fp2
+	xP, yP := fp2.Elt(P.X().Polynomial()[0]), fp2.Elt(P.Y().Polynomial()[0])
+	xQ, yQ := Q.X(), Q.Y()
+	xT, yT := xQ, yQ
+	f := fp12One()
+	for i := xAbs.BitLen() - 2; i >= 0; i-- {
+		// Doubling step, with slope l = 3xT^2/(2yT).
+		l := F.Mul(F.Mul(F.Elt(3), F.Sqr(xT)), F.Inv(F.Add(yT, yT)))
+		f = f.sqr().mul(line(l, xT, yT, xP, yP))
+		x := F.Sub(F.Sqr(l), F.Add(xT, xT))
+		xT, yT = x, F.Sub(F.Mul(l, F.Sub(xT, x)), yT)
+		if xAbs.Bit(i) != 0 {
+			// Addition step, with slope l = (yQ-yT)/(xQ-xT).
+			l = F.Mul(F.Sub(yQ, yT), F.Inv(F.Sub(xQ, xT)))
+			f = f.mul(line(l, xT, yT, xP, yP))
+			x = F.Sub(F.Sqr(l), F.Add(xT, xQ))
+			xT, yT = x, F.Sub(F.Mul(l, F.Sub(xT, x)), yT)
+		}
+	}
+	return f.conj()
+}
+
+// line evaluates at P the line through T with slope l, scaled by w^3:
+// (l*xT-yT) - l*xP*w^2 + yP*w^3.
+func line(l, xT, yT, xP, yP GF.Elt) fp12 {
+	F := fp2
+	a0 := fp6{F.Sub(F.Mul(l, xT), yT), F.Neg(F.Mul(l, xP)), F.Zero()}
+	a1 := fp6{F.Zero(), yP, F.Zero()}
+	return fp12{a0, a1}
+}
+
+// finalExp raises f to 3(p^12-1)/r, so the pairing is the cube of the
+// reduced pairing, which is also bilinear and non-degenerate. The hard part
+// uses 3(p^4-p^2+1)/r = (x-1)^2(x+p)(x^2+p^2-1)+3, as given by Hayashida,
+// Hayasaka and Teruya.
+func finalExp(f fp12) fp12 {
+	f = f.conj().mul(f.inv())            // f^(p^6-1)
+	f = f.frobenius().frobenius().mul(f) // f^(p^2+1)
+	// Now f is in the cyclotomic subgroup, where inverses are conjugates.
+	a := expX(f).mul(f.conj())                                     // f^(x-1)
+	a = expX(a).mul(a.conj())                                      // a^(x-1)
+	a = expX(a).mul(a.frobenius())                                 // a^(x+p)
+	a = expX(expX(a)).mul(a.frobenius().frobenius()).mul(a.conj()) // a^(x^2+p^2-1)
+	return a.mul(f.sqr().mul(f))
+}
+
+// expX returns f^x for f in the cyclotomic subgroup.
+func expX(f fp12) fp12 { return f.exp(xAbs).conj() }
@@ -0,0 +1,130 @@
+package bls12381
+
+import (
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// The target group lives in the tower of extensions
+//
+//	GF(p^6)  = GF(p^2)[v]/(v^3-xi), where xi=1+u,
+//	GF(p^12) = GF(p^6)[w]/(w^2-v),
+//
+// so that w^6=xi. Elements of GF(p^12) are written as a0+a1*w, and those of
+// GF(p^6) as c0+c1*v+c2*v^2.
+type fp6 [3]GF.Elt
+type fp12 [2]fp6
+
+var (
+	xi = fp2.Elt([]int{1, 1})
+	// gamma[k] = xi^(k(p-1)/6), so that the Frobenius map sends w^k to
+	// gamma[k]*w^k.
+	gamma = frobeniusConstants()
+)
+
+func frobeniusConstants() (g [6]GF.Elt) {
+	e := new(big.Int).Sub(p, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+	for k := range g {
+		g[k] = fp2.Exp(xi, new(big.Int).Mul(e, big.NewInt(int64(k))))
+	}
+	return
+}
+
+// conj returns the conjugate c0-c1*u of c0+c1*u, which is its image under
+// the Frobenius map.
+func conj(a GF.Elt) GF.Elt {
+	c := a.Polynomial()
+	return fp2.Elt([]*big.Int{c[0], c[1].Neg(c[1])})
+}
+
+func mulXi(a GF.Elt) GF.Elt { return fp2.Mul(a, xi) }
+
+func fp6Zero() fp6 { return fp6{fp2.Zero(), fp2.Zero(), fp2.Zero()} }
+func fp6One() fp6  { return fp6{fp2.One(), fp2.Zero(), fp2.Zero()} }
+
+func (a fp6) isZero() bool {
+	return fp2.IsZero(a[0]) && fp2.IsZero(a[1]) && fp2.IsZero(a[2])
+}
+func (a fp6) add(b fp6) fp6 {
+	return fp6{fp2.Add(a[0], b[0]), fp2.Add(a[1], b[1]), fp2.Add(a[2], b[2])}
+}
+func (a fp6) sub(b fp6) fp6 {
+	return fp6{fp2.Sub(a[0], b[0]), fp2.Sub(a[1], b[1]), fp2.Sub(a[2], b[2])}
+}
+func (a fp6) neg() fp6 { return fp6{fp2.Neg(a[0]), fp2.Neg(a[1]), fp2.Neg(a[2])} }
+
+// mulV returns a*v.
+func (a fp6) mulV() fp6 { return fp6{mulXi(a[2]), a[0], a[1]} }
+
+func (a fp6) mul(b fp6) fp6 {
+	F := fp2
+	t0 := F.Mul(a[0], b[0])
+	t1 := F.Mul(a[1], b[1])
+	t2 := F.Mul(a[2], b[2])
+	// c0 = t0 + xi(a1b2+a2b1)
+	c0 := F.Mul(F.Add(a[1], a[2]), F.Add(b[1], b[2]))
+	c0 = F.Add(t0, mulXi(F.Sub(c0, F.Add(t1, t2))))
+	// c1 = a0b1+a1b0 + xi*t2
+	c1 := F.Mul(F.Add(a[0], a[1]), F.Add(b[0], b[1]))
+	c1 = F.Add(F.Sub(c1, F.Add(t0, t1)), mulXi(t2))
+	// c2 = a0b2+a2b0 + t1
+	c2 := F.Mul(F.Add(a[0], a[2]), F.Add(b[0], b[2]))
+	c2 = F.Add(F.Sub(c2, F.Add(t0, t2)), t1)
+	return fp6{c0, c1, c2}
+}
+
+func (a fp6) inv() fp6 {
+	F := fp2
+	A := F.Sub(F.Sqr(a[0]), mulXi(F.Mul(a[1], a[2])))
+	B := F.Sub(mulXi(F.Sqr(a[2])), F.Mul(a[0], a[1]))
+	C := F.Sub(F.Sqr(a[1]), F.Mul(a[0], a[2]))
+	// N = a0A + xi(a2B+a1C) is the norm of a down to GF(p^2).
+	N := F.Add(F.Mul(a[0], A), mulXi(F.Add(F.Mul(a[2], B), F.Mul(a[1], C))))
+	N = F.Inv(N)
+	return fp6{F.Mul(A, N), F.Mul(B, N), F.Mul(C, N)}
+}
+
+func fp12One() fp12 { return fp12{fp6One(), fp6Zero()} }
+
+func (a fp12) isEqual(b fp12) bool {
+	return a[0].sub(b[0]).isZero() && a[1].sub(b[1]).isZero()
+}
+
+func (a fp12) mul(b fp12) fp12 {
+	t0 := a[0].mul(b[0])
+	t1 := a[1].mul(b[1])
+	c1 := a[0].add(a[1]).mul(b[0].add(b[1]))
+	return fp12{t0.add(t1.mulV()), c1.sub(t0.add(t1))}
+}
+
+func (a fp12) sqr() fp12 { return a.mul(a) }
+
+// conj returns a0-a1*w, which equals a^(p^6).
+func (a fp12) conj() fp12 { return fp12{a[0], a[1].neg()} }
+
+func (a fp12) inv() fp12 {
+	// 1/(a0+a1w) = (a0-a1w)/(a0^2-a1^2v)
+	t := a[0].mul(a[0]).sub(a[1].mul(a[1]).mulV()).inv()
+	return fp12{a[0].mul(t), a[1].mul(t).neg()}
+}
+
+// frobenius returns a^p. The coefficient of w^k is a[k%2][k/2].
+func (a fp12) frobenius() (z fp12) {
+	for k := 0; k < 6; k++ {
+		z[k%2][k/2] = fp2.Mul(conj(a[k%2][k/2]), gamma[k])
+	}
+	return z
+}
+
+func (a fp12) exp(n *big.Int) fp12 {
+	z := fp12One()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = z.sqr()
+		if n.Bit(i) != 0 {
+			z = z.mul(a)
+		}
+	}
+	return z
+}
@@ -0,0 +1,108 @@
+// Package commitment implements Pedersen commitments to scalars and to
+// vectors of scalars on any elliptic curve of the library.
+//
+// A commitment C=[m]G+[r]H to m with randomness r is perfectly hiding, and it
+// is binding as long as the discrete logarithm of H to the base G is unknown.
+// Here H, and the extra generators of vector commitments, are obtained by
+// hashing to the curve with the HashToElement of the group package, so that
+// nobody knows the discrete logarithms between any of them.
+package commitment
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+// Params are the public parameters of commitments on the subgroup of prime
+// order N of E.
+type Params struct {
+	E C.EllCurve
+	N *big.Int
+	// G are the generators of the committed values, where G[0] is the base
+	// point given to New.
+	G []C.Point
+	// H is the generator of the randomness.
+	H C.Point
+}
+
+// New returns parameters for commitments to vectors of up to n scalars. The
+// base point G of E must have prime order E.Order(). The other generators are
+// derived by hashing to E, as in group.FromCurve with SHA-512, under the
+// domain separation tag label, which should be unique to the application.
+func New(E C.EllCurve, G C.Point, n int, label []byte) (*Params, error) {
+	if n < 1 {
+		return nil, errors.New("at least one generator is required")
+	}
+	g, err := group.FromCurve(E, G, crypto.SHA512)
+	if err != nil {
+		return nil, err
+	}
+	pp := &Params{E: E, N: g.Order(), G: make([]C.Point, n)}
+	pp.G[0] = G.Copy()
+	pp.H = g.HashToElement([]byte("H"), label).Point()
+	for i := 1; i < n; i++ {
+		pp.G[i] = g.HashToElement([]byte(fmt.Sprintf("G%d", i)), label).Point()
+	}
+	return pp, nil
+}
+
+// RandomScalar returns a random scalar in [0,N), to be used as randomness.
+func (pp *Params) RandomScalar(rnd io.Reader) (*big.Int, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	return rand.Int(rnd, pp.N)
+}
+
+// Commit returns the commitment [m]G+[r]H to m.
+func (pp *Params) Commit(m, r *big.Int) C.Point {
+	return C.MultiScalarMult(pp.E, []C.Point{pp.G[0], pp.H}, []*big.Int{pp.reduce(m), pp.reduce(r)})
+}
+
+// CommitVector returns the commitment [m[0]]G[0]+...+[m[n-1]]G[n-1]+[r]H to
+// the vector m, which is computed with a multi-scalar multiplication.
+func (pp *Params) CommitVector(m []*big.Int, r *big.Int) (C.Point, error) {
+	if len(m) > len(pp.G) {
+		return nil, fmt.Errorf("vector is longer than the %v generators", len(pp.G))
+	}
+	P := append(append([]C.Point{}, pp.G[:len(m)]...), pp.H)
+	k := make([]*big.Int, 0, len(m)+1)
+	for _, mi := range m {
+		k = append(k, pp.reduce(mi))
+	}
+	return C.MultiScalarMult(pp.E, P, append(k, pp.reduce(r))), nil
+}
+
+// Verify reports whether c opens to m with randomness r.
+func (pp *Params) Verify(c C.Point, m, r *big.Int) bool {
+	return c.IsEqual(pp.Commit(m, r))
+}
+
+// VerifyVector reports whether c opens to the vector m with randomness r.
+func (pp *Params) VerifyVector(c C.Point, m []*big.Int, r *big.Int) bool {
+	d, err := pp.CommitVector(m, r)
+	return err == nil && c.IsEqual(d)
+}
+
+// Add returns a+b, which is a commitment to the sum of the committed values
+// under the sum of the randomness.
+func (pp *Params) Add(a, b C.Point) C.Point { return pp.E.Add(a, b) }
+
+// Sub returns a-b, which is a commitment to the difference of the committed
+// values under the difference of the randomness.
+func (pp *Params) Sub(a, b C.Point) C.Point { return pp.E.Add(a, pp.E.Neg(b)) }
+
+// Scale returns [k]a, which is a commitment to the committed values times k
+// under the randomness times k.
+func (pp *Params) Scale(a C.Point, k *big.Int) C.Point {
+	return pp.E.ScalarMult(a, pp.reduce(k))
+}
+
+func (pp *Params) reduce(k *big.Int) *big.Int { return new(big.Int).Mod(k, pp.N) }
@@ -0,0 +1,123 @@
+package commitment_test
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/commitment"
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	"github.com/armfazh/tozan-ecc/eddsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+func p256() (C.EllCurve, C.Point) {
+	p := elliptic.P256().Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	return E, E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy))
+}
+
+func TestCommitment(t *testing.T) {
+	label := []byte("commitment test")
+	for _, curve := range []func() (C.EllCurve, C.Point){p256, eddsa.Edwards25519} {
+		E, G := curve()
+		pp, err := commitment.New(E, G, 3, label)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rnd := func() *big.Int { k, _ := pp.RandomScalar(rand.Reader); return k }
+		m1, r1, m2, r2 := rnd(), rnd(), rnd(), rnd()
+		c1, c2 := pp.Commit(m1, r1), pp.Commit(m2, r2)
+		if !pp.Verify(c1, m1, r1) {
+			t.Fatalf("%v: valid opening rejected", E)
+		}
+		if pp.Verify(c1, m2, r1) || pp.Verify(c1, m1, r2) {
+			t.Fatalf("%v: invalid opening accepted", E)
+		}
+
+		// Homomorphic operations act on the committed values and randomness.
+		sum := func(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+		diff := func(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) }
+		k := big.NewInt(-7)
+		if !pp.Verify(pp.Add(c1, c2), sum(m1, m2), sum(r1, r2)) ||
+			!pp.Verify(pp.Sub(c1, c2), diff(m1, m2), diff(r1, r2)) ||
+			!pp.Verify(pp.Scale(c1, k), new(big.Int).Mul(k, m1), new(big.Int).Mul(k, r1)) {
+			t.Fatalf("%v: commitments are not homomorphic", E)
+		}
+
+		// Vector commitments extend commitments to scalars.
+		v1, v2 := []*big.Int{m1, m2, big.NewInt(1)}, []*big.Int{m2, m1, big.NewInt(2)}
+		cv1, err := pp.CommitVector(v1, r1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cv2, _ := pp.CommitVector(v2, r2)
+		if !pp.VerifyVector(cv1, v1, r1) || pp.VerifyVector(cv1, v2, r1) {
+			t.Fatalf("%v: wrong opening of vector commitment", E)
+		}
+		v3 := []*big.Int{sum(m1, m2), sum(m1, m2), big.NewInt(3)}
+		if !pp.VerifyVector(pp.Add(cv1, cv2), v3, sum(r1, r2)) {
+			t.Fatalf("%v: vector commitments are not homomorphic", E)
+		}
+		if c, _ := pp.CommitVector([]*big.Int{m1}, r1); !c.IsEqual(c1) {
+			t.Fatalf("%v: commitment to a vector of length one differs", E)
+		}
+		if _, err := pp.CommitVector(append(v1, m1), r1); err == nil {
+			t.Fatalf("%v: vector longer than the generators accepted", E)
+		}
+
+		// Generators are deterministic, distinct and depend on the label.
+		pp2, _ := commitment.New(E, G, 3, label)
+		pp3, _ := commitment.New(E, G, 3, []byte("other label"))
+		if !pp2.H.IsEqual(pp.H) || pp3.H.IsEqual(pp.H) {
+			t.Fatalf("%v: generators must be derived from the label", E)
+		}
+		all := append(append([]C.Point{}, pp.G...), pp.H)
+		for i, P := range all {
+			for _, Q := range all[i+1:] {
+				if P.IsEqual(Q) {
+					t.Fatalf("%v: repeated generators", E)
+				}
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	E, G := p256()
+	if _, err := commitment.New(E, G, 0, nil); err == nil {
+		t.Fatal("zero generators accepted")
+	}
+	if _, err := commitment.New(E, E.Identity(), 1, nil); err == nil {
+		t.Fatal("identity accepted as base point")
+	}
+	F := E.Field()
+	noOrder := C.Weierstrass.New("no order", F, F.Elt(-3), F.Elt(7), nil, nil)
+	if _, err := commitment.New(noOrder, G, 1, nil); err == nil {
+		t.Fatal("curve of unknown order accepted")
+	}
+	E0, g0, _ := toy.W0.New()
+	if _, err := commitment.New(E0, g0, 1, nil); err == nil {
+		t.Fatal("base point of composite order accepted")
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	// Generators are the outputs of hash_to_curve of RFC 9380.
+	E, G := p256()
+	label := []byte("commitment test")
+	pp, err := commitment.New(E, G, 2, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, _ := group.FromCurve(E, G, crypto.SHA512)
+	if !pp.H.IsEqual(g.HashToElement([]byte("H"), label).Point()) ||
+		!pp.G[1].IsEqual(g.HashToElement([]byte("G1"), label).Point()) {
+		t.Fatal("generators differ from hash_to_curve")
+	}
+}
@@ -58,3 +58,35 @@ func (p *infPoint) Copy() Point          { return &infPoint{} }
 func (p *infPoint) IsEqual(q Point) bool { _, t := q.(*infPoint); return t }
 func (p *infPoint) IsIdentity() bool     { return true }
 func (p *infPoint) IsTwoTorsion() bool   { return false }
+
+// prPoint is a point in projective coordinates (X:Y:Z).
+type prPoint struct{ x, y, z GF.Elt }
+
+func (p prPoint) String() string { return fmt.Sprintf("(%v: %v: %v)", p.x, p.y, p.z) }
+func (p *prPoint) copy() *prPoint {
+	return &prPoint{p.x.Copy(), p.y.Copy(), p.z.Copy()}
+}
+func (p *prPoint) isZero(f GF.Field) bool {
+	return f.IsZero(p.x) && f.IsZero(p.y) && f.IsZero(p.z)
+}
+func (p *prPoint) isEqual(f GF.Field, q *prPoint) bool {
+	return f.AreEqual(f.Mul(p.x, q.y), f.Mul(q.x, p.y)) &&
+		f.AreEqual(f.Mul(p.x, q.z), f.Mul(q.x, p.z)) &&
+		f.AreEqual(f.Mul(p.y, q.z), f.Mul(q.y, p.z))
+}
+
+// affine returns (X/Z, Y/Z), or nil coordinates if Z=0.
+func (p *prPoint) affine(f GF.Field) (x, y GF.Elt) {
+	if f.IsZero(p.z) {
+		return nil, nil
+	}
+	invZ := f.Inv(p.z)
+	return f.Mul(p.x, invZ), f.Mul(p.y, invZ)
+}
+
+// addViaMap calculates P+Q on the domain of m using the group law of its
+// codomain. It handles the inputs for which the formulas of a model are not
+// defined.
+func addViaMap(m RationalMap, p, q Point) Point {
+	return m.Pull(m.Codomain().Add(m.Push(p), m.Push(q)))
+}
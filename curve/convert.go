@@ -0,0 +1,150 @@
+package curve
+
+import (
+	"fmt"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// conversion is an edge of the graph of models; it lists the birational maps
+// from a curve to curves of another model.
+type conversion struct {
+	to   Model
+	maps func(EllCurve) []RationalMap
+}
+
+var conversions = map[Model][]conversion{
+	Weierstrass: {
+		{WeierstrassC, func(E EllCurve) (m []RationalMap) {
+			e := E.(*weCurve)
+			for _, alpha := range cubicRoots(e.F, e.A, e.B) {
+				m = append(m, e.toWeierstrassC(alpha))
+			}
+			return
+		}},
+		{WeierstrassGeneral, func(E EllCurve) []RationalMap { return []RationalMap{E.(*weCurve).ToWeierstrassGeneral()} }},
+	},
+	WeierstrassC: {
+		{Weierstrass, func(E EllCurve) []RationalMap { return []RationalMap{E.(*wcCurve).ToWeierstrass()} }},
+		{Montgomery, func(E EllCurve) []RationalMap { return oneOrNone(E.(*wcCurve).toMontgomery()) }},
+		{TwistedEdwards, func(E EllCurve) []RationalMap { return oneOrNone(E.(*wcCurve).toTwistedEdwards()) }},
+		{JacobiQuartic, func(E EllCurve) []RationalMap { return []RationalMap{E.(*wcCurve).toJacobiQuartic()} }},
+		{Huff, func(E EllCurve) []RationalMap { return oneOrNone(E.(*wcCurve).toHuff()) }},
+	},
+	Montgomery: {
+		{WeierstrassC, func(E EllCurve) []RationalMap { return []RationalMap{E.(*mtCurve).ToWeierstrassC()} }},
+	},
+	TwistedEdwards: {
+		{WeierstrassC, func(E EllCurve) []RationalMap { return []RationalMap{E.(*teCurve).ToWeierstrassC()} }},
+	},
+	WeierstrassGeneral: {
+		{Weierstrass, func(E EllCurve) []RationalMap { return []RationalMap{E.(*geCurve).ToWeierstrass()} }},
+	},
+	TwistedHessian: {
+		{GeneralizedHessian, func(E EllCurve) []RationalMap { return []RationalMap{E.(*thCurve).ToGeneralizedHessian()} }},
+	},
+	GeneralizedHessian: {
+		{TwistedHessian, func(E EllCurve) []RationalMap { return []RationalMap{E.(*ghCurve).ToTwistedHessian()} }},
+		{WeierstrassGeneral, func(E EllCurve) []RationalMap { return []RationalMap{E.(*ghCurve).ToWeierstrassGeneral()} }},
+	},
+	JacobiQuartic: {
+		{WeierstrassC, func(E EllCurve) []RationalMap { return []RationalMap{E.(*jqCurve).ToWeierstrassC()} }},
+		{JacobiIntersection, func(E EllCurve) []RationalMap { return oneOrNone(E.(*jqCurve).toJacobiIntersection()) }},
+	},
+	JacobiIntersection: {
+		{JacobiQuartic, func(E EllCurve) []RationalMap { return []RationalMap{E.(*jiCurve).ToJacobiQuartic()} }},
+	},
+	Huff: {
+		{WeierstrassC, func(E EllCurve) []RationalMap { return []RationalMap{E.(*hfCurve).ToWeierstrassC()} }},
+	},
+}
+
+func oneOrNone(m RationalMap, err error) []RationalMap {
+	if err != nil {
+		return nil
+	}
+	return []RationalMap{m}
+}
+
+// modelOf returns the model of an elliptic curve.
+func modelOf(E EllCurve) (Model, error) {
+	switch E.(type) {
+	case *weCurve:
+		return Weierstrass, nil
+	case *wcCurve:
+		return WeierstrassC, nil
+	case *teCurve:
+		return TwistedEdwards, nil
+	case *mtCurve:
+		return Montgomery, nil
+	case *geCurve:
+		return WeierstrassGeneral, nil
+	case *thCurve:
+		return TwistedHessian, nil
+	case *ghCurve:
+		return GeneralizedHessian, nil
+	case *jqCurve:
+		return JacobiQuartic, nil
+	case *jiCurve:
+		return JacobiIntersection, nil
+	case *hfCurve:
+		return Huff, nil
+	default:
+		return 0, fmt.Errorf("elliptic curve model not supported: %T", E)
+	}
+}
+
+// ConvertTo returns a curve of the target model together with a birational
+// map from E to it. The map is found by searching for the shortest chain of
+// supported conversions; an error is returned if no chain exists, for
+// example, when a conversion requires a square that the field lacks.
+func ConvertTo(E EllCurve, target Model) (EllCurve, RationalMap, error) {
+	source, err := modelOf(E)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source == target {
+		return E, &idMap{E}, nil
+	}
+
+	type path struct {
+		E      EllCurve
+		models []Model
+		maps   []RationalMap
+	}
+	visited := func(p *path, m Model) bool {
+		for _, mi := range p.models {
+			if mi == m {
+				return true
+			}
+		}
+		return false
+	}
+
+	queue := []*path{{E: E, models: []Model{source}}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, c := range conversions[p.models[len(p.models)-1]] {
+			if visited(p, c.to) {
+				continue
+			}
+			for _, m := range c.maps(p.E) {
+				maps := append(append([]RationalMap{}, p.maps...), m)
+				if c.to == target {
+					return m.Codomain(), Compose(maps...), nil
+				}
+				models := append(append([]Model{}, p.models...), c.to)
+				queue = append(queue, &path{E: m.Codomain(), models: models, maps: maps})
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no birational map from %v to %v", source, target)
+}
+
+// cubicRoots returns the roots of x^3+ax+b in F.
+func cubicRoots(F GF.Field, a, b GF.Elt) []GF.Elt {
+	R := poly.NewRing(F)
+	return R.Roots(R.New(b, a, F.Zero(), F.One()))
+}
@@ -0,0 +1,134 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// unreachable lists the models that are not birationally equivalent to a toy
+// curve over its base field, as the curve lacks the rational points of order
+// two or four that these models require.
+var unreachable = map[toy.ID][]C.Model{
+	toy.W0:    {C.WeierstrassC, C.Montgomery, C.TwistedEdwards, C.JacobiQuartic, C.JacobiIntersection, C.Huff},
+	toy.W1:    {C.Montgomery, C.TwistedEdwards, C.JacobiIntersection, C.Huff},
+	toy.W1ISO: {C.Montgomery, C.TwistedEdwards, C.JacobiIntersection, C.Huff},
+	toy.W2:    {C.Montgomery, C.TwistedEdwards, C.JacobiIntersection, C.Huff},
+	toy.W3:    {C.JacobiIntersection, C.Huff},
+	toy.W4:    {C.WeierstrassC, C.Montgomery, C.TwistedEdwards, C.JacobiQuartic, C.JacobiIntersection, C.Huff},
+	toy.WC0:   {C.Montgomery, C.TwistedEdwards, C.JacobiIntersection, C.Huff},
+	toy.M0:    {C.JacobiIntersection, C.Huff},
+	toy.M1:    {C.JacobiIntersection, C.Huff},
+	toy.E0:    {C.JacobiIntersection, C.Huff},
+	toy.E1:    {C.JacobiIntersection, C.Huff},
+}
+
+func isUnreachable(id toy.ID, model C.Model) bool {
+	for _, m := range unreachable[id] {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConvertTo(t *testing.T) {
+	models := []C.Model{C.Weierstrass, C.WeierstrassC, C.Montgomery, C.TwistedEdwards,
+		C.JacobiQuartic, C.JacobiIntersection, C.Huff}
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		for _, model := range models {
+			E1, m, err := C.ConvertTo(E, model)
+			if isUnreachable(curveID, model) {
+				if err == nil {
+					t.Fatalf("%v to %v: unexpected map found", curveID, model)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%v to %v: %v", curveID, model, err)
+			}
+			if !m.Domain().IsEqual(E) || !m.Codomain().IsEqual(E1) {
+				t.Fatalf("%v to %v: wrong domain or codomain", curveID, model)
+			}
+			P := E.Identity()
+			for i := 0; i < 10; i++ {
+				Q := m.Push(P)
+				if !E1.IsOnCurve(Q) {
+					t.Fatalf("%v to %v: point not in the curve: %v", curveID, model, Q)
+				}
+				if got := m.Pull(Q); !got.IsEqual(P) {
+					t.Fatalf("%v to %v:\ngot:  %v\nwant: %v", curveID, model, got, P)
+				}
+				got := E1.Add(Q, m.Push(g))
+				P = E.Add(P, g)
+				if want := m.Push(P); !got.IsEqual(want) {
+					t.Fatalf("%v to %v:\ngot:  %v\nwant: %v", curveID, model, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestConvertToAll(t *testing.T) {
+	// M0 is birationally equivalent to every supported model.
+	E, g, _ := toy.M0.New()
+	k := big.NewInt(7)
+	var maps []C.RationalMap
+	for _, model := range []C.Model{C.Weierstrass, C.WeierstrassC, C.TwistedEdwards, C.Montgomery} {
+		_, m, err := C.ConvertTo(E, model)
+		if err != nil {
+			t.Fatalf("M0 to %v: %v", model, err)
+		}
+		maps = append(maps, m)
+	}
+	// Walks E -> W -> E -> WC -> E -> TE -> E -> M.
+	var chain []C.RationalMap
+	for _, m := range maps {
+		chain = append(chain, m, C.Invert(m))
+	}
+	m := C.Compose(chain...)
+	want := E.ScalarMult(g, k)
+	got := m.Push(want)
+	if !got.IsEqual(want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+	if got = m.Pull(want); !got.IsEqual(want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestConvertPointsAtInfinity(t *testing.T) {
+	// As d=4 is a square, the twisted Edwards curve is not complete, and the
+	// points of order two of its Weierstrass model other than (0,0) are
+	// mapped to points at infinity.
+	F := GF.NewFp("53", 53)
+	E := C.TwistedEdwards.New("", F, F.Elt(1), F.Elt(4), nil, nil)
+	W, m, err := C.ConvertTo(E, C.WeierstrassC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	T := C.Torsion(W, 2)
+	if len(T) != 4 {
+		t.Fatalf("got %v points of order dividing two, want 4", len(T))
+	}
+	for _, Q := range T[1:] {
+		if F.IsZero(Q.X()) {
+			if got, want := m.Pull(Q), E.NewPoint(F.Zero(), F.Elt(-1)); !got.IsEqual(want) {
+				t.Fatalf("got: %v\nwant: %v", got, want)
+			}
+			continue
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%v has no image, but Pull didn't panic", Q)
+				}
+			}()
+			m.Pull(Q)
+		}()
+	}
+}
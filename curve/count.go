@@ -0,0 +1,535 @@
+package curve
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// CountPoints returns the number of points of E over its base field. It
+// enumerates the field for tiny fields, uses baby-step giant-step for fields
+// of at most 64 bits, and Schoof's algorithm otherwise.
+func CountPoints(E EllCurve) *big.Int {
+	switch n := E.Field().Order().BitLen(); {
+	case n <= 16:
+		return CountPointsNaive(E)
+	case n <= 64:
+		return CountPointsBSGS(E)
+	default:
+		return CountPointsSchoof(E)
+	}
+}
+
+// VerifyOrder returns an error if Order()*Cofactor() is not the number of
+// points of E.
+func VerifyOrder(E EllCurve) error {
+	n, err := curveOrder(E)
+	if err != nil {
+		return err
+	}
+	if c := CountPoints(E); n.Cmp(c) != 0 {
+		return fmt.Errorf("declared order %v*%v=%v differs from the number of points %v",
+			E.Order(), E.Cofactor(), n, c)
+	}
+	return nil
+}
+
+// shortWeierstrass returns a short Weierstrass curve isomorphic to E.
+func shortWeierstrass(E EllCurve) *weCurve {
+	if e, ok := E.(*weCurve); ok {
+		return e
+	}
+	W, _, err := ConvertTo(E, Weierstrass)
+	if err != nil {
+		panic(err)
+	}
+	return W.(*weCurve)
+}
+
+// CountPointsNaive counts the points of E by evaluating the Legendre symbol
+// of x^3+Ax+B for every x in the field. It is only practical for tiny fields.
+func CountPointsNaive(E EllCurve) *big.Int {
+	e := shortWeierstrass(E)
+	F := e.F
+	// The elements of the field are written as sum_i c_i*g^i, for
+	// 0 <= c_i < p, where g is a generator of the field over F_p.
+	p := F.P().Int64()
+	basis := make([]GF.Elt, F.Ext())
+	basis[0] = F.One()
+	for i := 1; i < len(basis); i++ {
+		basis[i] = F.Mul(basis[i-1], F.Generator())
+	}
+	c := make([]int64, len(basis))
+	n := int64(1)
+	for {
+		x := F.Zero()
+		for i := range c {
+			x = F.Add(x, F.Mul(F.Elt(c[i]), basis[i]))
+		}
+		if y2 := e.EvalRHS(x); F.IsZero(y2) {
+			n++
+		} else if F.IsSquare(y2) {
+			n += 2
+		}
+		i := 0
+		for ; i < len(c) && c[i] == p-1; i++ {
+			c[i] = 0
+		}
+		if i == len(c) {
+			return big.NewInt(n)
+		}
+		c[i]++
+	}
+}
+
+// hasseInterval returns the bounds of q+1-2sqrt(q) <= #E <= q+1+2sqrt(q).
+func hasseInterval(q *big.Int) (lo, hi *big.Int) {
+	s := new(big.Int).Lsh(q, 2)
+	s.Sqrt(s) // floor(2sqrt(q))
+	lo = new(big.Int).Add(q, big.NewInt(1))
+	hi = new(big.Int).Add(lo, s)
+	lo.Sub(lo, s)
+	return
+}
+
+// CountPointsBSGS counts the points of E using Mestre's baby-step giant-step
+// algorithm, which computes the orders of random points of E and of its
+// quadratic twist until only one value in the Hasse interval is compatible
+// with them. Fields with at most 229 elements are enumerated instead.
+func CountPointsBSGS(E EllCurve) *big.Int {
+	e := shortWeierstrass(E)
+	F := e.F
+	q := F.Order()
+	if q.Cmp(big.NewInt(229)) <= 0 {
+		return CountPointsNaive(E)
+	}
+	var d GF.Elt
+	for d = F.Rand(rand.Reader); F.IsZero(d) || F.IsSquare(d); d = F.Rand(rand.Reader) {
+	}
+	dd := F.Sqr(d)
+	et := &weCurve{&params{Name: "twist", F: F, A: F.Mul(e.A, dd), B: F.Mul(e.B, F.Mul(dd, d))}}
+
+	lo, hi := hasseInterval(q)
+	sum := new(big.Int).Add(q, big.NewInt(1)) // #E+#E' = 2(q+1)
+	sum.Lsh(sum, 1)
+	loT, hiT := new(big.Int).Sub(sum, hi), new(big.Int).Sub(sum, lo)
+
+	L, LT := big.NewInt(1), big.NewInt(1)
+	for {
+		L = lcm(L, pointOrder(e, randomPoint(e), lo, hi))
+		LT = lcm(LT, pointOrder(et, randomPoint(et), loT, hiT))
+
+		// Solves N = 0 mod L and N = sum mod LT.
+		g := new(big.Int).GCD(nil, nil, L, LT)
+		c := new(big.Int).Mod(sum, LT)
+		if new(big.Int).Mod(c, g).Sign() != 0 {
+			panic(errors.New("inconsistent point orders"))
+		}
+		m := new(big.Int).Quo(LT, g)
+		k := new(big.Int).Quo(c, g)
+		if m.Cmp(big.NewInt(1)) != 0 {
+			k.Mul(k, new(big.Int).ModInverse(new(big.Int).Quo(L, g), m))
+		}
+		k.Mod(k, m)
+		N := new(big.Int).Mul(L, k)
+		step := new(big.Int).Mul(L, m)
+		// First N >= lo.
+		t := new(big.Int).Sub(lo, N)
+		if t.Sign() > 0 {
+			t.Add(t, step).Sub(t, big.NewInt(1)).Quo(t, step)
+			N.Add(N, t.Mul(t, step))
+		} else {
+			N.Sub(N, t.Quo(t.Neg(t), step).Mul(t, step))
+		}
+		if N.Cmp(hi) <= 0 && new(big.Int).Add(N, step).Cmp(hi) > 0 {
+			return N
+		}
+	}
+}
+
+func lcm(a, b *big.Int) *big.Int {
+	g := new(big.Int).GCD(nil, nil, a, b)
+	return new(big.Int).Mul(a, new(big.Int).Quo(b, g))
+}
+
+// pointOrder returns the order of P, knowing that it divides a number in the
+// interval [lo, hi].
+func pointOrder(e *weCurve, P Point, lo, hi *big.Int) *big.Int {
+	n, err := orderOf(e, P, bsgsMultiple(e, P, lo, hi))
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// bsgsMultiple returns N in [lo, hi] such that [N]P is the identity.
+func bsgsMultiple(e *weCurve, P Point, lo, hi *big.Int) *big.Int {
+	m := new(big.Int).Sub(hi, lo)
+	m.Sqrt(m).Add(m, big.NewInt(1))
+	M := int(m.Int64())
+
+	// Baby steps: jP for 1 <= j <= M, indexed by x-coordinate.
+	baby := make(map[string]int, M)
+	jP := e.Identity()
+	steps := make([]Point, M+1)
+	for j := 1; j <= M; j++ {
+		jP = e.Add(jP, P)
+		steps[j] = jP
+		if jP.IsIdentity() {
+			return pointOrderMultiple(big.NewInt(int64(j)), lo)
+		}
+		baby[fmt.Sprint(jP.X())] = j
+	}
+	// Giant steps: lo*P + i*M*P.
+	MP := e.ScalarMult(P, m)
+	Q := e.ScalarMult(P, lo)
+	N := new(big.Int).Set(lo)
+	for N.Cmp(hi) <= 0 {
+		if Q.IsIdentity() {
+			return N
+		}
+		if j, ok := baby[fmt.Sprint(Q.X())]; ok {
+			n := big.NewInt(int64(j))
+			if Q.IsEqual(steps[j]) {
+				n.Sub(N, n)
+			} else {
+				n.Add(N, n)
+			}
+			if n.Cmp(lo) >= 0 && n.Cmp(hi) <= 0 {
+				return n
+			}
+		}
+		Q = e.Add(Q, MP)
+		N.Add(N, m)
+	}
+	panic(errors.New("no multiple of the order of the point found in the interval"))
+}
+
+// pointOrderMultiple returns the least multiple of n which is at least lo.
+func pointOrderMultiple(n, lo *big.Int) *big.Int {
+	k := new(big.Int).Add(lo, n)
+	k.Sub(k, big.NewInt(1)).Quo(k, n)
+	return k.Mul(k, n)
+}
+
+// schoofMatchBits is the logarithm of the number of candidates for the
+// trace of Frobenius, from which CountPointsSchoof tells them apart with
+// baby-step giant-step instead of using more primes.
+const schoofMatchBits = 36
+
+// CountPointsSchoof counts the points of E using Schoof's algorithm. It
+// computes the trace of Frobenius t modulo small primes l, by finding the
+// action of Frobenius on the l-torsion, until their product M exceeds
+// 4sqrt(q). Once fewer than 2^36 values of t are left in the Hasse interval,
+// it tries to find the one that is compatible with the orders of random
+// points using baby-step giant-step, which saves the largest primes. The
+// primes are processed concurrently.
+func CountPointsSchoof(E EllCurve) *big.Int {
+	e := shortWeierstrass(E)
+	F := e.F
+	q := F.Order()
+	psi := newDivPolys(e)
+	R := psi.R
+
+	bound := new(big.Int).Sqrt(q)
+	bound.Add(bound, big.NewInt(1)).Lsh(bound, 2) // 4sqrt(q)
+
+	// t mod 2 is zero iff the curve has a point of order 2.
+	t := big.NewInt(1)
+	xq := R.NewModulus(psi.f).ExpMod(R.X(), q)
+	if R.Deg(R.Gcd(R.Sub(xq, R.X()), psi.f)) > 0 {
+		t.SetInt64(0)
+	}
+	M := big.NewInt(2)
+	l := int64(1)
+	nextPrime := func() int64 {
+		for l += 2; ; l += 2 {
+			if L := big.NewInt(l); L.ProbablyPrime(10) && L.Cmp(F.P()) != 0 {
+				return l
+			}
+		}
+	}
+	// Chinese remainder: t = t + M*((tl-t)/M mod l).
+	addTraces := func(ls, tl []int64) {
+		for i := range ls {
+			L := big.NewInt(ls[i])
+			k := new(big.Int).Sub(big.NewInt(tl[i]), t)
+			k.Mul(k, new(big.Int).ModInverse(M, L)).Mod(k, L)
+			t.Add(t, k.Mul(k, M))
+			M.Mul(M, L)
+		}
+	}
+
+	var ls []int64
+	for N := new(big.Int).Set(M); N.Cmp(bound) <= 0 &&
+		new(big.Int).Quo(bound, N).BitLen() > schoofMatchBits; {
+		ls = append(ls, nextPrime())
+		N.Mul(N, big.NewInt(l))
+	}
+	addTraces(ls, schoofTraces(e, psi, ls))
+	for M.Cmp(bound) <= 0 {
+		if n := schoofMatch(e, t, M); n != nil {
+			return n
+		}
+		ls = []int64{nextPrime()}
+		addTraces(ls, schoofTraces(e, psi, ls))
+	}
+	if h := new(big.Int).Rsh(M, 1); t.Cmp(h) > 0 {
+		t.Sub(t, M)
+	}
+	n := new(big.Int).Add(q, big.NewInt(1))
+	return n.Sub(n, t)
+}
+
+// schoofTraces returns the traces of Frobenius modulo the odd primes ls,
+// using as many goroutines as processors.
+func schoofTraces(e *weCurve, psi *divPolys, ls []int64) []int64 {
+	// As divPolys is not safe for concurrent use, the division polynomials
+	// are found beforehand.
+	h := make([]poly.Poly, len(ls))
+	for i := range ls {
+		h[i] = psi.get(int(ls[i]))
+	}
+	// The largest primes, which take the longest, are started first.
+	work := make(chan int, len(ls))
+	for i := len(ls) - 1; i >= 0; i-- {
+		work <- i
+	}
+	close(work)
+	tl := make([]int64, len(ls))
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU() && w < len(ls); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				tl[i] = schoofPrime(e, psi.f, h[i], ls[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return tl
+}
+
+// schoofMatch returns the number of points N=q+1-t, knowing t mod M, if it is
+// the only value in the Hasse interval such that [N]P is the identity for a
+// few random points P. Otherwise, it returns nil.
+func schoofMatch(e *weCurve, t, M *big.Int) *big.Int {
+	// The candidates are N0+kM in [lo, hi], for 0 <= k < K.
+	lo, hi := hasseInterval(e.F.Order())
+	N0 := new(big.Int).Add(e.F.Order(), big.NewInt(1))
+	N0.Sub(N0, t).Sub(N0, lo).Mod(N0, M).Add(N0, lo)
+	K := new(big.Int).Sub(hi, N0)
+	K.Quo(K, M).Add(K, big.NewInt(1))
+
+	const tries, maxCandidates = 8, 16
+	var candidates []*big.Int
+	for i := 0; i < tries; i++ {
+		P := randomPoint(e)
+		if candidates == nil {
+			candidates = bsgsProgression(e, P, N0, M, K, maxCandidates)
+		} else {
+			next := candidates[:0]
+			for _, N := range candidates {
+				if e.ScalarMult(P, N).IsIdentity() {
+					next = append(next, N)
+				}
+			}
+			candidates = next
+		}
+		if len(candidates) == 1 {
+			return candidates[0]
+		}
+	}
+	return nil
+}
+
+// bsgsProgression returns the values N=N0+kM, for 0 <= k < K, such that [N]P
+// is the identity, or nil if there are more than max of them.
+func bsgsProgression(e *weCurve, P Point, N0, M, K *big.Int, max int) []*big.Int {
+	m := new(big.Int).Sqrt(K)
+	m.Add(m, big.NewInt(1))
+	steps := int(m.Int64())
+
+	// Baby steps: [jM]P for 0 <= j < m, indexed by x-coordinate.
+	MP := e.ScalarMult(P, M)
+	baby := make(map[string][]int, steps)
+	jMP := e.Identity()
+	for j := 0; j < steps; j++ {
+		if !jMP.IsIdentity() {
+			x := fmt.Sprint(jMP.X())
+			baby[x] = append(baby[x], j)
+		}
+		jMP = e.Add(jMP, MP)
+	}
+	// Giant steps: [N0+imM]P, which matches -[jM]P when [N0+(im+j)M]P is the
+	// identity.
+	var found []*big.Int
+	mMP := e.ScalarMult(MP, m)
+	Q := e.ScalarMult(P, N0)
+	N := new(big.Int).Set(N0)
+	step := new(big.Int).Mul(m, M)
+	last := new(big.Int).Mul(K, M)
+	last.Add(last, N0)
+	add := func(N *big.Int, j int) bool {
+		n := new(big.Int).Add(N, new(big.Int).Mul(big.NewInt(int64(j)), M))
+		if n.Cmp(last) >= 0 {
+			return true
+		}
+		found = append(found, n)
+		return len(found) <= max
+	}
+	for N.Cmp(last) < 0 {
+		if Q.IsIdentity() && !add(N, 0) {
+			return nil
+		}
+		if !Q.IsIdentity() {
+			for _, j := range baby[fmt.Sprint(Q.X())] {
+				if R := e.ScalarMult(MP, big.NewInt(int64(j))); R.IsEqual(e.Neg(Q)) && !add(N, j) {
+					return nil
+				}
+			}
+		}
+		Q = e.Add(Q, mMP)
+		N.Add(N, step)
+	}
+	return found
+}
+
+// schoofPrime returns the trace of Frobenius modulo the odd prime l, where
+// the curve is y^2=f(x) and h is its l-th division polynomial.
+func schoofPrime(e *weCurve, f, h poly.Poly, l int64) int64 {
+	R, F := poly.NewRing(e.F), e.F
+	q := F.Order()
+	s := &schoofRing{R: R, M: R.NewModulus(R.Monic(h))}
+	f = s.M.Mod(f)
+	ff := s.M.SqrMod(f)
+
+	// The points (a(x), b(x)y) on y^2=f(x) are mapped to the points
+	// (fa, f^2b) on v^2=u^3+Af^2u+Bf^3, so that no y is left.
+	s.A = s.M.Mod(R.Scale(ff, e.A))
+	P := &jacobian{X: s.M.Mod(R.Mul(f, R.X())), Y: ff, Z: R.One()}
+
+	// Frobenius: (x^q, f^((q-1)/2)y), and its square (x^q^2, f^((q^2-1)/2)y),
+	// which is found by composition, as raising to q is a ring homomorphism.
+	xq := s.M.ExpMod(R.X(), q)
+	yq := s.M.ExpMod(f, new(big.Int).Rsh(q, 1))
+	c := s.M.Compose(xq, xq, yq)
+	xq2, yq2 := c[0], s.M.MulMod(c[1], yq)
+	pi := &jacobian{X: s.M.MulMod(f, xq), Y: s.M.MulMod(ff, yq), Z: R.One()}
+	pi2 := &jacobian{X: s.M.MulMod(f, xq2), Y: s.M.MulMod(ff, yq2), Z: R.One()}
+
+	// Finds tau such that pi^2+[q]P = [tau]pi.
+	qbar := new(big.Int).Mod(q, big.NewInt(l)).Int64()
+	S, ok := s.addOrZero(pi2, s.scalarMult(P, qbar))
+	if !ok {
+		return 0
+	}
+	ZZ := s.M.SqrMod(S.Z)
+	T := pi
+	for tau := int64(1); tau <= l/2; tau++ {
+		TT := s.M.SqrMod(T.Z)
+		if R.AreEqual(s.M.MulMod(S.X, TT), s.M.MulMod(T.X, ZZ)) {
+			if R.AreEqual(s.M.MulMod(S.Y, s.M.MulMod(TT, T.Z)), s.M.MulMod(T.Y, s.M.MulMod(ZZ, S.Z))) {
+				return tau
+			}
+			return l - tau
+		}
+		if tau == 1 {
+			T = s.double(pi)
+		} else {
+			T = s.add(T, pi)
+		}
+	}
+	panic(errors.New("trace of Frobenius not found"))
+}
+
+// schoofRing performs arithmetic on the curve v^2=u^3+Au+B, whose
+// coordinates are in F[x]/(h(x)), where h is the l-th division polynomial.
+// As h may be reducible, the ring may have zero divisors; a sum of points
+// that are equal only modulo a factor of h gives zero coordinates modulo
+// that factor, which the comparisons of schoofPrime are not affected by.
+type schoofRing struct {
+	R poly.Ring
+	M *poly.Modulus
+	A poly.Poly
+}
+
+// jacobian is the point (X/Z^2, Y/Z^3).
+type jacobian struct{ X, Y, Z poly.Poly }
+
+func (s *schoofRing) scalarMult(P *jacobian, k int64) *jacobian {
+	var Q *jacobian
+	for i := 62; i >= 0; i-- {
+		if Q != nil {
+			Q = s.double(Q)
+		}
+		if (k>>uint(i))&1 != 0 {
+			if Q == nil {
+				Q = P
+			} else {
+				Q = s.add(Q, P)
+			}
+		}
+	}
+	return Q
+}
+
+// double returns 2P, for P of odd order.
+func (s *schoofRing) double(P *jacobian) *jacobian {
+	R, M := s.R, s.M
+	F := R.F
+	XX := M.SqrMod(P.X)
+	YY := M.SqrMod(P.Y)
+	ZZ := M.SqrMod(P.Z)
+	S := R.Scale(M.MulMod(P.X, YY), F.Elt(4))
+	W := R.Add(R.Scale(XX, F.Elt(3)), M.MulMod(s.A, M.SqrMod(ZZ)))
+	X := R.Sub(M.SqrMod(W), R.Add(S, S))
+	Y := R.Sub(M.MulMod(W, R.Sub(S, X)), R.Scale(M.SqrMod(YY), F.Elt(8)))
+	Z := R.Scale(M.MulMod(P.Y, P.Z), F.Elt(2))
+	return &jacobian{X: X, Y: Y, Z: Z}
+}
+
+// add returns P+Q, for Q with Z=1, and P different from Q and -Q.
+func (s *schoofRing) add(P, Q *jacobian) *jacobian {
+	R, M := s.R, s.M
+	ZZ := M.SqrMod(P.Z)
+	H := R.Sub(M.MulMod(Q.X, ZZ), P.X)
+	r := R.Sub(M.MulMod(Q.Y, M.MulMod(ZZ, P.Z)), P.Y)
+	return s.sum(P, H, r)
+}
+
+// sum returns P+Q, given H=U2-U1 and r=S2-S1 of the addition formula.
+func (s *schoofRing) sum(P *jacobian, H, r poly.Poly) *jacobian {
+	R, M := s.R, s.M
+	HH := M.SqrMod(H)
+	HHH := M.MulMod(H, HH)
+	V := M.MulMod(P.X, HH)
+	X := R.Sub(R.Sub(M.SqrMod(r), HHH), R.Add(V, V))
+	Y := R.Sub(M.MulMod(r, R.Sub(V, X)), M.MulMod(P.Y, HHH))
+	Z := M.MulMod(P.Z, H)
+	return &jacobian{X: X, Y: Y, Z: Z}
+}
+
+// addOrZero returns P+Q, for P with Z=1, and false if P+Q is the identity
+// modulo some factor of h.
+func (s *schoofRing) addOrZero(P, Q *jacobian) (*jacobian, bool) {
+	R, M := s.R, s.M
+	ZZ := M.SqrMod(Q.Z)
+	H := R.Sub(M.MulMod(P.X, ZZ), Q.X)
+	r := R.Sub(M.MulMod(P.Y, M.MulMod(ZZ, Q.Z)), Q.Y)
+	if !R.IsZero(H) {
+		return s.sum(Q, H, r), true
+	}
+	// P and Q have the same x-coordinate.
+	if R.IsZero(r) {
+		return s.double(P), true
+	}
+	return nil, false
+}
@@ -0,0 +1,115 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestCountPoints(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		want := new(big.Int).Mul(E.Order(), E.Cofactor())
+		for _, count := range []func(C.EllCurve) *big.Int{
+			C.CountPoints,
+			C.CountPointsNaive,
+			C.CountPointsBSGS,
+			C.CountPointsSchoof,
+		} {
+			if got := count(E); got.Cmp(want) != 0 {
+				t.Fatalf("%v:\ngot:  %v\nwant: %v", curveID, got, want)
+			}
+		}
+		if err := C.VerifyOrder(E); err != nil {
+			t.Fatalf("%v: %v", curveID, err)
+		}
+	}
+}
+
+func TestTraceModL(t *testing.T) {
+	curves := []C.EllCurve{}
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		curves = append(curves, E)
+	}
+	F := GF.NewFp("10007", 10007)
+	curves = append(curves, C.Weierstrass.New("", F, F.Elt(3), F.Elt(5), nil, nil))
+	for _, E := range curves {
+		q := E.Field().Order()
+		tr := new(big.Int).Add(q, big.NewInt(1))
+		tr.Sub(tr, C.CountPointsNaive(E))
+		for _, l := range []int64{3, 5, 7, 11, 13} {
+			if q.Int64()%l == 0 {
+				continue
+			}
+			want := new(big.Int).Mod(tr, big.NewInt(l)).Int64()
+			if got := C.TraceModL(E, l); got != want {
+				t.Fatalf("%v mod %v:\ngot:  %v\nwant: %v", E, l, got, want)
+			}
+		}
+	}
+}
+
+func TestCountPointsMedium(t *testing.T) {
+	F := GF.NewFp("10007", 10007)
+	E := C.Weierstrass.New("", F, F.Elt(3), F.Elt(5), nil, nil)
+	want := C.CountPointsNaive(E)
+	if got := C.CountPointsBSGS(E); got.Cmp(want) != 0 {
+		t.Fatalf("got:  %v\nwant: %v", got, want)
+	}
+	if got := C.CountPointsSchoof(E); got.Cmp(want) != 0 {
+		t.Fatalf("got:  %v\nwant: %v", got, want)
+	}
+
+	F = GF.NewFp("1000003", 1000003)
+	E = C.Weierstrass.New("", F, F.Elt(-3), F.Elt(7), nil, nil)
+	want = C.CountPointsBSGS(E)
+	if got := C.CountPointsSchoof(E); got.Cmp(want) != 0 {
+		t.Fatalf("got:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestVerifyOrder(t *testing.T) {
+	F := GF.NewFp("53", 53)
+	E := C.Weierstrass.New("", F, F.Elt(3), F.Elt(2), big.NewInt(51), big.NewInt(3))
+	if err := C.VerifyOrder(E); err == nil {
+		t.Fatal("wrong order was accepted")
+	}
+}
+
+func TestCountPointsLarge(t *testing.T) {
+	for _, v := range []struct {
+		name, p, a, b, r string
+		long             bool
+	}{
+		{
+			name: "secp112r1",
+			p:    "0xdb7c2abf62e35e668076bead208b",
+			a:    "0xdb7c2abf62e35e668076bead2088",
+			b:    "0x659ef8ba043916eede8911702b22",
+			r:    "0xdb7c2abf62e35e7628dfac6561c5",
+		},
+		{
+			name: "secp128r1",
+			p:    "0xfffffffdffffffffffffffffffffffff",
+			a:    "0xfffffffdfffffffffffffffffffffffc",
+			b:    "0xe87579c11079f43dd824993c2cee5ed3",
+			r:    "0xfffffffe0000000075a30d1b9038a115",
+			long: true,
+		},
+	} {
+		if v.long && testing.Short() {
+			t.Logf("skipping %v in short mode", v.name)
+			continue
+		}
+		F := GF.NewFp(v.name, v.p)
+		r, _ := new(big.Int).SetString(v.r, 0)
+		E := C.Weierstrass.New(v.name, F, F.Elt(v.a), F.Elt(v.b), r, big.NewInt(1))
+		if err := C.VerifyOrder(E); err != nil {
+			t.Fatalf("%v: %v", v.name, err)
+		}
+	}
+}
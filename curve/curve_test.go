@@ -1,16 +1,18 @@
 package curve_test
 
 import (
+	"math/big"
 	"testing"
 
 	C "github.com/armfazh/tozan-ecc/curve"
 	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
 )
 
 func TestCurves(t *testing.T) {
 	for _, curveID := range toy.Curves {
 		e, g, _ := curveID.New()
-		order := e.Order().Uint64()
+		order := new(big.Int).Mul(e.Order(), e.Cofactor()).Uint64()
 		T := make([]C.Point, order)
 		T[0] = e.Identity()
 		for i := uint64(1); i < order; i++ {
@@ -30,6 +32,41 @@ func TestCurves(t *testing.T) {
 	}
 }
 
+func TestScalarMult(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		e, g, _ := curveID.New()
+		order := new(big.Int).Mul(e.Order(), e.Cofactor()).Uint64()
+		want := e.Identity()
+		for i := uint64(0); i <= order; i++ {
+			got := e.ScalarMult(g, new(big.Int).SetUint64(i))
+			if !got.IsEqual(want) {
+				t.Fatalf("%v: got: %v\nwant: %v\nk: %v", curveID, got, want, i)
+			}
+			want = e.Add(want, g)
+		}
+	}
+}
+
+func TestWeierstrassCAsMap(t *testing.T) {
+	E, g, _ := toy.WC0.New()
+	m := E.(C.RationalMap)
+	W := E.(C.WC).ToWeierstrass().Codomain()
+	if !m.Domain().IsEqual(E) || !m.Codomain().IsEqual(W) {
+		t.Fatal("wrong domain or codomain")
+	}
+	P := E.Identity()
+	for i := 0; i < 10; i++ {
+		Q := m.Push(P)
+		if !W.IsOnCurve(Q) {
+			t.Fatalf("point not in the curve: %v", Q)
+		}
+		if got := m.Pull(Q); !got.IsEqual(P) {
+			t.Fatalf("got: %v\nwant: %v", got, P)
+		}
+		P = E.Add(P, g)
+	}
+}
+
 func BenchmarkCurve(b *testing.B) {
 	E, P, _ := toy.W0.New()
 	Q := E.Double(P)
@@ -44,3 +81,46 @@ func BenchmarkCurve(b *testing.B) {
 		}
 	})
 }
+
+func TestWeierstrassGeneral(t *testing.T) {
+	F := GF.NewFp("53", 53)
+	E := C.NewWeierstrassGeneral("WG0", F, F.Elt(1), F.Elt(2), F.Elt(3), F.Elt(5), F.Elt(7), nil, nil)
+	m := E.(C.WG).ToWeierstrass()
+	W := m.Codomain()
+
+	points := []C.Point{E.Identity()}
+	for i := 0; i < 53; i++ {
+		for j := 0; j < 53; j++ {
+			x, y := F.Elt(i), F.Elt(j)
+			func() {
+				defer func() { _ = recover() }()
+				points = append(points, E.NewPoint(x, y))
+			}()
+		}
+	}
+	for _, P := range points {
+		if got := m.Pull(m.Push(P)); !got.IsEqual(P) {
+			t.Fatalf("got: %v\nwant: %v", got, P)
+		}
+		for _, Q := range points {
+			R := E.Add(P, Q)
+			if !E.IsOnCurve(R) {
+				t.Fatalf("point not in the curve: %v\n", R)
+			}
+			got := m.Push(R)
+			want := W.Add(m.Push(P), m.Push(Q))
+			if !got.IsEqual(want) {
+				t.Fatalf("got: %v\nwant: %v", got, want)
+			}
+		}
+	}
+
+	// j-invariant of y^2=x^3+Ax+B is 1728*4A^3/(4A^3+27B^2).
+	A, B := W.(C.W).A, W.(C.W).B
+	t0 := F.Mul(F.Elt(4), F.Mul(F.Sqr(A), A))
+	t1 := F.Mul(F.Elt(27), F.Sqr(B))
+	want := F.Mul(F.Mul(F.Elt(1728), t0), F.Inv(F.Add(t0, t1)))
+	if got := E.(C.WG).JInvariant(); !F.AreEqual(got, want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+package curve
+
+import (
+	"errors"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// divPolys computes the division polynomials of a short Weierstrass curve.
+// As the polynomials of even index are multiples of y, it stores g_n=psi_n
+// for odd n and g_n=psi_n/y for even n, so that all of them are in F[x].
+type divPolys struct {
+	R poly.Ring
+	f poly.Poly // x^3+Ax+B
+	g map[int]poly.Poly
+}
+
+func newDivPolys(e *weCurve) *divPolys {
+	F := e.F
+	R := poly.NewRing(F)
+	z := F.Zero()
+	A, B := e.A, e.B
+	AA := F.Sqr(A)
+	d := &divPolys{R: R, f: R.New(B, A, z, F.One()), g: make(map[int]poly.Poly)}
+	d.g[0] = R.Zero()
+	d.g[1] = R.One()
+	d.g[2] = R.Const(F.Elt(2))
+	// g3 = 3x^4+6Ax^2+12Bx-A^2
+	d.g[3] = R.New(F.Neg(AA), F.Mul(F.Elt(12), B), F.Mul(F.Elt(6), A), z, F.Elt(3))
+	// g4 = 4(x^6+5Ax^4+20Bx^3-5A^2x^2-4ABx-8B^2-A^3)
+	d.g[4] = R.Scale(R.New(
+		F.Neg(F.Add(F.Mul(F.Elt(8), F.Sqr(B)), F.Mul(AA, A))),
+		F.Neg(F.Mul(F.Elt(4), F.Mul(A, B))),
+		F.Neg(F.Mul(F.Elt(5), AA)),
+		F.Mul(F.Elt(20), B),
+		F.Mul(F.Elt(5), A),
+		z,
+		F.One()), F.Elt(4))
+	return d
+}
+
+// get returns g_n, where g_{-n}=-g_n.
+func (d *divPolys) get(n int) poly.Poly {
+	if g, ok := d.g[n]; ok {
+		return g
+	}
+	if n < 0 {
+		return d.R.Neg(d.get(-n))
+	}
+	R := d.R
+	m := n / 2
+	var g poly.Poly
+	if n%2 == 1 {
+		// psi_{2m+1} = psi_{m+2}psi_m^3 - psi_{m-1}psi_{m+1}^3
+		t0 := R.Mul(d.get(m+2), R.Mul(R.Sqr(d.get(m)), d.get(m)))
+		t1 := R.Mul(d.get(m-1), R.Mul(R.Sqr(d.get(m+1)), d.get(m+1)))
+		if ff := R.Sqr(d.f); m%2 == 0 {
+			t0 = R.Mul(t0, ff)
+		} else {
+			t1 = R.Mul(t1, ff)
+		}
+		g = R.Sub(t0, t1)
+	} else {
+		// psi_{2m} = psi_m(psi_{m+2}psi_{m-1}^2 - psi_{m-2}psi_{m+1}^2)/(2y)
+		t0 := R.Mul(d.get(m+2), R.Sqr(d.get(m-1)))
+		t1 := R.Mul(d.get(m-2), R.Sqr(d.get(m+1)))
+		g = R.Mul(d.get(m), R.Sub(t0, t1))
+		g = R.Scale(g, R.F.Inv(R.F.Elt(2)))
+	}
+	d.g[n] = g
+	return g
+}
+
+// DivisionPolynomials are the division polynomials of an elliptic curve. They
+// are computed on a short Weierstrass curve y^2=f(x) isomorphic to it, so
+// that they are polynomials in x, except for a factor y in psi_n for even n.
+type DivisionPolynomials struct {
+	d *divPolys
+	m RationalMap
+}
+
+// NewDivisionPolynomials returns the division polynomials of E.
+func NewDivisionPolynomials(E EllCurve) *DivisionPolynomials {
+	e, m := weierstrassOf(E)
+	return &DivisionPolynomials{d: newDivPolys(e), m: m}
+}
+
+// Ring returns the ring of polynomials in x.
+func (d *DivisionPolynomials) Ring() poly.Ring { return d.d.R }
+
+// Map returns the map from the curve to the short Weierstrass curve where the
+// polynomials are defined.
+func (d *DivisionPolynomials) Map() RationalMap { return d.m }
+
+// F returns f(x)=x^3+Ax+B, the right-hand side of the short Weierstrass curve.
+func (d *DivisionPolynomials) F() poly.Poly { return d.d.f }
+
+// Psi returns the polynomial g such that psi_n=g for odd n, and psi_n=yg for
+// even n.
+func (d *DivisionPolynomials) Psi(n int) poly.Poly { return d.d.get(n) }
+
+// PsiSqr returns psi_n^2 as a polynomial in x.
+func (d *DivisionPolynomials) PsiSqr(n int) poly.Poly {
+	R := d.d.R
+	g := R.Sqr(d.d.get(n))
+	if n%2 == 0 {
+		g = R.Mul(g, d.d.f)
+	}
+	return g
+}
+
+// Phi returns phi_n=x*psi_n^2-psi_{n+1}psi_{n-1} as a polynomial in x.
+func (d *DivisionPolynomials) Phi(n int) poly.Poly {
+	R := d.d.R
+	t := R.Mul(d.d.get(n+1), d.d.get(n-1))
+	if n%2 != 0 {
+		t = R.Mul(t, d.d.f)
+	}
+	return R.Sub(R.Mul(R.X(), d.PsiSqr(n)), t)
+}
+
+// MultiplicationByN returns the polynomials such that the multiplication-by-n
+// map on the short Weierstrass curve is [n](x,y)=(a(x)/b(x), y*c(x)/e(x)),
+// where a=phi_n, b=psi_n^2 and y*c/e=omega_n/psi_n^3. It panics if n is zero.
+func (d *DivisionPolynomials) MultiplicationByN(n int) (a, b, c, e poly.Poly) {
+	if n == 0 {
+		panic(errors.New("n must be nonzero"))
+	}
+	R := d.d.R
+	a, b = d.Phi(n), d.PsiSqr(n)
+	// omega_n = (psi_{n+2}psi_{n-1}^2-psi_{n-2}psi_{n+1}^2)/(4y)
+	t0 := R.Mul(d.d.get(n+2), R.Sqr(d.d.get(n-1)))
+	t1 := R.Mul(d.d.get(n-2), R.Sqr(d.d.get(n+1)))
+	c = R.Scale(R.Sub(t0, t1), R.F.Inv(R.F.Elt(4)))
+	g := d.d.get(n)
+	e = R.Mul(R.Sqr(g), g)
+	if n%2 == 0 {
+		// psi_n^3 = y^3g^3, so y*c/e = c/(y^3g^3) = y*c/(f^2g^3).
+		e = R.Mul(e, R.Sqr(d.d.f))
+	}
+	return
+}
+
+// Eval returns [n]P by evaluating the multiplication-by-n map.
+func (d *DivisionPolynomials) Eval(n int, p Point) Point {
+	W := d.m.Codomain()
+	P := d.m.Push(p)
+	if P.IsIdentity() {
+		return d.m.Pull(P)
+	}
+	R := d.d.R
+	F := R.F
+	a, b, c, e := d.MultiplicationByN(n)
+	x, y := P.X(), P.Y()
+	bx := R.Eval(b, x)
+	if F.IsZero(bx) {
+		return d.m.Pull(W.Identity())
+	}
+	var xn, yn GF.Elt
+	xn = F.Mul(R.Eval(a, x), F.Inv(bx))
+	yn = F.Mul(R.Eval(c, x), F.Inv(R.Eval(e, x)))
+	yn = F.Mul(y, yn)
+	return d.m.Pull(W.(*weCurve).NewPoint(xn, yn))
+}
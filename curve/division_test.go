@@ -0,0 +1,67 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+)
+
+func TestDivisionPolynomials(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		d := C.NewDivisionPolynomials(E)
+		R := d.Ring()
+		p := E.Field().P().Int64()
+		for n := -3; n <= 8; n++ {
+			if n == 0 {
+				continue
+			}
+			// deg psi_n^2 = n^2-1 and deg phi_n = n^2, if p doesn't divide n.
+			if int64(n)%p != 0 {
+				if got, want := R.Deg(d.PsiSqr(n)), n*n-1; got != want {
+					t.Fatalf("%v: deg psi_%v^2 got: %v want: %v", curveID, n, got, want)
+				}
+				if got, want := R.Deg(d.Phi(n)), n*n; got != want {
+					t.Fatalf("%v: deg phi_%v got: %v want: %v", curveID, n, got, want)
+				}
+			}
+			k := big.NewInt(int64(n))
+			k.Abs(k)
+			P := E.Identity()
+			for i := 0; i < 5; i++ {
+				got := d.Eval(n, P)
+				want := E.ScalarMult(P, k)
+				if n < 0 {
+					want = E.Neg(want)
+				}
+				if !got.IsEqual(want) {
+					t.Fatalf("%v: [%v]%v\ngot:  %v\nwant: %v", curveID, n, P, got, want)
+				}
+				P = E.Add(P, g)
+			}
+		}
+	}
+}
+
+// TestDivisionPolynomialsDoubling verifies symbolically the doubling formula
+// x' = (3x^2+A)^2/(4f) - 2x of short Weierstrass curves.
+func TestDivisionPolynomialsDoubling(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		d := C.NewDivisionPolynomials(E)
+		R := d.Ring()
+		F := E.Field()
+		f := d.F()
+		a, b, _, _ := d.MultiplicationByN(2)
+
+		// (3x^2+A)^2 - 8xf over 4f.
+		l := R.New(f[1], F.Zero(), F.Elt(3))
+		num := R.Sub(R.Sqr(l), R.Scale(R.Mul(R.X(), f), F.Elt(8)))
+		den := R.Scale(f, F.Elt(4))
+		if got, want := R.Mul(a, den), R.Mul(num, b); !R.AreEqual(got, want) {
+			t.Fatalf("%v:\ngot:  %v\nwant: %v", curveID, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package curve
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+)
+
+var errNoDiscreteLog = errors.New("point is not in the subgroup generated by the base")
+
+// DiscreteLog returns the least k >= 0 such that P=[k]G. The order of G is
+// found from the factorization of Order()*Cofactor(), and the problem is
+// split with the Pohlig-Hellman method into problems in subgroups of prime
+// order, which are solved with baby-step giant-step or Pollard's rho.
+func DiscreteLog(E EllCurve, G, P Point) (*big.Int, error) {
+	N, err := curveOrder(E)
+	if err != nil {
+		return nil, err
+	}
+	e, m := weierstrassOf(E)
+	G, P = m.Push(G), m.Push(P)
+	n, err := orderOf(e, G, N)
+	if err != nil {
+		return nil, err
+	}
+	return discreteLog(e, G, P, n)
+}
+
+// orderOf returns the order of P, given a multiple N of it.
+func orderOf(e *weCurve, P Point, N *big.Int) (*big.Int, error) {
+	n := new(big.Int).Set(N)
+	for _, f := range Factorize(N) {
+		if !f.Prime {
+			return nil, fmt.Errorf("factor %v of the order is not prime", f.P)
+		}
+		for i := 0; i < f.E; i++ {
+			t := new(big.Int).Quo(n, f.P)
+			if !e.ScalarMult(P, t).IsIdentity() {
+				break
+			}
+			n = t
+		}
+	}
+	return n, nil
+}
+
+// discreteLog solves P=[k]G using the Pohlig-Hellman method, where n is the
+// order of G.
+func discreteLog(e *weCurve, G, P Point, n *big.Int) (*big.Int, error) {
+	if !e.ScalarMult(P, n).IsIdentity() {
+		return nil, errNoDiscreteLog
+	}
+
+	// Pohlig-Hellman: x mod p^k for each p^k dividing n.
+	x, M := big.NewInt(0), big.NewInt(1)
+	for _, f := range Factorize(n) {
+		pk := new(big.Int).Exp(f.P, big.NewInt(int64(f.E)), nil)
+		t := new(big.Int).Quo(n, pk)
+		G0, P0 := e.ScalarMult(G, t), e.ScalarMult(P, t)
+		g := e.ScalarMult(G0, new(big.Int).Quo(pk, f.P)) // order p
+		xk, pi := big.NewInt(0), big.NewInt(1)
+		for i := 0; i < f.E; i++ {
+			// h = [p^(k-1-i)](P0-[xk]G0)
+			h := e.Add(P0, e.Neg(e.ScalarMult(G0, xk)))
+			t.Quo(pk, pi).Quo(t, f.P)
+			h = e.ScalarMult(h, t)
+			d, err := discreteLogPrime(e, g, h, f.P)
+			if err != nil {
+				return nil, err
+			}
+			xk.Add(xk, d.Mul(d, pi))
+			pi.Mul(pi, f.P)
+		}
+		// Chinese remainder: x = x + M*((xk-x)/M mod p^k).
+		xk.Sub(xk, x)
+		xk.Mul(xk, new(big.Int).ModInverse(M, pk)).Mod(xk, pk)
+		x.Add(x, xk.Mul(xk, M))
+		M.Mul(M, pk)
+	}
+	if !e.ScalarMult(G, x).IsEqual(P) {
+		return nil, errNoDiscreteLog
+	}
+	return x, nil
+}
+
+// weierstrassOf returns a short Weierstrass curve isomorphic to E and the
+// map to it, so that points have unique affine coordinates.
+func weierstrassOf(E EllCurve) (*weCurve, RationalMap) {
+	if e, ok := E.(*weCurve); ok {
+		return e, &idMap{e}
+	}
+	W, m, err := ConvertTo(E, Weierstrass)
+	if err != nil {
+		panic(err)
+	}
+	return W.(*weCurve), m
+}
+
+// discreteLogPrime solves P=[k]G for G of prime order p.
+func discreteLogPrime(e *weCurve, G, P Point, p *big.Int) (*big.Int, error) {
+	if p.BitLen() <= 32 {
+		return discreteLogBSGS(e, G, P, p)
+	}
+	return discreteLogRho(e, G, P, p)
+}
+
+// DiscreteLogBSGS returns k in [0, n) such that P=[k]G using baby-step
+// giant-step, where n is a bound on the order of G.
+func DiscreteLogBSGS(E EllCurve, G, P Point, n *big.Int) (*big.Int, error) {
+	e, m := weierstrassOf(E)
+	return discreteLogBSGS(e, m.Push(G), m.Push(P), n)
+}
+
+// DiscreteLogRho returns k such that P=[k]G using Pollard's rho method with
+// distinguished points, where n is the prime order of G.
+func DiscreteLogRho(E EllCurve, G, P Point, n *big.Int) (*big.Int, error) {
+	if !n.ProbablyPrime(20) {
+		return nil, errors.New("order of the base point must be prime")
+	}
+	e, m := weierstrassOf(E)
+	return discreteLogRho(e, m.Push(G), m.Push(P), n)
+}
+
+// pointKey returns a string that identifies an affine point.
+func pointKey(P Point) string {
+	if P.IsIdentity() {
+		return "O"
+	}
+	return fmt.Sprint(P.X(), P.Y())
+}
+
+func discreteLogBSGS(e *weCurve, G, P Point, n *big.Int) (*big.Int, error) {
+	m := new(big.Int).Sqrt(n)
+	m.Add(m, big.NewInt(1))
+	M := m.Int64()
+
+	// Baby steps: jG for 0 <= j < m.
+	baby := make(map[string]int64, M)
+	jG := e.Identity()
+	for j := int64(0); j < M; j++ {
+		if _, ok := baby[pointKey(jG)]; !ok {
+			baby[pointKey(jG)] = j
+		}
+		jG = e.Add(jG, G)
+	}
+	// Giant steps: P-[i*m]G for 0 <= i <= m.
+	mG := e.Neg(e.ScalarMult(G, m))
+	Q := P
+	for i := int64(0); i <= M; i++ {
+		if j, ok := baby[pointKey(Q)]; ok {
+			k := big.NewInt(i)
+			return k.Mul(k, m).Add(k, big.NewInt(j)), nil
+		}
+		Q = e.Add(Q, mG)
+	}
+	return nil, errNoDiscreteLog
+}
+
+func discreteLogRho(e *weCurve, G, P Point, n *big.Int) (*big.Int, error) {
+	const partitions = 16
+	// A point is distinguished if the low bits of its hash are zero; there
+	// are about n^(1/4) steps between distinguished points.
+	mask := uint64(1)<<uint(n.BitLen()/4) - 1
+	maxWalk := 20 * (mask + 1)
+
+	hash := func(Q Point) uint64 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(pointKey(Q)))
+		return h.Sum64()
+	}
+	rnd := func() *big.Int {
+		k, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			panic(err)
+		}
+		return k
+	}
+	comb := func(a, b *big.Int) Point { return e.Add(e.ScalarMult(G, a), e.ScalarMult(P, b)) }
+
+	// r-adding walk: Q -> Q+R_i, where R_i=[a_i]G+[b_i]P.
+	var steps [partitions]struct {
+		a, b *big.Int
+		R    Point
+	}
+	for i := range steps {
+		steps[i].a, steps[i].b = rnd(), rnd()
+		steps[i].R = comb(steps[i].a, steps[i].b)
+	}
+	type pair struct{ a, b *big.Int }
+	seen := make(map[string]pair)
+	for {
+		a, b := rnd(), rnd()
+		Q := comb(a, b)
+		for w := uint64(0); w < maxWalk; w++ {
+			h := hash(Q)
+			if h&mask == 0 {
+				key := pointKey(Q)
+				if s, ok := seen[key]; ok {
+					// [a]G+[b]P = [s.a]G+[s.b]P, so k = (a-s.a)/(s.b-b).
+					den := new(big.Int).Sub(s.b, b)
+					if den.Mod(den, n).Sign() != 0 {
+						k := new(big.Int).Sub(a, s.a)
+						k.Mul(k, den.ModInverse(den, n)).Mod(k, n)
+						if e.ScalarMult(G, k).IsEqual(P) {
+							return k, nil
+						}
+						return nil, errNoDiscreteLog
+					}
+				}
+				seen[key] = pair{a, b}
+				break
+			}
+			s := &steps[(h>>32)%partitions]
+			Q = e.Add(Q, s.R)
+			a = new(big.Int).Add(a, s.a)
+			a.Mod(a, n)
+			b = new(big.Int).Add(b, s.b)
+			b.Mod(b, n)
+		}
+	}
+}
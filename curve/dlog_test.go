@@ -0,0 +1,73 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestDiscreteLog(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		N := new(big.Int).Mul(E.Order(), E.Cofactor())
+		for i := 0; i < 10; i++ {
+			k, _ := rand.Int(rand.Reader, N)
+			P := E.ScalarMult(g, k)
+			got, err := C.DiscreteLog(E, g, P)
+			if err != nil {
+				t.Fatalf("%v: %v", curveID, err)
+			}
+			if !E.ScalarMult(g, got).IsEqual(P) {
+				t.Fatalf("%v: got: %v want: %v", curveID, got, k)
+			}
+		}
+	}
+}
+
+func TestDiscreteLogNotInSubgroup(t *testing.T) {
+	// g has order 44, so g and [5]g are not in the subgroup generated by [4]g.
+	E, g, _ := toy.M0.New()
+	P := E.ClearCofactor(g)
+	for _, Q := range []C.Point{g, E.Add(g, P)} {
+		if _, err := C.DiscreteLog(E, P, Q); err == nil {
+			t.Fatalf("found a discrete logarithm of %v", Q)
+		}
+	}
+}
+
+func TestDiscreteLogPrimeOrder(t *testing.T) {
+	// y^2=x^3+3x+7 over F_1000003 has 999853 points, which is prime.
+	F := GF.NewFp("1000003", 1000003)
+	E := C.Weierstrass.New("", F, F.Elt(3), F.Elt(7), nil, nil)
+	n := C.CountPoints(E)
+	if n.Int64() != 999853 || !n.ProbablyPrime(20) {
+		t.Fatalf("order %v is not the prime 999853", n)
+	}
+	// Any point other than the identity generates the group.
+	var G C.Point
+	for x := 0; G == nil; x++ {
+		if y2 := E.(C.W).EvalRHS(F.Elt(x)); F.IsSquare(y2) {
+			G = E.NewPoint(F.Elt(x), F.Sqrt(y2))
+		}
+	}
+	for i := 0; i < 5; i++ {
+		k, _ := rand.Int(rand.Reader, n)
+		P := E.ScalarMult(G, k)
+		for _, dlog := range []func(C.EllCurve, C.Point, C.Point, *big.Int) (*big.Int, error){
+			C.DiscreteLogBSGS,
+			C.DiscreteLogRho,
+		} {
+			got, err := dlog(E, G, P, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Cmp(k) != 0 {
+				t.Fatalf("got: %v want: %v", got, k)
+			}
+		}
+	}
+}
@@ -21,6 +21,8 @@ type Point interface {
 // EllCurve represents an elliptic curve group.
 type EllCurve interface {
 	Field() GF.Field
+	// Order is the order of the prime-order subgroup, and Cofactor is its
+	// index, so that Order()*Cofactor() is the number of points of the curve.
 	Order() *big.Int
 	Cofactor() *big.Int
 	NewPoint(x, y GF.Elt) Point
@@ -37,6 +39,14 @@ type EllCurve interface {
 	ScalarMult(Point, *big.Int) Point
 }
 
+// Invariants is implemented by all the curve models of this package. It is
+// kept apart from EllCurve, so that curves implemented elsewhere satisfy
+// EllCurve without providing them.
+type Invariants interface {
+	Discriminant() GF.Elt
+	JInvariant() GF.Elt
+}
+
 // RationalMap represents a birational map between two elliptic curves.
 type RationalMap interface {
 	Domain() EllCurve
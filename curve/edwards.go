@@ -9,7 +9,11 @@ import (
 )
 
 // teCurve is a twisted Edwards curve
-type teCurve struct{ *params }
+type teCurve struct {
+	*params
+	aIsMinusOne bool   // true if A = -1.
+	twoD        GF.Elt // 2D
+}
 
 type T = *teCurve
 
@@ -19,16 +23,44 @@ func (e *teCurve) String() string {
 func (e *teCurve) New() EllCurve {
 	e.params.D = e.params.B
 	if e.IsValid() {
+		F := e.F
+		e.aIsMinusOne = F.AreEqual(e.A, F.Elt(-1))
+		e.twoD = F.Add(e.D, e.D)
 		return e
 	}
 	panic(errors.New("can't instantiate a twisted Edwards curve"))
 }
 func (e *teCurve) NewPoint(x, y GF.Elt) (P Point) {
-	if P = (&ptTe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+	F := e.F
+	if P = (&ptTe{e, x.Copy(), y.Copy(), F.One(), F.Mul(x, y)}); e.IsOnCurve(P) {
 		return P
 	}
 	panic(fmt.Errorf("p:%v not on %v", P, e))
 }
+
+// Discriminant returns AD(A-D)^4/16, the discriminant of the curve given by
+// ToWeierstrassC.
+func (e *teCurve) Discriminant() GF.Elt {
+	F := e.F
+	t0 := F.Sub(e.A, e.D)              // A-D
+	t0 = F.Sqr(F.Sqr(t0))              // (A-D)^4
+	t0 = F.Mul(t0, F.Mul(e.A, e.D))    // AD(A-D)^4
+	return F.Mul(t0, F.Inv(F.Elt(16))) // AD(A-D)^4/16
+}
+
+// JInvariant returns 16(A^2+14AD+D^2)^3/(AD(A-D)^4).
+func (e *teCurve) JInvariant() GF.Elt {
+	F := e.F
+	t0 := F.Mul(e.A, e.D)               // AD
+	t1 := F.Mul(F.Elt(14), t0)          // 14AD
+	t1 = F.Add(t1, F.Sqr(e.A))          // A^2+14AD
+	t1 = F.Add(t1, F.Sqr(e.D))          // A^2+14AD+D^2
+	t1 = F.Mul(F.Sqr(t1), t1)           // (A^2+14AD+D^2)^3
+	t1 = F.Mul(F.Elt(16), t1)           // 16(A^2+14AD+D^2)^3
+	t2 := F.Sqr(F.Sqr(F.Sub(e.A, e.D))) // (A-D)^4
+	t0 = F.Mul(t0, t2)                  // AD(A-D)^4
+	return F.Mul(t1, F.Inv(t0))         // 16(A^2+14AD+D^2)^3/(AD(A-D)^4)
+}
 func (e *teCurve) IsValid() bool {
 	F := e.F
 	cond1 := !F.AreEqual(e.A, e.D) // A != D
@@ -37,8 +69,8 @@ func (e *teCurve) IsValid() bool {
 	return cond1 && cond2 && cond3
 }
 func (e *teCurve) IsEqual(ec EllCurve) bool {
-	e0 := ec.(*teCurve)
-	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
+	e0, ok := ec.(*teCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
 }
 func (e *teCurve) IsComplete() bool {
 	F := e.F
@@ -47,64 +79,192 @@ func (e *teCurve) IsComplete() bool {
 func (e *teCurve) IsOnCurve(p Point) bool {
 	P := p.(*ptTe)
 	F := e.F
-	var t0, t1, t2 GF.Elt
-	t0 = F.Sqr(P.x)         // x^2
-	t1 = F.Sqr(P.y)         // y^2
-	t2 = F.Mul(t0, t1)      // x^2y^2
-	t2 = F.Mul(t2, e.D)     // Dx^2y^2
-	t2 = F.Add(t2, F.One()) // 1+Dx^2y^2
-	t0 = F.Mul(t0, e.A)     // Ax^2
-	t0 = F.Add(t0, t1)      // Ax^2+y^2
-	return F.AreEqual(t0, t2)
+	if F.IsZero(P.z) {
+		return false
+	}
+	var t0, t1, t2, t3 GF.Elt
+	t0 = F.Sqr(P.x)      // X^2
+	t1 = F.Sqr(P.y)      // Y^2
+	t2 = F.Sqr(P.z)      // Z^2
+	t3 = F.Mul(t0, t1)   // X^2Y^2
+	t3 = F.Mul(t3, e.D)  // DX^2Y^2
+	t0 = F.Mul(t0, e.A)  // AX^2
+	t0 = F.Add(t0, t1)   // AX^2+Y^2
+	t0 = F.Mul(t0, t2)   // (AX^2+Y^2)Z^2
+	t2 = F.Sqr(t2)       // Z^4
+	t2 = F.Add(t2, t3)   // Z^4+DX^2Y^2
+	t1 = F.Mul(P.x, P.y) // XY
+	t3 = F.Mul(P.t, P.z) // TZ
+	return F.AreEqual(t0, t2) && F.AreEqual(t1, t3)
 }
 func (e *teCurve) Identity() Point { return e.NewPoint(e.F.Zero(), e.F.One()) }
+func (e *teCurve) Neg(p Point) Point {
+	P := p.(*ptTe)
+	F := e.F
+	return &ptTe{e, F.Neg(P.x), P.y.Copy(), P.z.Copy(), F.Neg(P.t)}
+}
+
+// Add calculates P+Q using the unified formulas of Hisil-Wong-Carter-Dawson,
+// which are complete if the curve is complete.
 func (e *teCurve) Add(p, q Point) Point {
 	P := p.(*ptTe)
 	Q := q.(*ptTe)
 	F := e.F
 
-	var t0, t1, t2, t3 GF.Elt
-	t0 = F.Mul(e.D, P.x)    // Dx1
-	t0 = F.Mul(t0, P.y)     // Dx1y1
-	t0 = F.Mul(t0, Q.x)     // Dx1y1x2
-	t0 = F.Mul(t0, Q.y)     // Dx1y1x2y2
-	t2 = F.Add(F.One(), t0) // 1+Dx1y1x2y2
-	t3 = F.Sub(F.One(), t0) // 1-Dx1y1x2y2
-	t2 = F.Inv(t2)          // 1/(1+Dx1y1x2y2)
-	t3 = F.Inv(t3)          // 1/(1-Dx1y1x2y2)
-
-	t0 = F.Mul(P.x, Q.y) // x1y2
-	t1 = F.Mul(Q.x, P.y) // x2y1
-	t0 = F.Add(t0, t1)   // x1y2+x2y1
-	x := F.Mul(t0, t2)   // (x1y2+x2y1)/(1+Dx1y1x2y2)
-
-	t0 = F.Mul(P.y, Q.y) // y1y2
-	t1 = F.Mul(P.x, Q.x) // x1x2
-	t1 = F.Mul(t1, e.A)  // Ax1x2
-	t0 = F.Sub(t0, t1)   // y1y2-Ax1x2
-	y := F.Mul(t0, t3)   // (y1y2-Ax1x2)/(1-Dx1y1x2y2)
-
-	return &ptTe{e, &afPoint{x: x, y: y}}
+	var a, b, c, d, ee, f, g, h GF.Elt
+	if e.aIsMinusOne {
+		a = F.Sub(P.y, P.x)  // Y1-X1
+		b = F.Sub(Q.y, Q.x)  // Y2-X2
+		a = F.Mul(a, b)      // A = (Y1-X1)(Y2-X2)
+		b = F.Add(P.y, P.x)  // Y1+X1
+		c = F.Add(Q.y, Q.x)  // Y2+X2
+		b = F.Mul(b, c)      // B = (Y1+X1)(Y2+X2)
+		c = F.Mul(P.t, Q.t)  // T1T2
+		c = F.Mul(c, e.twoD) // C = 2DT1T2
+		d = F.Mul(P.z, Q.z)  // Z1Z2
+		d = F.Add(d, d)      // D = 2Z1Z2
+		ee = F.Sub(b, a)     // E = B-A
+		h = F.Add(b, a)      // H = B+A
+	} else {
+		a = F.Mul(P.x, Q.x)  // A = X1X2
+		b = F.Mul(P.y, Q.y)  // B = Y1Y2
+		c = F.Mul(P.t, Q.t)  // T1T2
+		c = F.Mul(c, e.D)    // C = DT1T2
+		d = F.Mul(P.z, Q.z)  // D = Z1Z2
+		ee = F.Add(P.x, P.y) // X1+Y1
+		f = F.Add(Q.x, Q.y)  // X2+Y2
+		ee = F.Mul(ee, f)    // (X1+Y1)(X2+Y2)
+		ee = F.Sub(ee, a)    // (X1+Y1)(X2+Y2)-A
+		ee = F.Sub(ee, b)    // E = (X1+Y1)(X2+Y2)-A-B
+		h = F.Mul(e.A, a)    // aA
+		h = F.Sub(b, h)      // H = B-aA
+	}
+	f = F.Sub(d, c) // F = D-C
+	g = F.Add(d, c) // G = D+C
+	return e.newExtended(ee, f, g, h)
 }
-func (e *teCurve) Neg(p Point) Point {
+
+// addDedicated calculates P+Q, for P != Q, using the dedicated formulas of
+// Hisil-Wong-Carter-Dawson. It returns nil if the formulas are not defined
+// for the inputs, which always happens when P = Q.
+func (e *teCurve) addDedicated(P, Q *ptTe) *ptTe {
+	F := e.F
+
+	var a, b, c, d, ee, f, g, h GF.Elt
+	if e.aIsMinusOne {
+		a = F.Sub(P.y, P.x) // Y1-X1
+		b = F.Add(Q.y, Q.x) // Y2+X2
+		a = F.Mul(a, b)     // A = (Y1-X1)(Y2+X2)
+		b = F.Add(P.y, P.x) // Y1+X1
+		c = F.Sub(Q.y, Q.x) // Y2-X2
+		b = F.Mul(b, c)     // B = (Y1+X1)(Y2-X2)
+		c = F.Mul(P.z, Q.t) // Z1T2
+		c = F.Add(c, c)     // C = 2Z1T2
+		d = F.Mul(P.t, Q.z) // T1Z2
+		d = F.Add(d, d)     // D = 2T1Z2
+		f = F.Sub(b, a)     // F = B-A
+		g = F.Add(b, a)     // G = B+A
+	} else {
+		a = F.Mul(P.x, Q.x) // A = X1X2
+		b = F.Mul(P.y, Q.y) // B = Y1Y2
+		c = F.Mul(P.z, Q.t) // C = Z1T2
+		d = F.Mul(P.t, Q.z) // D = T1Z2
+		f = F.Sub(P.x, P.y) // X1-Y1
+		g = F.Add(Q.x, Q.y) // X2+Y2
+		f = F.Mul(f, g)     // (X1-Y1)(X2+Y2)
+		f = F.Add(f, b)     // (X1-Y1)(X2+Y2)+B
+		f = F.Sub(f, a)     // F = (X1-Y1)(X2+Y2)+B-A
+		g = F.Mul(e.A, a)   // aA
+		g = F.Add(b, g)     // G = B+aA
+	}
+	ee = F.Add(d, c) // E = D+C
+	h = F.Sub(d, c)  // H = D-C
+	if R := e.newExtended(ee, f, g, h); !F.IsZero(R.z) {
+		return R
+	}
+	return nil
+}
+
+// Double calculates 2P using the doubling formulas of Hisil-Wong-Carter-Dawson.
+func (e *teCurve) Double(p Point) Point {
 	P := p.(*ptTe)
-	return &ptTe{e, &afPoint{x: e.F.Neg(P.x), y: P.y.Copy()}}
+	F := e.F
+
+	var a, b, c, d, ee, f, g, h GF.Elt
+	a = F.Sqr(P.x)       // A = X1^2
+	b = F.Sqr(P.y)       // B = Y1^2
+	c = F.Sqr(P.z)       // Z1^2
+	c = F.Add(c, c)      // C = 2Z1^2
+	ee = F.Add(P.x, P.y) // X1+Y1
+	ee = F.Sqr(ee)       // (X1+Y1)^2
+	ee = F.Sub(ee, a)    // (X1+Y1)^2-A
+	ee = F.Sub(ee, b)    // E = (X1+Y1)^2-A-B
+	if e.aIsMinusOne {
+		d = F.Neg(a) // D = -A
+	} else {
+		d = F.Mul(e.A, a) // D = aA
+	}
+	g = F.Add(d, b) // G = D+B
+	f = F.Sub(g, c) // F = G-C
+	h = F.Sub(d, b) // H = D-B
+	return e.newExtended(ee, f, g, h)
+}
+
+// newExtended returns the point (EF:GH:FG:EH).
+func (e *teCurve) newExtended(ee, f, g, h GF.Elt) *ptTe {
+	F := e.F
+	return &ptTe{e, F.Mul(ee, f), F.Mul(g, h), F.Mul(f, g), F.Mul(ee, h)}
+}
+
+// ScalarMult calculates kP using the dedicated addition formulas, and falls
+// back to the unified ones whenever the former are not defined.
+func (e *teCurve) ScalarMult(p Point, k *big.Int) Point {
+	P := p.(*ptTe)
+	Q := e.Identity().(*ptTe)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		Q = e.Double(Q).(*ptTe)
+		if k.Bit(i) != 0 {
+			if R := e.addDedicated(Q, P); R != nil {
+				Q = R
+			} else {
+				Q = e.Add(Q, P).(*ptTe)
+			}
+		}
+	}
+	return Q
 }
-func (e *teCurve) Double(p Point) Point                 { return e.Add(p, p) }
-func (e *teCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
 
 func (e *teCurve) ClearCofactor(p Point) Point { return e.ScalarMult(p, e.H) }
 
+// ptTe is a point on a twisted Edwards curve in extended coordinates
+// (X:Y:Z:T), such that x=X/Z, y=Y/Z, and xy=T/Z.
 type ptTe struct {
 	*teCurve
-	*afPoint
+	x, y, z, t GF.Elt
 }
 
-func (p *ptTe) String() string { return p.afPoint.String() }
-func (p *ptTe) Copy() Point    { return &ptTe{p.teCurve, p.copy()} }
+func (p *ptTe) String() string {
+	x, y := p.affine()
+	return fmt.Sprintf("(%v, %v)", x, y)
+}
+func (p *ptTe) Copy() Point {
+	return &ptTe{p.teCurve, p.x.Copy(), p.y.Copy(), p.z.Copy(), p.t.Copy()}
+}
+func (p *ptTe) X() GF.Elt { x, _ := p.affine(); return x }
+func (p *ptTe) Y() GF.Elt { _, y := p.affine(); return y }
+func (p *ptTe) affine() (x, y GF.Elt) {
+	F := p.F
+	invZ := F.Inv(p.z)
+	return F.Mul(p.x, invZ), F.Mul(p.y, invZ)
+}
 func (p *ptTe) IsEqual(q Point) bool {
-	qq := q.(*ptTe)
-	return p.teCurve.IsEqual(qq.teCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptTe)
+	if !ok || !p.teCurve.IsEqual(qq.teCurve) {
+		return false
+	}
+	F := p.F
+	return F.AreEqual(F.Mul(p.x, qq.z), F.Mul(qq.x, p.z)) &&
+		F.AreEqual(F.Mul(p.y, qq.z), F.Mul(qq.y, p.z))
 }
-func (p *ptTe) IsIdentity() bool   { return p.F.IsZero(p.x) && p.F.AreEqual(p.y, p.F.One()) }
-func (p *ptTe) IsTwoTorsion() bool { return p.F.IsZero(p.x) && p.F.AreEqual(p.y, p.F.Elt(-1)) }
+func (p *ptTe) IsIdentity() bool   { return p.F.IsZero(p.x) && p.F.AreEqual(p.y, p.z) }
+func (p *ptTe) IsTwoTorsion() bool { return p.F.IsZero(p.x) && p.F.AreEqual(p.y, p.F.Neg(p.z)) }
@@ -0,0 +1,100 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// affineAdd returns the coordinates of P+Q on ax^2+y^2=1+dx^2y^2, and false
+// if the affine addition law is not defined for P and Q.
+func affineAdd(F GF.Field, a, d GF.Elt, P, Q C.Point) (x, y GF.Elt, ok bool) {
+	x1, y1, x2, y2 := P.X(), P.Y(), Q.X(), Q.Y()
+	t := F.Mul(d, F.Mul(F.Mul(x1, x2), F.Mul(y1, y2))) // dx1x2y1y2
+	den0, den1 := F.Add(F.One(), t), F.Sub(F.One(), t)
+	if F.IsZero(den0) || F.IsZero(den1) {
+		return nil, nil, false
+	}
+	x = F.Add(F.Mul(x1, y2), F.Mul(y1, x2))           // x1y2+y1x2
+	y = F.Sub(F.Mul(y1, y2), F.Mul(a, F.Mul(x1, x2))) // y1y2-ax1x2
+	return F.Mul(x, F.Inv(den0)), F.Mul(y, F.Inv(den1)), true
+}
+
+func TestEdwardsFormulas(t *testing.T) {
+	F := GF.NewFp("53", 53)
+	for _, curve := range []struct{ a, d int }{
+		{-1, 12}, // complete, a=-1
+		{1, 3},   // complete, a!=-1
+		{-1, 4},  // incomplete, a=-1
+		{2, 4},   // incomplete, a!=-1
+	} {
+		a, d := F.Elt(curve.a), F.Elt(curve.d)
+		E := C.TwistedEdwards.New("", F, a, d, nil, nil)
+		var points []C.Point
+		for i := 0; i < 53; i++ {
+			for j := 0; j < 53; j++ {
+				x, y := F.Elt(i), F.Elt(j)
+				xx, yy := F.Sqr(x), F.Sqr(y)
+				lhs := F.Add(F.Mul(a, xx), yy)
+				rhs := F.Add(F.One(), F.Mul(d, F.Mul(xx, yy)))
+				if F.AreEqual(lhs, rhs) {
+					P := E.NewPoint(x, y)
+					// Also uses a projective representative with Z != 1.
+					points = append(points, P, E.Add(P, E.Double(E.Identity())))
+				}
+			}
+		}
+		O := E.Identity()
+		dedicated := 0
+		for _, P := range points {
+			if got := E.Double(O); !got.IsIdentity() {
+				t.Fatalf("a=%v d=%v: 2O=%v", a, d, got)
+			}
+			if got := E.Add(P, O); !got.IsEqual(P) {
+				t.Fatalf("a=%v d=%v: %v+O=%v", a, d, P, got)
+			}
+			if got := E.Add(O, P); !got.IsEqual(P) {
+				t.Fatalf("a=%v d=%v: O+%v=%v", a, d, P, got)
+			}
+			if x, y, ok := affineAdd(F, a, d, P, P); ok {
+				if got := E.Double(P); !F.AreEqual(got.X(), x) || !F.AreEqual(got.Y(), y) {
+					t.Fatalf("a=%v d=%v: 2%v\ngot:  %v\nwant: (%v, %v)", a, d, P, got, x, y)
+				}
+			}
+			if got := C.AddDedicated(E, P, P); got != nil {
+				t.Fatalf("a=%v d=%v: dedicated formulas used for doubling %v", a, d, P)
+			}
+			for _, Q := range points {
+				x, y, ok := affineAdd(F, a, d, P, Q)
+				if !ok {
+					continue
+				}
+				if got := E.Add(P, Q); !F.AreEqual(got.X(), x) || !F.AreEqual(got.Y(), y) {
+					t.Fatalf("a=%v d=%v: %v+%v\ngot:  %v\nwant: (%v, %v)", a, d, P, Q, got, x, y)
+				}
+				if got := C.AddDedicated(E, P, Q); got != nil {
+					dedicated++
+					if !F.AreEqual(got.X(), x) || !F.AreEqual(got.Y(), y) {
+						t.Fatalf("a=%v d=%v: %v+%v\ngot:  %v\nwant: (%v, %v)", a, d, P, Q, got, x, y)
+					}
+				}
+			}
+		}
+		if dedicated == 0 {
+			t.Fatalf("a=%v d=%v: dedicated formulas never used", a, d)
+		}
+		// The scalar multiplication, which mixes both additions, agrees with
+		// repeated affine additions.
+		for _, P := range points[:8] {
+			want := O
+			for k := int64(0); k < 12; k++ {
+				if got := E.ScalarMult(P, big.NewInt(k)); !got.IsEqual(want) {
+					t.Fatalf("a=%v d=%v: [%v]%v\ngot:  %v\nwant: %v", a, d, k, P, got, want)
+				}
+				want = E.Add(want, P)
+			}
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package curve
+
+// AddDedicated exposes the dedicated addition formulas of twisted Edwards
+// curves to the tests. It returns nil where they are not defined.
+func AddDedicated(E EllCurve, p, q Point) Point {
+	if R := E.(*teCurve).addDedicated(p.(*ptTe), q.(*ptTe)); R != nil {
+		return R
+	}
+	return nil
+}
+
+// TraceModL exposes to the tests the trace of Frobenius modulo the odd prime
+// l, as found by Schoof's algorithm.
+func TraceModL(E EllCurve, l int64) int64 {
+	e := shortWeierstrass(E)
+	psi := newDivPolys(e)
+	return schoofPrime(e, psi.f, psi.get(int(l)), l)
+}
@@ -0,0 +1,129 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Factor is a term p^e of the factorization of an integer. Prime is false if
+// p is a composite number that could not be split.
+type Factor struct {
+	P     *big.Int
+	E     int
+	Prime bool
+}
+
+func (f Factor) String() string {
+	s := f.P.String()
+	if f.E > 1 {
+		s = fmt.Sprintf("%v^%v", s, f.E)
+	}
+	if !f.Prime {
+		s += "(composite)"
+	}
+	return s
+}
+
+// Factorize returns the factorization of n>0 sorted in increasing order of
+// the factors. It uses trial division followed by Pollard's rho method, so
+// factors too large for rho are returned as composite.
+func Factorize(n *big.Int) []Factor {
+	if n.Sign() <= 0 {
+		panic(fmt.Errorf("can't factorize %v", n))
+	}
+	var factors []Factor
+	add := func(p *big.Int, prime bool) {
+		for i := range factors {
+			if factors[i].P.Cmp(p) == 0 {
+				factors[i].E++
+				return
+			}
+		}
+		factors = append(factors, Factor{P: new(big.Int).Set(p), E: 1, Prime: prime})
+	}
+
+	m := new(big.Int).Set(n)
+	q, r := new(big.Int), new(big.Int)
+	for d := big.NewInt(2); d.Cmp(big.NewInt(trialDivisionBound)) < 0; d.Add(d, big.NewInt(1)) {
+		for q.QuoRem(m, d, r); r.Sign() == 0; q.QuoRem(m, d, r) {
+			add(d, true)
+			m.Set(q)
+		}
+	}
+
+	var split func(m *big.Int)
+	split = func(m *big.Int) {
+		if m.Cmp(big.NewInt(1)) == 0 {
+			return
+		}
+		if m.ProbablyPrime(20) {
+			add(m, true)
+			return
+		}
+		d := pollardRho(m)
+		if d == nil {
+			add(m, false)
+			return
+		}
+		split(d)
+		split(new(big.Int).Quo(m, d))
+	}
+	split(m)
+
+	sort.Slice(factors, func(i, j int) bool { return factors[i].P.Cmp(factors[j].P) < 0 })
+	return factors
+}
+
+const (
+	trialDivisionBound = 1 << 12
+	pollardRhoBound    = 1 << 20
+)
+
+// pollardRho returns a non-trivial factor of the composite number n using
+// Brent's variant of Pollard's rho method, or nil if none is found after
+// pollardRhoBound iterations.
+func pollardRho(n *big.Int) *big.Int {
+	const batch = 128
+	one := big.NewInt(1)
+	for c := int64(1); c < 8; c++ {
+		C := big.NewInt(c)
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x, x)
+			x.Add(x, C)
+			return x.Mod(x, n)
+		}
+		x, y, ys := big.NewInt(2), big.NewInt(2), new(big.Int)
+		g, q, t := big.NewInt(1), big.NewInt(1), new(big.Int)
+		for r, i := 1, 0; g.Cmp(one) == 0 && i < pollardRhoBound; r <<= 1 {
+			x.Set(y)
+			for k := 0; k < r; k++ {
+				f(y)
+			}
+			for k := 0; k < r && g.Cmp(one) == 0; k += batch {
+				ys.Set(y)
+				for j := 0; j < batch && j < r-k; j++ {
+					f(y)
+					q.Mul(q, t.Abs(t.Sub(x, y)))
+					q.Mod(q, n)
+				}
+				g.GCD(nil, nil, q, n)
+				i += batch
+			}
+		}
+		if g.Cmp(n) == 0 {
+			// The batch overshot; backtracks one step at a time.
+			for g.Cmp(one) == 0 || g.Cmp(n) == 0 {
+				f(ys)
+				g.GCD(nil, nil, t.Abs(t.Sub(x, ys)), n)
+				if g.Cmp(n) == 0 {
+					break
+				}
+			}
+		}
+		if g.Cmp(one) != 0 && g.Cmp(n) != 0 {
+			return g
+		}
+	}
+	return nil
+}
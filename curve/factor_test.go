@@ -0,0 +1,38 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+func TestFactorize(t *testing.T) {
+	for _, v := range []struct {
+		n    string
+		want string
+	}{
+		{"1", ""},
+		{"97", "97"},
+		{"360", "2^3 * 3^2 * 5"},
+		{"18446744073709551617", "274177 * 67280421310721"},
+		// Order of the twist of P-256.
+		{"115792089210356248762697446949407573530175331606444868048645003556665683663535",
+			"3 * 5 * 13 * 179 * 3317349640749355357762425066592395746459685764401801118712075735758936647"},
+	} {
+		n, _ := new(big.Int).SetString(v.n, 10)
+		got := ""
+		for i, f := range C.Factorize(n) {
+			if !f.Prime {
+				t.Fatalf("n: %v composite factor: %v", v.n, f)
+			}
+			if i > 0 {
+				got += " * "
+			}
+			got += f.String()
+		}
+		if got != v.want {
+			t.Fatalf("n: %v\ngot:  %v\nwant: %v", v.n, got, v.want)
+		}
+	}
+}
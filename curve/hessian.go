@@ -0,0 +1,508 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// thCurve is a twisted Hessian curve
+type thCurve struct {
+	*params
+	toW RationalMap
+}
+
+type TH = *thCurve
+
+func (e *thCurve) String() string {
+	return fmt.Sprintf("aX^3+Y^3+Z^3=dXYZ\nF: %v\na: %v\nd: %v\n", e.F, e.A, e.D)
+}
+func (e *thCurve) New() EllCurve {
+	e.params.D = e.params.B
+	if e.IsValid() {
+		e.toW = e.ToWeierstrass()
+		return e
+	}
+	panic(errors.New("can't instantiate a twisted Hessian curve"))
+}
+
+// NewPoint returns the point (x:y:1).
+func (e *thCurve) NewPoint(x, y GF.Elt) (P Point) {
+	if P = (&ptTh{e, &prPoint{x, y, e.F.One()}}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p:%v not on %v", P, e))
+}
+
+// Discriminant returns the discriminant of the curve given by ToWeierstrass.
+func (e *thCurve) Discriminant() GF.Elt { return e.toW.Codomain().(*weCurve).Discriminant() }
+func (e *thCurve) JInvariant() GF.Elt   { return e.toW.Codomain().(*weCurve).JInvariant() }
+func (e *thCurve) IsValid() bool {
+	F := e.F
+	t0 := F.Sqr(e.D)            // d^2
+	t0 = F.Mul(t0, e.D)         // d^3
+	t1 := F.Mul(F.Elt(27), e.A) // 27a
+	t0 = F.Sub(t1, t0)          // 27a-d^3
+	t0 = F.Mul(t0, e.A)         // a(27a-d^3)
+	return !F.IsZero(t0)        // a(27a-d^3) != 0
+}
+func (e *thCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*thCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
+}
+func (e *thCurve) IsOnCurve(p Point) bool {
+	P := p.(*ptTh)
+	F := e.F
+	if P.isZero(F) {
+		return false
+	}
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.x)      // X^2
+	t0 = F.Mul(t0, P.x)  // X^3
+	t0 = F.Mul(t0, e.A)  // aX^3
+	t1 = F.Sqr(P.y)      // Y^2
+	t1 = F.Mul(t1, P.y)  // Y^3
+	t0 = F.Add(t0, t1)   // aX^3+Y^3
+	t1 = F.Sqr(P.z)      // Z^2
+	t1 = F.Mul(t1, P.z)  // Z^3
+	t0 = F.Add(t0, t1)   // aX^3+Y^3+Z^3
+	t1 = F.Mul(P.x, P.y) // XY
+	t1 = F.Mul(t1, P.z)  // XYZ
+	t1 = F.Mul(t1, e.D)  // dXYZ
+	return F.AreEqual(t0, t1)
+}
+func (e *thCurve) Identity() Point {
+	F := e.F
+	return &ptTh{e, &prPoint{F.Zero(), F.Elt(-1), F.One()}}
+}
+func (e *thCurve) Neg(p Point) Point {
+	P := p.(*ptTh)
+	return &ptTh{e, &prPoint{P.x.Copy(), P.z.Copy(), P.y.Copy()}}
+}
+
+// Add calculates P+Q using the rotated addition law of Bernstein-Chuengsatiansup-
+// Kohel-Lange, which is complete if a is not a cube. Otherwise, the standard
+// addition law is used for the inputs not covered by the rotated one.
+func (e *thCurve) Add(p, q Point) Point {
+	P := p.(*ptTh)
+	Q := q.(*ptTh)
+	F := e.F
+
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(Q.z)     // Z2^2
+	t0 = F.Mul(t0, P.x) // X1Z2^2
+	t0 = F.Mul(t0, P.z) // X1Z1Z2^2
+	t1 = F.Sqr(P.y)     // Y1^2
+	t1 = F.Mul(t1, Q.x) // X2Y1^2
+	t1 = F.Mul(t1, Q.y) // X2Y2Y1^2
+	x := F.Sub(t0, t1)  // X3 = X1Z1Z2^2-X2Y2Y1^2
+
+	t0 = F.Sqr(Q.y)     // Y2^2
+	t0 = F.Mul(t0, P.y) // Y1Y2^2
+	t0 = F.Mul(t0, P.z) // Y1Z1Y2^2
+	t1 = F.Sqr(P.x)     // X1^2
+	t1 = F.Mul(t1, Q.x) // X2X1^2
+	t1 = F.Mul(t1, Q.z) // X2Z2X1^2
+	t1 = F.Mul(t1, e.A) // aX2Z2X1^2
+	y := F.Sub(t0, t1)  // Y3 = Y1Z1Y2^2-aX2Z2X1^2
+
+	t0 = F.Sqr(Q.x)     // X2^2
+	t0 = F.Mul(t0, P.x) // X1X2^2
+	t0 = F.Mul(t0, P.y) // X1Y1X2^2
+	t0 = F.Mul(t0, e.A) // aX1Y1X2^2
+	t1 = F.Sqr(P.z)     // Z1^2
+	t1 = F.Mul(t1, Q.y) // Y2Z1^2
+	t1 = F.Mul(t1, Q.z) // Y2Z2Z1^2
+	z := F.Sub(t0, t1)  // Z3 = aX1Y1X2^2-Y2Z2Z1^2
+
+	if R := (&ptTh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	return e.addStandard(P, Q)
+}
+
+// addStandard calculates P+Q using the standard addition law, which is not
+// defined for P=Q.
+func (e *thCurve) addStandard(P, Q *ptTh) Point {
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.x)     // X1^2
+	t0 = F.Mul(t0, Q.y) // Y2X1^2
+	t0 = F.Mul(t0, Q.z) // Y2Z2X1^2
+	t1 = F.Sqr(Q.x)     // X2^2
+	t1 = F.Mul(t1, P.y) // Y1X2^2
+	t1 = F.Mul(t1, P.z) // Y1Z1X2^2
+	x := F.Sub(t0, t1)  // X3 = X1^2Y2Z2-X2^2Y1Z1
+
+	t0 = F.Sqr(P.z)     // Z1^2
+	t0 = F.Mul(t0, Q.x) // X2Z1^2
+	t0 = F.Mul(t0, Q.y) // X2Y2Z1^2
+	t1 = F.Sqr(Q.z)     // Z2^2
+	t1 = F.Mul(t1, P.x) // X1Z2^2
+	t1 = F.Mul(t1, P.y) // X1Y1Z2^2
+	y := F.Sub(t0, t1)  // Y3 = Z1^2X2Y2-Z2^2X1Y1
+
+	t0 = F.Sqr(P.y)     // Y1^2
+	t0 = F.Mul(t0, Q.x) // X2Y1^2
+	t0 = F.Mul(t0, Q.z) // X2Z2Y1^2
+	t1 = F.Sqr(Q.y)     // Y2^2
+	t1 = F.Mul(t1, P.x) // X1Y2^2
+	t1 = F.Mul(t1, P.z) // X1Z1Y2^2
+	z := F.Sub(t0, t1)  // Z3 = Y1^2X2Z2-Y2^2X1Z1
+
+	if R := (&ptTh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	panic(errors.New("addition law not defined"))
+}
+func (e *thCurve) Double(p Point) Point {
+	P := p.(*ptTh)
+	F := e.F
+	var x3, y3, z3, t0 GF.Elt
+	x3 = F.Sqr(P.x)     // X^2
+	x3 = F.Mul(x3, P.x) // X^3
+	x3 = F.Mul(x3, e.A) // aX^3
+	y3 = F.Sqr(P.y)     // Y^2
+	y3 = F.Mul(y3, P.y) // Y^3
+	z3 = F.Sqr(P.z)     // Z^2
+	z3 = F.Mul(z3, P.z) // Z^3
+
+	t0 = F.Sub(z3, y3)  // Z^3-Y^3
+	x := F.Mul(t0, P.x) // X3 = X(Z^3-Y^3)
+	t0 = F.Sub(y3, x3)  // Y^3-aX^3
+	y := F.Mul(t0, P.z) // Y3 = Z(Y^3-aX^3)
+	t0 = F.Sub(x3, z3)  // aX^3-Z^3
+	z := F.Mul(t0, P.y) // Z3 = Y(aX^3-Z^3)
+
+	if R := (&ptTh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	return e.Add(p, p)
+}
+func (e *thCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *thCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToGeneralizedHessian returns the map (X:Y:Z) -> (Y:Z:X) to the curve
+// X^3+Y^3+aZ^3=dXYZ.
+func (e *thCurve) ToGeneralizedHessian() RationalMap {
+	e1 := GeneralizedHessian.New("GH from "+e.Name, e.F, e.A, e.D, e.params.R, e.params.H)
+	return &th2gh{E0: e, E1: e1.(*ghCurve)}
+}
+
+// ToWeierstrass returns a map to a short Weierstrass curve.
+func (e *thCurve) ToWeierstrass() RationalMap {
+	m := e.ToGeneralizedHessian()
+	return Compose(m, m.Codomain().(*ghCurve).ToWeierstrass())
+}
+
+type th2gh struct {
+	E0 *thCurve
+	E1 *ghCurve
+}
+
+func (r *th2gh) Domain() EllCurve   { return r.E0 }
+func (r *th2gh) Codomain() EllCurve { return r.E1 }
+func (r *th2gh) Push(p Point) Point {
+	P := p.(*ptTh)
+	return &ptGh{r.E1, &prPoint{P.y.Copy(), P.z.Copy(), P.x.Copy()}}
+}
+func (r *th2gh) Pull(p Point) Point {
+	P := p.(*ptGh)
+	return &ptTh{r.E0, &prPoint{P.z.Copy(), P.x.Copy(), P.y.Copy()}}
+}
+
+// ptTh is a projective point on a twisted Hessian curve.
+type ptTh struct {
+	*thCurve
+	*prPoint
+}
+
+func (p *ptTh) String() string { return p.prPoint.String() }
+func (p *ptTh) Copy() Point    { return &ptTh{p.thCurve, p.copy()} }
+func (p *ptTh) X() GF.Elt      { x, _ := p.affine(p.F); return x }
+func (p *ptTh) Y() GF.Elt      { _, y := p.affine(p.F); return y }
+func (p *ptTh) IsEqual(q Point) bool {
+	qq, ok := q.(*ptTh)
+	return ok && p.thCurve.IsEqual(qq.thCurve) && p.isEqual(p.F, qq.prPoint)
+}
+func (p *ptTh) IsIdentity() bool { return p.IsEqual(p.thCurve.Identity()) }
+func (p *ptTh) IsTwoTorsion() bool {
+	return !p.IsIdentity() && p.F.AreEqual(p.y, p.z)
+}
+
+// ghCurve is a generalized Hessian curve
+type ghCurve struct {
+	*params
+	toW RationalMap
+}
+
+type GH = *ghCurve
+
+func (e *ghCurve) String() string {
+	return fmt.Sprintf("X^3+Y^3+cZ^3=dXYZ\nF: %v\nc: %v\nd: %v\n", e.F, e.A, e.D)
+}
+func (e *ghCurve) New() EllCurve {
+	e.params.D = e.params.B
+	if e.IsValid() {
+		e.toW = e.ToWeierstrassGeneral()
+		return e
+	}
+	panic(errors.New("can't instantiate a generalized Hessian curve"))
+}
+
+// NewPoint returns the point (x:y:1).
+func (e *ghCurve) NewPoint(x, y GF.Elt) (P Point) {
+	if P = (&ptGh{e, &prPoint{x, y, e.F.One()}}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p:%v not on %v", P, e))
+}
+
+// Discriminant returns the discriminant of the curve given by
+// ToWeierstrassGeneral.
+func (e *ghCurve) Discriminant() GF.Elt { return e.toW.Codomain().(*geCurve).Discriminant() }
+func (e *ghCurve) JInvariant() GF.Elt   { return e.toW.Codomain().(*geCurve).JInvariant() }
+func (e *ghCurve) IsValid() bool {
+	F := e.F
+	t0 := F.Sqr(e.D)            // d^2
+	t0 = F.Mul(t0, e.D)         // d^3
+	t1 := F.Mul(F.Elt(27), e.A) // 27c
+	t0 = F.Sub(t0, t1)          // d^3-27c
+	t0 = F.Mul(t0, e.A)         // c(d^3-27c)
+	return !F.IsZero(t0)        // c(d^3-27c) != 0
+}
+func (e *ghCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*ghCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
+}
+func (e *ghCurve) IsOnCurve(p Point) bool {
+	P := p.(*ptGh)
+	F := e.F
+	if P.isZero(F) {
+		return false
+	}
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.x)      // X^2
+	t0 = F.Mul(t0, P.x)  // X^3
+	t1 = F.Sqr(P.y)      // Y^2
+	t1 = F.Mul(t1, P.y)  // Y^3
+	t0 = F.Add(t0, t1)   // X^3+Y^3
+	t1 = F.Sqr(P.z)      // Z^2
+	t1 = F.Mul(t1, P.z)  // Z^3
+	t1 = F.Mul(t1, e.A)  // cZ^3
+	t0 = F.Add(t0, t1)   // X^3+Y^3+cZ^3
+	t1 = F.Mul(P.x, P.y) // XY
+	t1 = F.Mul(t1, P.z)  // XYZ
+	t1 = F.Mul(t1, e.D)  // dXYZ
+	return F.AreEqual(t0, t1)
+}
+func (e *ghCurve) Identity() Point {
+	F := e.F
+	return &ptGh{e, &prPoint{F.One(), F.Elt(-1), F.Zero()}}
+}
+func (e *ghCurve) Neg(p Point) Point {
+	P := p.(*ptGh)
+	return &ptGh{e, &prPoint{P.y.Copy(), P.x.Copy(), P.z.Copy()}}
+}
+
+// Add calculates P+Q using the rotated addition law, which is complete if c
+// is not a cube. Otherwise, the standard addition law is used for the inputs
+// not covered by the rotated one.
+func (e *ghCurve) Add(p, q Point) Point {
+	P := p.(*ptGh)
+	Q := q.(*ptGh)
+	F := e.F
+
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(Q.x)     // X2^2
+	t0 = F.Mul(t0, P.x) // X1X2^2
+	t0 = F.Mul(t0, P.y) // X1Y1X2^2
+	t1 = F.Sqr(P.z)     // Z1^2
+	t1 = F.Mul(t1, Q.y) // Y2Z1^2
+	t1 = F.Mul(t1, Q.z) // Y2Z2Z1^2
+	t1 = F.Mul(t1, e.A) // cY2Z2Z1^2
+	x := F.Sub(t0, t1)  // X3 = X1Y1X2^2-cY2Z2Z1^2
+
+	t0 = F.Sqr(Q.z)     // Z2^2
+	t0 = F.Mul(t0, P.x) // X1Z2^2
+	t0 = F.Mul(t0, P.z) // X1Z1Z2^2
+	t0 = F.Mul(t0, e.A) // cX1Z1Z2^2
+	t1 = F.Sqr(P.y)     // Y1^2
+	t1 = F.Mul(t1, Q.x) // X2Y1^2
+	t1 = F.Mul(t1, Q.y) // X2Y2Y1^2
+	y := F.Sub(t0, t1)  // Y3 = cX1Z1Z2^2-X2Y2Y1^2
+
+	t0 = F.Sqr(Q.y)     // Y2^2
+	t0 = F.Mul(t0, P.y) // Y1Y2^2
+	t0 = F.Mul(t0, P.z) // Y1Z1Y2^2
+	t1 = F.Sqr(P.x)     // X1^2
+	t1 = F.Mul(t1, Q.x) // X2X1^2
+	t1 = F.Mul(t1, Q.z) // X2Z2X1^2
+	z := F.Sub(t0, t1)  // Z3 = Y1Z1Y2^2-X2Z2X1^2
+
+	if R := (&ptGh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	return e.addStandard(P, Q)
+}
+
+// addStandard calculates P+Q using the standard addition law, which is not
+// defined for P=Q.
+func (e *ghCurve) addStandard(P, Q *ptGh) Point {
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.y)     // Y1^2
+	t0 = F.Mul(t0, Q.x) // X2Y1^2
+	t0 = F.Mul(t0, Q.z) // X2Z2Y1^2
+	t1 = F.Sqr(Q.y)     // Y2^2
+	t1 = F.Mul(t1, P.x) // X1Y2^2
+	t1 = F.Mul(t1, P.z) // X1Z1Y2^2
+	x := F.Sub(t0, t1)  // X3 = Y1^2X2Z2-Y2^2X1Z1
+
+	t0 = F.Sqr(P.x)     // X1^2
+	t0 = F.Mul(t0, Q.y) // Y2X1^2
+	t0 = F.Mul(t0, Q.z) // Y2Z2X1^2
+	t1 = F.Sqr(Q.x)     // X2^2
+	t1 = F.Mul(t1, P.y) // Y1X2^2
+	t1 = F.Mul(t1, P.z) // Y1Z1X2^2
+	y := F.Sub(t0, t1)  // Y3 = X1^2Y2Z2-X2^2Y1Z1
+
+	t0 = F.Sqr(P.z)     // Z1^2
+	t0 = F.Mul(t0, Q.x) // X2Z1^2
+	t0 = F.Mul(t0, Q.y) // X2Y2Z1^2
+	t1 = F.Sqr(Q.z)     // Z2^2
+	t1 = F.Mul(t1, P.x) // X1Z2^2
+	t1 = F.Mul(t1, P.y) // X1Y1Z2^2
+	z := F.Sub(t0, t1)  // Z3 = Z1^2X2Y2-Z2^2X1Y1
+
+	if R := (&ptGh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	panic(errors.New("addition law not defined"))
+}
+func (e *ghCurve) Double(p Point) Point {
+	P := p.(*ptGh)
+	F := e.F
+	var x3, y3, z3, t0 GF.Elt
+	x3 = F.Sqr(P.x)     // X^2
+	x3 = F.Mul(x3, P.x) // X^3
+	y3 = F.Sqr(P.y)     // Y^2
+	y3 = F.Mul(y3, P.y) // Y^3
+	z3 = F.Sqr(P.z)     // Z^2
+	z3 = F.Mul(z3, P.z) // Z^3
+	z3 = F.Mul(z3, e.A) // cZ^3
+
+	t0 = F.Sub(z3, x3)  // cZ^3-X^3
+	x := F.Mul(t0, P.y) // X3 = Y(cZ^3-X^3)
+	t0 = F.Sub(y3, z3)  // Y^3-cZ^3
+	y := F.Mul(t0, P.x) // Y3 = X(Y^3-cZ^3)
+	t0 = F.Sub(x3, y3)  // X^3-Y^3
+	z := F.Mul(t0, P.z) // Z3 = Z(X^3-Y^3)
+
+	if R := (&ptGh{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	return e.Add(p, p)
+}
+func (e *ghCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *ghCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToWeierstrassGeneral returns a map to the curve
+// y^2=x^3-27d^2x^2+54bdx-27b^2, where b=4(d^3-27c), sending the tangent line
+// at the identity to the line at infinity.
+func (e *ghCurve) ToWeierstrassGeneral() RationalMap {
+	F := e.F
+	var t0, b GF.Elt
+	t0 = F.Sqr(e.D)                     // d^2
+	b = F.Mul(t0, e.D)                  // d^3
+	b = F.Sub(b, F.Mul(F.Elt(27), e.A)) // d^3-27c
+	b = F.Mul(F.Elt(4), b)              // b = 4(d^3-27c)
+
+	a2 := F.Mul(F.Elt(-27), t0) // a2 = -27d^2
+	a4 := F.Mul(b, e.D)         // bd
+	a4 = F.Mul(F.Elt(54), a4)   // a4 = 54bd
+	a6 := F.Sqr(b)              // b^2
+	a6 = F.Mul(F.Elt(-27), a6)  // a6 = -27b^2
+	z := F.Zero()
+	e1 := NewWeierstrassGeneral("WG from "+e.Name, F, z, a2, z, a4, a6, e.params.R, e.params.H)
+	return &gh2ge{E0: e, E1: e1.(*geCurve), b: b}
+}
+
+// ToWeierstrass returns a map to a short Weierstrass curve.
+func (e *ghCurve) ToWeierstrass() RationalMap {
+	m := e.ToWeierstrassGeneral()
+	return Compose(m, m.Codomain().(*geCurve).ToWeierstrass())
+}
+
+// ToTwistedHessian returns the map (X:Y:Z) -> (Z:X:Y) to the curve
+// cX^3+Y^3+Z^3=dXYZ.
+func (e *ghCurve) ToTwistedHessian() RationalMap {
+	e0 := TwistedHessian.New("TH from "+e.Name, e.F, e.A, e.D, e.params.R, e.params.H)
+	return Invert(&th2gh{E0: e0.(*thCurve), E1: e})
+}
+
+type gh2ge struct {
+	E0 *ghCurve
+	E1 *geCurve
+	b  GF.Elt // 4(d^3-27c)
+}
+
+func (r *gh2ge) Domain() EllCurve   { return r.E0 }
+func (r *gh2ge) Codomain() EllCurve { return r.E1 }
+func (r *gh2ge) Push(p Point) Point {
+	P := p.(*ptGh)
+	F := r.E0.F
+	var t0, t1 GF.Elt
+	t0 = F.Add(P.x, P.y)     // X+Y
+	t0 = F.Mul(F.Elt(3), t0) // 3(X+Y)
+	t1 = F.Mul(r.E0.D, P.z)  // dZ
+	t0 = F.Add(t0, t1)       // L = 3(X+Y)+dZ
+	if F.IsZero(t0) {
+		return r.E1.Identity()
+	}
+	t0 = F.Inv(t0)            // 1/L
+	t0 = F.Mul(t0, r.b)       // b/L
+	t1 = F.Mul(F.Elt(3), P.z) // 3Z
+	x := F.Mul(t1, t0)        // x = 3bZ/L
+	t1 = F.Sub(P.x, P.y)      // X-Y
+	t1 = F.Mul(F.Elt(27), t1) // 27(X-Y)
+	y := F.Mul(t1, t0)        // y = 27b(X-Y)/L
+	return r.E1.NewPoint(x, y)
+}
+func (r *gh2ge) Pull(p Point) Point {
+	if p.IsIdentity() {
+		return r.E0.Identity()
+	}
+	P := p.(*ptGe)
+	F := r.E0.F
+	var t0, t1 GF.Elt
+	t0 = F.Mul(F.Elt(9), r.b)  // 9b
+	t1 = F.Mul(r.E0.D, P.x)    // dx
+	t1 = F.Mul(F.Elt(3), t1)   // 3dx
+	t0 = F.Sub(t0, t1)         // 9b-3dx
+	X := F.Add(t0, P.y)        // X = 9b-3dx+y
+	Y := F.Sub(t0, P.y)        // Y = 9b-3dx-y
+	Z := F.Mul(F.Elt(18), P.x) // Z = 18x
+	return &ptGh{r.E0, &prPoint{X, Y, Z}}
+}
+
+// ptGh is a projective point on a generalized Hessian curve.
+type ptGh struct {
+	*ghCurve
+	*prPoint
+}
+
+func (p *ptGh) String() string { return p.prPoint.String() }
+func (p *ptGh) Copy() Point    { return &ptGh{p.ghCurve, p.copy()} }
+func (p *ptGh) X() GF.Elt      { x, _ := p.affine(p.F); return x }
+func (p *ptGh) Y() GF.Elt      { _, y := p.affine(p.F); return y }
+func (p *ptGh) IsEqual(q Point) bool {
+	qq, ok := q.(*ptGh)
+	return ok && p.ghCurve.IsEqual(qq.ghCurve) && p.isEqual(p.F, qq.prPoint)
+}
+func (p *ptGh) IsIdentity() bool { return p.IsEqual(p.ghCurve.Identity()) }
+func (p *ptGh) IsTwoTorsion() bool {
+	return !p.IsIdentity() && p.F.AreEqual(p.x, p.y)
+}
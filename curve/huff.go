@@ -0,0 +1,223 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// hfCurve is a Huff curve
+type hfCurve struct {
+	*params
+	toW RationalMap
+}
+
+type HF = *hfCurve
+
+func (e *hfCurve) String() string {
+	return fmt.Sprintf("ax(y^2-1)=by(x^2-1)\nF: %v\na: %v\nb: %v\n", e.F, e.A, e.B)
+}
+func (e *hfCurve) New() EllCurve {
+	if e.IsValid() {
+		e.toW = e.ToWeierstrassC()
+		return e
+	}
+	panic(errors.New("can't instantiate a Huff curve"))
+}
+
+// NewPoint returns the point (x:y:1).
+func (e *hfCurve) NewPoint(x, y GF.Elt) (P Point) {
+	if P = (&ptHf{e, &prPoint{x, y, e.F.One()}}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p:%v not on %v", P, e))
+}
+
+// Discriminant returns the discriminant of the curve given by ToWeierstrassC.
+func (e *hfCurve) Discriminant() GF.Elt { return e.toW.Codomain().(*wcCurve).Discriminant() }
+func (e *hfCurve) JInvariant() GF.Elt   { return e.toW.Codomain().(*wcCurve).JInvariant() }
+func (e *hfCurve) IsValid() bool {
+	F := e.F
+	t0 := F.Sqr(e.A)           // a^2
+	t0 = F.Sub(t0, F.Sqr(e.B)) // a^2-b^2
+	t0 = F.Mul(t0, e.A)        // a(a^2-b^2)
+	t0 = F.Mul(t0, e.B)        // ab(a^2-b^2)
+	return !F.IsZero(t0)       // ab(a^2-b^2) != 0
+}
+func (e *hfCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*hfCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+}
+func (e *hfCurve) IsOnCurve(p Point) bool {
+	P := p.(*ptHf)
+	F := e.F
+	if P.isZero(F) {
+		return false
+	}
+	var t0, t1, zz GF.Elt
+	zz = F.Sqr(P.z)     // Z^2
+	t0 = F.Sqr(P.y)     // Y^2
+	t0 = F.Sub(t0, zz)  // Y^2-Z^2
+	t0 = F.Mul(t0, P.x) // X(Y^2-Z^2)
+	t0 = F.Mul(t0, e.A) // aX(Y^2-Z^2)
+	t1 = F.Sqr(P.x)     // X^2
+	t1 = F.Sub(t1, zz)  // X^2-Z^2
+	t1 = F.Mul(t1, P.y) // Y(X^2-Z^2)
+	t1 = F.Mul(t1, e.B) // bY(X^2-Z^2)
+	return F.AreEqual(t0, t1)
+}
+func (e *hfCurve) Identity() Point {
+	F := e.F
+	return &ptHf{e, &prPoint{F.Zero(), F.Zero(), F.One()}}
+}
+func (e *hfCurve) Neg(p Point) Point {
+	P := p.(*ptHf)
+	F := e.F
+	return &ptHf{e, &prPoint{F.Neg(P.x), F.Neg(P.y), P.z.Copy()}}
+}
+
+// Add calculates P+Q using the unified formulas of Joye-Tibouchi-Vergnaud.
+// The inputs for which the formulas are not defined are added on the
+// WeierstrassC curve.
+func (e *hfCurve) Add(p, q Point) Point {
+	P := p.(*ptHf)
+	Q := q.(*ptHf)
+	F := e.F
+
+	var zz, xx, yy, t0, t1 GF.Elt
+	zz = F.Mul(P.z, Q.z) // Z1Z2
+	xx = F.Mul(P.x, Q.x) // X1X2
+	yy = F.Mul(P.y, Q.y) // Y1Y2
+
+	t0 = F.Mul(P.x, Q.z)      // X1Z2
+	t1 = F.Mul(Q.x, P.z)      // X2Z1
+	t0 = F.Add(t0, t1)        // X1Z2+X2Z1
+	t1 = F.Add(zz, yy)        // Z1Z2+Y1Y2
+	t0 = F.Mul(t0, F.Sqr(t1)) // (X1Z2+X2Z1)(Z1Z2+Y1Y2)^2
+	t1 = F.Sub(zz, xx)        // Z1Z2-X1X2
+	x := F.Mul(t0, t1)        // X3 = (X1Z2+X2Z1)(Z1Z2+Y1Y2)^2(Z1Z2-X1X2)
+
+	t0 = F.Mul(P.y, Q.z)      // Y1Z2
+	t1 = F.Mul(Q.y, P.z)      // Y2Z1
+	t0 = F.Add(t0, t1)        // Y1Z2+Y2Z1
+	t1 = F.Add(zz, xx)        // Z1Z2+X1X2
+	t0 = F.Mul(t0, F.Sqr(t1)) // (Y1Z2+Y2Z1)(Z1Z2+X1X2)^2
+	t1 = F.Sub(zz, yy)        // Z1Z2-Y1Y2
+	y := F.Mul(t0, t1)        // Y3 = (Y1Z2+Y2Z1)(Z1Z2+X1X2)^2(Z1Z2-Y1Y2)
+
+	zz = F.Sqr(zz)     // Z1^2Z2^2
+	t0 = F.Sqr(xx)     // X1^2X2^2
+	t0 = F.Sub(zz, t0) // Z1^2Z2^2-X1^2X2^2
+	t1 = F.Sqr(yy)     // Y1^2Y2^2
+	t1 = F.Sub(zz, t1) // Z1^2Z2^2-Y1^2Y2^2
+	z := F.Mul(t0, t1) // Z3 = (Z1^2Z2^2-X1^2X2^2)(Z1^2Z2^2-Y1^2Y2^2)
+
+	if R := (&ptHf{e, &prPoint{x, y, z}}); !R.isZero(F) {
+		return R
+	}
+	return addViaMap(e.toW, p, q)
+}
+func (e *hfCurve) Double(p Point) Point                 { return e.Add(p, p) }
+func (e *hfCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *hfCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToWeierstrassC returns the map (x,y) -> (u,v) = (ab(ay-bx)/(ax-by),
+// ab(b^2-a^2)/(ax-by)) to the curve v^2=u^3+(a^2+b^2)u^2+a^2b^2u.
+func (e *hfCurve) ToWeierstrassC() RationalMap {
+	F := e.F
+	aa := F.Sqr(e.A)
+	bb := F.Sqr(e.B)
+	e1 := WeierstrassC.New("WC from "+e.Name, F, F.Add(aa, bb), F.Mul(aa, bb), e.params.R, e.params.H)
+	return &hf2wc{E0: e, E1: e1.(*wcCurve)}
+}
+
+// ToWeierstrass returns a map to a short Weierstrass curve.
+func (e *hfCurve) ToWeierstrass() RationalMap {
+	m := e.ToWeierstrassC()
+	return Compose(m, m.Codomain().(*wcCurve).ToWeierstrass())
+}
+
+// toHuff returns a map from e to a Huff curve; it requires that
+// x^2+Ax+B=(x+a^2)(x+b^2) for some a and b in the field.
+func (e *wcCurve) toHuff() (RationalMap, error) {
+	F := e.F
+	t0 := F.Sqr(e.A)                     // A^2
+	t0 = F.Sub(t0, F.Mul(F.Elt(4), e.B)) // A^2-4B
+	if !F.IsSquare(t0) {
+		return nil, errors.New("x^2+Ax+B has no roots")
+	}
+	t0 = F.Sqrt(t0)
+	half := F.Inv(F.Elt(2))
+	aa := F.Mul(F.Add(e.A, t0), half) // a^2 = (A+sqrt(A^2-4B))/2
+	bb := F.Mul(F.Sub(e.A, t0), half) // b^2 = (A-sqrt(A^2-4B))/2
+	if !F.IsSquare(aa) || !F.IsSquare(bb) {
+		return nil, errors.New("roots of x^2+Ax+B are not squares")
+	}
+	a, b := F.Sqrt(aa), F.Sqrt(bb)
+	e0 := Huff.New("HF from "+e.Name, F, a, b, e.params.R, e.params.H)
+	return Invert(&hf2wc{E0: e0.(*hfCurve), E1: e}), nil
+}
+
+type hf2wc struct {
+	E0 *hfCurve
+	E1 *wcCurve
+}
+
+func (r *hf2wc) Domain() EllCurve   { return r.E0 }
+func (r *hf2wc) Codomain() EllCurve { return r.E1 }
+func (r *hf2wc) Push(p Point) Point {
+	P := p.(*ptHf)
+	F := r.E0.F
+	a, b := r.E0.A, r.E0.B
+	var t0, t1, ab GF.Elt
+	t0 = F.Mul(a, P.x) // aX
+	t1 = F.Mul(b, P.y) // bY
+	t0 = F.Sub(t0, t1) // aX-bY
+	if F.IsZero(t0) {
+		return r.E1.Identity()
+	}
+	ab = F.Mul(a, b)              // ab
+	t0 = F.Inv(t0)                // 1/(aX-bY)
+	t0 = F.Mul(t0, ab)            // ab/(aX-bY)
+	t1 = F.Mul(a, P.y)            // aY
+	t1 = F.Sub(t1, F.Mul(b, P.x)) // aY-bX
+	u := F.Mul(t1, t0)            // u = ab(aY-bX)/(aX-bY)
+	t1 = F.Sqr(b)                 // b^2
+	t1 = F.Sub(t1, F.Sqr(a))      // b^2-a^2
+	t1 = F.Mul(t1, P.z)           // (b^2-a^2)Z
+	v := F.Mul(t1, t0)            // v = ab(b^2-a^2)Z/(aX-bY)
+	return r.E1.NewPoint(u, v)
+}
+func (r *hf2wc) Pull(p Point) Point {
+	if p.IsIdentity() {
+		return r.E0.Identity()
+	}
+	P := p.(*ptWc)
+	F := r.E0.F
+	a, b := r.E0.A, r.E0.B
+	x := F.Add(P.x, F.Sqr(a)) // u+a^2
+	x = F.Mul(x, b)           // X = b(u+a^2)
+	y := F.Add(P.x, F.Sqr(b)) // u+b^2
+	y = F.Mul(y, a)           // Y = a(u+b^2)
+	z := F.Neg(P.y)           // Z = -v
+	return &ptHf{r.E0, &prPoint{x, y, z}}
+}
+
+// ptHf is a projective point on a Huff curve.
+type ptHf struct {
+	*hfCurve
+	*prPoint
+}
+
+func (p *ptHf) String() string { return p.prPoint.String() }
+func (p *ptHf) Copy() Point    { return &ptHf{p.hfCurve, p.copy()} }
+func (p *ptHf) X() GF.Elt      { x, _ := p.affine(p.F); return x }
+func (p *ptHf) Y() GF.Elt      { _, y := p.affine(p.F); return y }
+func (p *ptHf) IsEqual(q Point) bool {
+	qq, ok := q.(*ptHf)
+	return ok && p.hfCurve.IsEqual(qq.hfCurve) && p.isEqual(p.F, qq.prPoint)
+}
+func (p *ptHf) IsIdentity() bool   { return p.IsEqual(p.hfCurve.Identity()) }
+func (p *ptHf) IsTwoTorsion() bool { return !p.IsIdentity() && p.F.IsZero(p.z) }
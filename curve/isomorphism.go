@@ -0,0 +1,178 @@
+package curve
+
+import (
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// Isomorphism is the change of variables x=u^2x'+r, y=u^3y'+su^2x'+t, which
+// maps a point (x,y) of E0 to the point (x',y') of E1. Both curves are given
+// by a Weierstrass equation.
+type Isomorphism struct {
+	E0, E1     EllCurve
+	U, R, S, T GF.Elt
+}
+
+func (m *Isomorphism) Domain() EllCurve   { return m.E0 }
+func (m *Isomorphism) Codomain() EllCurve { return m.E1 }
+func (m *Isomorphism) Push(p Point) Point {
+	if p.IsIdentity() {
+		return m.E1.Identity()
+	}
+	F := m.E0.Field()
+	var t0, t1 GF.Elt
+	t0 = F.Inv(m.U)                  // 1/u
+	t1 = F.Sqr(t0)                   // 1/u^2
+	t0 = F.Mul(t0, t1)               // 1/u^3
+	x := F.Sub(p.X(), m.R)           // x-r
+	y := F.Sub(p.Y(), F.Mul(m.S, x)) // y-s(x-r)
+	y = F.Sub(y, m.T)                // y-s(x-r)-t
+	y = F.Mul(y, t0)                 // y' = (y-s(x-r)-t)/u^3
+	x = F.Mul(x, t1)                 // x' = (x-r)/u^2
+	return m.E1.NewPoint(x, y)
+}
+func (m *Isomorphism) Pull(p Point) Point {
+	if p.IsIdentity() {
+		return m.E0.Identity()
+	}
+	F := m.E0.Field()
+	t0 := F.Sqr(m.U)            // u^2
+	x := F.Mul(t0, p.X())       // u^2x'
+	t0 = F.Mul(t0, m.U)         // u^3
+	y := F.Mul(t0, p.Y())       // u^3y'
+	y = F.Add(y, F.Mul(m.S, x)) // u^3y'+su^2x'
+	y = F.Add(y, m.T)           // y = u^3y'+su^2x'+t
+	x = F.Add(x, m.R)           // x = u^2x'+r
+	return m.E0.NewPoint(x, y)
+}
+
+// IsIsomorphic reports whether E0 and E1 are isomorphic over their base field,
+// and if so, returns an isomorphism from E0 to E1. If both curves are given by
+// a Weierstrass equation (Weierstrass, WeierstrassC and WeierstrassGeneral
+// models), the map is an *Isomorphism; otherwise, the *Isomorphism is composed
+// with the birational maps between the curves and their general Weierstrass
+// forms.
+func IsIsomorphic(E0, E1 EllCurve) (RationalMap, bool) {
+	if !E0.Field().IsEqual(E1.Field()) {
+		return nil, false
+	}
+	D0, g0, m0, ok := toWeierstrassForm(E0)
+	if !ok {
+		return nil, false
+	}
+	D1, g1, m1, ok := toWeierstrassForm(E1)
+	if !ok {
+		return nil, false
+	}
+	u, r, s, t, ok := g0.isomorphism(g1)
+	if !ok {
+		return nil, false
+	}
+	var m RationalMap = &Isomorphism{E0: D0, E1: D1, U: u, R: r, S: s, T: t}
+	if m0 != nil {
+		m = Compose(m0, m)
+	}
+	if m1 != nil {
+		m = Compose(m, Invert(m1))
+	}
+	return m, true
+}
+
+// toWeierstrassForm returns a curve D given by a Weierstrass equation with
+// coefficients g, and a map from E to D. The map is nil if D is E.
+func toWeierstrassForm(E EllCurve) (D EllCurve, g *geCurve, m RationalMap, ok bool) {
+	switch e := E.(type) {
+	case *weCurve:
+		z := e.F.Zero()
+		return e, &geCurve{params: e.params, a1: z, a2: z, a3: z, a4: e.A, a6: e.B}, nil, true
+	case *wcCurve:
+		z := e.F.Zero()
+		return e, &geCurve{params: e.params, a1: z, a2: e.A, a3: z, a4: e.B, a6: z}, nil, true
+	case *geCurve:
+		return e, e, nil, true
+	}
+	D, m, err := ConvertTo(E, WeierstrassGeneral)
+	if err != nil {
+		return nil, nil, nil, false
+	}
+	return D, D.(*geCurve), m, true
+}
+
+// isomorphism returns (u,r,s,t) such that the change of variables x=u^2x'+r,
+// y=u^3y'+su^2x'+t transforms e into e1.
+func (e *geCurve) isomorphism(e1 *geCurve) (u, r, s, t GF.Elt, ok bool) {
+	F := e.F
+	z, one := F.Zero(), F.One()
+	c4, c6 := e.cInvariants()
+	d4, d6 := e1.cInvariants()
+
+	// The c-invariants satisfy c4 = u^4d4 and c6 = u^6d6.
+	var f []GF.Elt
+	switch {
+	case F.IsZero(c4) != F.IsZero(d4) || F.IsZero(c6) != F.IsZero(d6):
+		return
+	case F.IsZero(c4): // j = 0
+		t0 := F.Mul(c6, F.Inv(d6)) // c6/d6
+		f = []GF.Elt{F.Neg(t0), z, z, z, z, z, one}
+	case F.IsZero(c6): // j = 1728
+		t0 := F.Mul(c4, F.Inv(d4)) // c4/d4
+		f = []GF.Elt{F.Neg(t0), z, z, z, one}
+	default:
+		t0 := F.Mul(c6, d4)       // c6d4
+		t1 := F.Mul(c4, d6)       // c4d6
+		t0 = F.Mul(t0, F.Inv(t1)) // u^2 = c6d4/(c4d6)
+		f = []GF.Elt{F.Neg(t0), z, one}
+	}
+	for _, u = range poly.NewRing(F).Roots(f) {
+		if r, s, t, ok = e.translation(u, e1); ok {
+			return
+		}
+	}
+	return
+}
+
+// translation returns (r,s,t) such that the change of variables
+// x=u^2x'+r, y=u^3y'+su^2x'+t transforms e into e1, following the
+// formulas in Table 3.1 of Silverman's "The Arithmetic of Elliptic Curves".
+func (e *geCurve) translation(u GF.Elt, e1 *geCurve) (r, s, t GF.Elt, ok bool) {
+	F := e.F
+	var t0, t1 GF.Elt
+	half := F.Inv(F.Elt(2))
+	u2 := F.Sqr(u)     // u^2
+	u3 := F.Mul(u2, u) // u^3
+
+	s = F.Mul(u, e1.a1)           // ua1'
+	s = F.Sub(s, e.a1)            // ua1'-a1
+	s = F.Mul(s, half)            // s = (ua1'-a1)/2
+	r = F.Mul(u2, e1.a2)          // u^2a2'
+	r = F.Sub(r, e.a2)            // u^2a2'-a2
+	t0 = F.Mul(s, e.a1)           // sa1
+	r = F.Add(r, t0)              // u^2a2'-a2+sa1
+	r = F.Add(r, F.Sqr(s))        // u^2a2'-a2+sa1+s^2
+	r = F.Mul(r, F.Inv(F.Elt(3))) // r = (u^2a2'-a2+sa1+s^2)/3
+	t = F.Mul(u3, e1.a3)          // u^3a3'
+	t = F.Sub(t, e.a3)            // u^3a3'-a3
+	t = F.Sub(t, F.Mul(r, e.a1))  // u^3a3'-a3-ra1
+	t = F.Mul(t, half)            // t = (u^3a3'-a3-ra1)/2
+
+	// u^4a4' = a4-sa3+2ra2-(t+rs)a1+3r^2-2st
+	t0 = F.Sub(e.a4, F.Mul(s, e.a3))                   // a4-sa3
+	t0 = F.Add(t0, F.Mul(F.Add(r, r), e.a2))           // a4-sa3+2ra2
+	t0 = F.Sub(t0, F.Mul(F.Add(t, F.Mul(r, s)), e.a1)) // a4-sa3+2ra2-(t+rs)a1
+	t0 = F.Add(t0, F.Mul(F.Elt(3), F.Sqr(r)))          // a4-sa3+2ra2-(t+rs)a1+3r^2
+	t1 = F.Mul(s, t)                                   // st
+	t0 = F.Sub(t0, F.Add(t1, t1))                      // a4-sa3+2ra2-(t+rs)a1+3r^2-2st
+	if !F.AreEqual(t0, F.Mul(F.Sqr(u2), e1.a4)) {
+		return
+	}
+	// u^6a6' = a6+ra4+r^2a2+r^3-ta3-t^2-rta1
+	t0 = F.Add(e.a6, F.Mul(r, e.a4))         // a6+ra4
+	t1 = F.Sqr(r)                            // r^2
+	t0 = F.Add(t0, F.Mul(t1, e.a2))          // a6+ra4+r^2a2
+	t0 = F.Add(t0, F.Mul(t1, r))             // a6+ra4+r^2a2+r^3
+	t0 = F.Sub(t0, F.Mul(t, e.a3))           // a6+ra4+r^2a2+r^3-ta3
+	t0 = F.Sub(t0, F.Sqr(t))                 // a6+ra4+r^2a2+r^3-ta3-t^2
+	t0 = F.Sub(t0, F.Mul(F.Mul(r, t), e.a1)) // a6+ra4+r^2a2+r^3-ta3-t^2-rta1
+	ok = F.AreEqual(t0, F.Mul(F.Sqr(u3), e1.a6))
+	return
+}
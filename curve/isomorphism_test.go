@@ -0,0 +1,93 @@
+package curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func testMap(t *testing.T, m C.RationalMap, g C.Point) {
+	E0, E1 := m.Domain(), m.Codomain()
+	P := E0.Identity()
+	for i := 0; i < 10; i++ {
+		Q := m.Push(P)
+		if !E1.IsOnCurve(Q) {
+			t.Fatalf("point not in the curve: %v", Q)
+		}
+		if got := m.Pull(Q); !got.IsEqual(P) {
+			t.Fatalf("got: %v\nwant: %v", got, P)
+		}
+		got := E1.Add(Q, m.Push(g))
+		P = E0.Add(P, g)
+		if want := m.Push(P); !got.IsEqual(want) {
+			t.Fatalf("got: %v\nwant: %v", got, want)
+		}
+	}
+}
+
+func TestIsIsomorphic(t *testing.T) {
+	models := []C.Model{C.Weierstrass, C.WeierstrassC, C.WeierstrassGeneral,
+		C.Montgomery, C.TwistedEdwards, C.JacobiQuartic, C.JacobiIntersection, C.Huff}
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		F := E.Field()
+		for _, model := range models {
+			E1, _, err := C.ConvertTo(E, model)
+			if err != nil {
+				continue
+			}
+			if got, want := E1.(C.Invariants).JInvariant(), E.(C.Invariants).JInvariant(); !F.AreEqual(got, want) {
+				t.Fatalf("%v to %v:\ngot:  %v\nwant: %v", curveID, model, got, want)
+			}
+			m, ok := C.IsIsomorphic(E, E1)
+			if !ok {
+				t.Fatalf("%v to %v: isomorphism not found", curveID, model)
+			}
+			if !m.Domain().IsEqual(E) || !m.Codomain().IsEqual(E1) {
+				t.Fatalf("%v to %v: wrong domain or codomain", curveID, model)
+			}
+			testMap(t, m, g)
+		}
+	}
+}
+
+func TestIsIsomorphicWeierstrass(t *testing.T) {
+	F := GF.NewFp("103", 103)
+	E := C.NewWeierstrassGeneral("WG0", F, F.Elt(1), F.Elt(2), F.Elt(3), F.Elt(5), F.Elt(7), nil, nil)
+	W := E.(C.WG).ToWeierstrass().Codomain().(C.W)
+	m, ok := C.IsIsomorphic(W, E)
+	if !ok {
+		t.Fatal("isomorphism not found")
+	}
+	iso, ok := m.(*C.Isomorphism)
+	if !ok {
+		t.Fatalf("got: %T\nwant: *curve.Isomorphism", m)
+	}
+	for x := 0; x < 103; x++ {
+		y2 := W.EvalRHS(F.Elt(x))
+		if F.IsSquare(y2) {
+			testMap(t, iso, W.NewPoint(F.Elt(x), F.Sqrt(y2)))
+			break
+		}
+	}
+
+	// y^2=x^3+2 and y^2=x^3+2c are isomorphic iff c is a sixth power, and
+	// y^2=x^3+2x and y^2=x^3+2cx are isomorphic iff c is a fourth power.
+	sixth, fourth := big.NewInt(17), big.NewInt(51)
+	E0 := C.Weierstrass.New("j=0", F, F.Zero(), F.Elt(2), nil, nil)
+	E1728 := C.Weierstrass.New("j=1728", F, F.Elt(2), F.Zero(), nil, nil)
+	for i := 1; i < 103; i++ {
+		c := F.Elt(i)
+		E := C.Weierstrass.New("", F, F.Zero(), F.Mul(F.Elt(2), c), nil, nil)
+		if _, got := C.IsIsomorphic(E0, E); got != F.AreEqual(F.Exp(c, sixth), F.One()) {
+			t.Fatalf("c: %v got: %v", c, got)
+		}
+		E = C.Weierstrass.New("", F, F.Mul(F.Elt(2), c), F.Zero(), nil, nil)
+		if _, got := C.IsIsomorphic(E1728, E); got != F.AreEqual(F.Exp(c, fourth), F.One()) {
+			t.Fatalf("c: %v got: %v", c, got)
+		}
+	}
+}
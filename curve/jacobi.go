@@ -0,0 +1,493 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// jqCurve is a Jacobi quartic curve
+type jqCurve struct {
+	*params
+	toW RationalMap
+}
+
+type JQ = *jqCurve
+
+func (e *jqCurve) String() string {
+	return fmt.Sprintf("y^2=dx^4+2ax^2+1\nF: %v\na: %v\nd: %v\n", e.F, e.A, e.D)
+}
+func (e *jqCurve) New() EllCurve {
+	e.params.D = e.params.B
+	if e.IsValid() {
+		e.toW = e.ToWeierstrassC()
+		return e
+	}
+	panic(errors.New("can't instantiate a Jacobi quartic curve"))
+}
+
+// NewPoint returns the point (x:y:1) in weighted projective coordinates.
+func (e *jqCurve) NewPoint(x, y GF.Elt) (P Point) {
+	if P = (&ptJq{e, &prPoint{x, y, e.F.One()}}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p:%v not on %v", P, e))
+}
+
+// Discriminant returns the discriminant of the curve given by ToWeierstrassC.
+func (e *jqCurve) Discriminant() GF.Elt { return e.toW.Codomain().(*wcCurve).Discriminant() }
+func (e *jqCurve) JInvariant() GF.Elt   { return e.toW.Codomain().(*wcCurve).JInvariant() }
+func (e *jqCurve) IsValid() bool {
+	F := e.F
+	t0 := F.Sqr(e.A)     // a^2
+	t0 = F.Sub(t0, e.D)  // a^2-d
+	t0 = F.Mul(t0, e.D)  // d(a^2-d)
+	return !F.IsZero(t0) // d(a^2-d) != 0
+}
+func (e *jqCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*jqCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
+}
+func (e *jqCurve) IsOnCurve(p Point) bool {
+	P := p.(*ptJq)
+	F := e.F
+	if F.IsZero(P.x) && F.IsZero(P.z) {
+		return false
+	}
+	var t0, t1, t2 GF.Elt
+	t0 = F.Sqr(P.x)     // X^2
+	t1 = F.Sqr(P.z)     // Z^2
+	t2 = F.Mul(t0, e.D) // dX^2
+	t1 = F.Mul(t1, e.A) // aZ^2
+	t1 = F.Add(t1, t1)  // 2aZ^2
+	t2 = F.Add(t2, t1)  // dX^2+2aZ^2
+	t2 = F.Mul(t2, t0)  // dX^4+2aX^2Z^2
+	t1 = F.Sqr(P.z)     // Z^2
+	t1 = F.Sqr(t1)      // Z^4
+	t2 = F.Add(t2, t1)  // dX^4+2aX^2Z^2+Z^4
+	return F.AreEqual(F.Sqr(P.y), t2)
+}
+func (e *jqCurve) Identity() Point {
+	F := e.F
+	return &ptJq{e, &prPoint{F.Zero(), F.One(), F.One()}}
+}
+func (e *jqCurve) Neg(p Point) Point {
+	P := p.(*ptJq)
+	return &ptJq{e, &prPoint{e.F.Neg(P.x), P.y.Copy(), P.z.Copy()}}
+}
+
+// Add calculates P+Q using the unified formulas of Hisil-Wong-Carter-Dawson.
+// The inputs for which the formulas are not defined are added on the
+// WeierstrassC curve.
+func (e *jqCurve) Add(p, q Point) Point {
+	P := p.(*ptJq)
+	Q := q.(*ptJq)
+	F := e.F
+
+	var t0, t1, xx, zz, dxx GF.Elt
+	t0 = F.Mul(P.x, P.z) // X1Z1
+	t0 = F.Mul(t0, Q.y)  // X1Z1Y2
+	t1 = F.Mul(Q.x, Q.z) // X2Z2
+	t1 = F.Mul(t1, P.y)  // Y1X2Z2
+	x := F.Add(t0, t1)   // X3 = X1Z1Y2+Y1X2Z2
+
+	xx = F.Mul(P.x, Q.x)  // X1X2
+	zz = F.Mul(P.z, Q.z)  // Z1Z2
+	t0 = F.Sqr(zz)        // Z1^2Z2^2
+	dxx = F.Sqr(xx)       // X1^2X2^2
+	dxx = F.Mul(dxx, e.D) // dX1^2X2^2
+	z := F.Sub(t0, dxx)   // Z3 = Z1^2Z2^2-dX1^2X2^2
+
+	t0 = F.Add(t0, dxx)  // Z1^2Z2^2+dX1^2X2^2
+	t1 = F.Mul(xx, zz)   // X1X2Z1Z2
+	xx = F.Mul(t1, e.A)  // aX1X2Z1Z2
+	xx = F.Add(xx, xx)   // 2aX1X2Z1Z2
+	zz = F.Mul(P.y, Q.y) // Y1Y2
+	zz = F.Add(zz, xx)   // Y1Y2+2aX1X2Z1Z2
+	t0 = F.Mul(t0, zz)   // (Y1Y2+2aX1X2Z1Z2)(Z1^2Z2^2+dX1^2X2^2)
+	t1 = F.Mul(t1, e.D)  // dX1X2Z1Z2
+	t1 = F.Add(t1, t1)   // 2dX1X2Z1Z2
+	xx = F.Mul(P.x, Q.z) // X1Z2
+	xx = F.Sqr(xx)       // X1^2Z2^2
+	zz = F.Mul(P.z, Q.x) // Z1X2
+	zz = F.Sqr(zz)       // Z1^2X2^2
+	xx = F.Add(xx, zz)   // X1^2Z2^2+Z1^2X2^2
+	t1 = F.Mul(t1, xx)   // 2dX1X2Z1Z2(X1^2Z2^2+Z1^2X2^2)
+	y := F.Add(t0, t1)   // Y3
+
+	if !F.IsZero(x) || !F.IsZero(z) {
+		return &ptJq{e, &prPoint{x, y, z}}
+	}
+	return addViaMap(e.toW, p, q)
+}
+func (e *jqCurve) Double(p Point) Point                 { return e.Add(p, p) }
+func (e *jqCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *jqCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToWeierstrassC returns the map (x,y) -> (u,v) = (2(y+1)/x^2+2a, 2u/x) to
+// the curve v^2=u^3-4au^2+4(a^2-d)u.
+func (e *jqCurve) ToWeierstrassC() RationalMap {
+	F := e.F
+	a := F.Mul(F.Elt(-4), e.A) // -4a
+	b := F.Sqr(e.A)            // a^2
+	b = F.Sub(b, e.D)          // a^2-d
+	b = F.Mul(F.Elt(4), b)     // 4(a^2-d)
+	e1 := WeierstrassC.New("WC from "+e.Name, F, a, b, e.params.R, e.params.H)
+	return &jq2wc{E0: e, E1: e1.(*wcCurve)}
+}
+
+// ToWeierstrass returns a map to a short Weierstrass curve.
+func (e *jqCurve) ToWeierstrass() RationalMap {
+	m := e.ToWeierstrassC()
+	return Compose(m, m.Codomain().(*wcCurve).ToWeierstrass())
+}
+
+// toJacobiQuartic returns a map from e to the Jacobi quartic curve with
+// a=-A/4 and d=a^2-B/4.
+func (e *wcCurve) toJacobiQuartic() RationalMap {
+	F := e.F
+	t0 := F.Inv(F.Elt(4)) // 1/4
+	a := F.Mul(e.A, t0)   // A/4
+	a = F.Neg(a)          // a = -A/4
+	t0 = F.Mul(e.B, t0)   // B/4
+	d := F.Sqr(a)         // a^2
+	d = F.Sub(d, t0)      // d = a^2-B/4
+	e0 := JacobiQuartic.New("JQ from "+e.Name, F, a, d, e.params.R, e.params.H)
+	return Invert(&jq2wc{E0: e0.(*jqCurve), E1: e})
+}
+
+type jq2wc struct {
+	E0 *jqCurve
+	E1 *wcCurve
+}
+
+func (r *jq2wc) Domain() EllCurve   { return r.E0 }
+func (r *jq2wc) Codomain() EllCurve { return r.E1 }
+func (r *jq2wc) Push(p Point) Point {
+	P := p.(*ptJq)
+	F := r.E0.F
+	if F.IsZero(P.x) {
+		if P.IsIdentity() {
+			return r.E1.Identity()
+		}
+		return r.E1.NewPoint(F.Zero(), F.Zero())
+	}
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.z)           // Z^2
+	t0 = F.Add(t0, P.y)       // Y+Z^2
+	t0 = F.Add(t0, t0)        // 2(Y+Z^2)
+	t1 = F.Inv(P.x)           // 1/X
+	t0 = F.Mul(t0, F.Sqr(t1)) // 2(Y+Z^2)/X^2
+	t0 = F.Add(t0, r.E0.A)    // 2(Y+Z^2)/X^2+a
+	u := F.Add(t0, r.E0.A)    // u = 2(Y+Z^2)/X^2+2a
+	t1 = F.Mul(t1, P.z)       // Z/X
+	t1 = F.Mul(t1, u)         // uZ/X
+	v := F.Add(t1, t1)        // v = 2uZ/X
+	return r.E1.NewPoint(u, v)
+}
+func (r *jq2wc) Pull(p Point) Point {
+	if p.IsIdentity() {
+		return r.E0.Identity()
+	}
+	P := p.(*ptWc)
+	F := r.E0.F
+	var t0 GF.Elt
+	half := F.Inv(F.Elt(2))
+	if F.IsZero(P.y) {
+		if F.IsZero(P.x) {
+			return &ptJq{r.E0, &prPoint{F.Zero(), F.Elt(-1), F.One()}}
+		}
+		t0 = F.Sub(P.x, F.Add(r.E0.A, r.E0.A)) // u-2a
+		t0 = F.Mul(t0, half)                   // (u-2a)/2
+		return &ptJq{r.E0, &prPoint{F.One(), t0, F.Zero()}}
+	}
+	t0 = F.Inv(P.y)          // 1/v
+	t0 = F.Mul(t0, P.x)      // u/v
+	x := F.Add(t0, t0)       // x = 2u/v
+	t0 = F.Mul(P.x, half)    // u/2
+	t0 = F.Sub(t0, r.E0.A)   // u/2-a
+	t0 = F.Mul(t0, F.Sqr(x)) // (u/2-a)x^2
+	y := F.Sub(t0, F.One())  // y = (u/2-a)x^2-1
+	return &ptJq{r.E0, &prPoint{x, y, F.One()}}
+}
+
+// ptJq is a point on a Jacobi quartic curve in weighted projective
+// coordinates (X:Y:Z), such that x=X/Z and y=Y/Z^2.
+type ptJq struct {
+	*jqCurve
+	*prPoint
+}
+
+func (p *ptJq) String() string { return p.prPoint.String() }
+func (p *ptJq) Copy() Point    { return &ptJq{p.jqCurve, p.copy()} }
+func (p *ptJq) X() GF.Elt {
+	if p.F.IsZero(p.z) {
+		return nil
+	}
+	return p.F.Mul(p.x, p.F.Inv(p.z))
+}
+func (p *ptJq) Y() GF.Elt {
+	if p.F.IsZero(p.z) {
+		return nil
+	}
+	return p.F.Mul(p.y, p.F.Inv(p.F.Sqr(p.z)))
+}
+func (p *ptJq) IsEqual(q Point) bool {
+	qq, ok := q.(*ptJq)
+	if !ok || !p.jqCurve.IsEqual(qq.jqCurve) {
+		return false
+	}
+	F := p.F
+	return F.AreEqual(F.Mul(p.x, qq.z), F.Mul(qq.x, p.z)) &&
+		F.AreEqual(F.Mul(p.y, F.Sqr(qq.z)), F.Mul(qq.y, F.Sqr(p.z))) &&
+		F.AreEqual(F.Mul(p.y, F.Sqr(qq.x)), F.Mul(qq.y, F.Sqr(p.x)))
+}
+func (p *ptJq) IsIdentity() bool { return p.IsEqual(p.jqCurve.Identity()) }
+func (p *ptJq) IsTwoTorsion() bool {
+	return !p.IsIdentity() && (p.F.IsZero(p.x) || p.F.IsZero(p.z))
+}
+
+// jiCurve is a Jacobi intersection curve
+type jiCurve struct {
+	*params
+	toJQ RationalMap
+}
+
+type JI = *jiCurve
+
+func (e *jiCurve) String() string {
+	return fmt.Sprintf("bs^2+c^2=1, as^2+d^2=1\nF: %v\na: %v\nb: %v\n", e.F, e.A, e.B)
+}
+func (e *jiCurve) New() EllCurve {
+	if e.IsValid() {
+		e.toJQ = e.ToJacobiQuartic()
+		return e
+	}
+	panic(errors.New("can't instantiate a Jacobi intersection curve"))
+}
+
+// NewPoint returns the point (s,c,d), where d is the square root of 1-as^2
+// such that Sgn0(d)=0.
+func (e *jiCurve) NewPoint(s, c GF.Elt) Point {
+	F := e.F
+	t0 := F.Sqr(s)          // s^2
+	t0 = F.Mul(t0, e.A)     // as^2
+	t0 = F.Sub(F.One(), t0) // 1-as^2
+	d := F.Sqrt(t0)
+	d = F.CMov(d, F.Neg(d), F.Sgn0(d) == 1)
+	return e.NewPointSCD(s, c, d)
+}
+
+// NewPointSCD returns the point (s,c,d).
+func (e *jiCurve) NewPointSCD(s, c, d GF.Elt) (P Point) {
+	if P = (&ptJi{e, s, c, d, e.F.One()}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p:%v not on %v", P, e))
+}
+
+// Discriminant returns the discriminant of the curve given by ToJacobiQuartic.
+func (e *jiCurve) Discriminant() GF.Elt { return e.toJQ.Codomain().(*jqCurve).Discriminant() }
+func (e *jiCurve) JInvariant() GF.Elt   { return e.toJQ.Codomain().(*jqCurve).JInvariant() }
+func (e *jiCurve) IsValid() bool {
+	F := e.F
+	t0 := F.Sub(e.A, e.B) // a-b
+	t0 = F.Mul(t0, e.A)   // a(a-b)
+	t0 = F.Mul(t0, e.B)   // ab(a-b)
+	return !F.IsZero(t0)  // ab(a-b) != 0
+}
+func (e *jiCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*jiCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+}
+func (e *jiCurve) IsOnCurve(p Point) bool {
+	P := p.(*ptJi)
+	F := e.F
+	if F.IsZero(P.s) && F.IsZero(P.c) && F.IsZero(P.d) && F.IsZero(P.z) {
+		return false
+	}
+	ss := F.Sqr(P.s) // S^2
+	zz := F.Sqr(P.z) // Z^2
+	t0 := F.Mul(e.B, ss)
+	t0 = F.Add(t0, F.Sqr(P.c)) // bS^2+C^2
+	t1 := F.Mul(e.A, ss)
+	t1 = F.Add(t1, F.Sqr(P.d)) // aS^2+D^2
+	return F.AreEqual(t0, zz) && F.AreEqual(t1, zz)
+}
+func (e *jiCurve) Identity() Point {
+	F := e.F
+	return &ptJi{e, F.Zero(), F.One(), F.One(), F.One()}
+}
+func (e *jiCurve) Neg(p Point) Point {
+	P := p.(*ptJi)
+	return &ptJi{e, e.F.Neg(P.s), P.c.Copy(), P.d.Copy(), P.z.Copy()}
+}
+
+// Add calculates P+Q using the addition formulas of the Jacobi elliptic
+// functions. The inputs for which the formulas are not defined are added on
+// the Jacobi quartic curve.
+func (e *jiCurve) Add(p, q Point) Point {
+	P := p.(*ptJi)
+	Q := q.(*ptJi)
+	F := e.F
+
+	var t0, t1, ss, sc, sd GF.Elt
+	t0 = F.Mul(Q.c, Q.d) // C2D2
+	t0 = F.Mul(t0, P.s)  // S1C2D2
+	t0 = F.Mul(t0, P.z)  // S1C2D2Z1
+	t1 = F.Mul(P.c, P.d) // C1D1
+	t1 = F.Mul(t1, Q.s)  // C1D1S2
+	t1 = F.Mul(t1, Q.z)  // C1D1S2Z2
+	s := F.Add(t0, t1)   // S3 = S1C2D2Z1+C1D1S2Z2
+
+	zz := F.Mul(P.z, Q.z) // Z1Z2
+	ss = F.Mul(P.s, Q.s)  // S1S2
+	sd = F.Mul(P.d, Q.d)  // D1D2
+	sd = F.Mul(sd, ss)    // S1D1S2D2
+	sd = F.Mul(sd, e.B)   // bS1D1S2D2
+	t0 = F.Mul(P.c, Q.c)  // C1C2
+	t0 = F.Mul(t0, zz)    // C1C2Z1Z2
+	c := F.Sub(t0, sd)    // C3 = C1C2Z1Z2-bS1D1S2D2
+
+	sc = F.Mul(P.c, Q.c) // C1C2
+	sc = F.Mul(sc, ss)   // S1C1S2C2
+	sc = F.Mul(sc, e.A)  // aS1C1S2C2
+	t0 = F.Mul(P.d, Q.d) // D1D2
+	t0 = F.Mul(t0, zz)   // D1D2Z1Z2
+	d := F.Sub(t0, sc)   // D3 = D1D2Z1Z2-aS1C1S2C2
+
+	t0 = F.Sqr(zz)      // Z1^2Z2^2
+	t1 = F.Sqr(ss)      // S1^2S2^2
+	t1 = F.Mul(t1, e.A) // aS1^2S2^2
+	t1 = F.Mul(t1, e.B) // abS1^2S2^2
+	z := F.Sub(t0, t1)  // Z3 = Z1^2Z2^2-abS1^2S2^2
+
+	if !(F.IsZero(s) && F.IsZero(c) && F.IsZero(d) && F.IsZero(z)) {
+		return &ptJi{e, s, c, d, z}
+	}
+	return addViaMap(e.toJQ, p, q)
+}
+func (e *jiCurve) Double(p Point) Point                 { return e.Add(p, p) }
+func (e *jiCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *jiCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToJacobiQuartic returns the map (s,c,d) -> (x,y) = (s/(1+c), 2d/(1+c)) to
+// the curve y^2=b^2x^4+2(b-2a)x^2+1.
+func (e *jiCurve) ToJacobiQuartic() RationalMap {
+	F := e.F
+	a := F.Sub(e.B, F.Add(e.A, e.A)) // b-2a
+	d := F.Sqr(e.B)                  // b^2
+	e1 := JacobiQuartic.New("JQ from "+e.Name, F, a, d, e.params.R, e.params.H)
+	return &ji2jq{E0: e, E1: e1.(*jqCurve)}
+}
+
+// ToWeierstrass returns a map to a short Weierstrass curve.
+func (e *jiCurve) ToWeierstrass() RationalMap {
+	m := e.ToJacobiQuartic()
+	return Compose(m, m.Codomain().(*jqCurve).ToWeierstrass())
+}
+
+// toJacobiIntersection returns a map from e to a Jacobi intersection curve;
+// it requires d to be a square.
+func (e *jqCurve) toJacobiIntersection() (RationalMap, error) {
+	F := e.F
+	if !F.IsSquare(e.D) {
+		return nil, errors.New("d is not a square")
+	}
+	b := F.Sqrt(e.D)              // b = sqrt(d)
+	a := F.Sub(b, e.A)            // b-a
+	a = F.Mul(a, F.Inv(F.Elt(2))) // (b-a)/2
+	e0 := JacobiIntersection.New("JI from "+e.Name, F, a, b, e.params.R, e.params.H)
+	return Invert(&ji2jq{E0: e0.(*jiCurve), E1: e}), nil
+}
+
+type ji2jq struct {
+	E0 *jiCurve
+	E1 *jqCurve
+}
+
+func (r *ji2jq) Domain() EllCurve   { return r.E0 }
+func (r *ji2jq) Codomain() EllCurve { return r.E1 }
+func (r *ji2jq) Push(p Point) Point {
+	P := p.(*ptJi)
+	F := r.E0.F
+	var x, y, z GF.Elt
+	if t0 := F.Add(P.c, P.z); !F.IsZero(t0) {
+		x = P.s.Copy()     // X = S
+		y = F.Mul(P.d, t0) // D(C+Z)
+		y = F.Add(y, y)    // Y = 2D(C+Z)
+		z = t0             // Z = C+Z
+	} else {
+		x = F.Sub(P.z, P.c)    // X = Z-C
+		y = F.Mul(P.d, x)      // D(Z-C)
+		y = F.Mul(y, r.E0.B)   // bD(Z-C)
+		y = F.Add(y, y)        // Y = 2bD(Z-C)
+		z = F.Mul(r.E0.B, P.s) // Z = bS
+	}
+	return &ptJq{r.E1, &prPoint{x, y, z}}
+}
+func (r *ji2jq) Pull(p Point) Point {
+	P := p.(*ptJq)
+	F := r.E0.F
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(P.x)        // X^2
+	t0 = F.Mul(t0, r.E0.B) // bX^2
+	t1 = F.Sqr(P.z)        // W^2
+	s := F.Mul(P.x, P.z)   // XW
+	s = F.Add(s, s)        // S = 2XW
+	c := F.Sub(t1, t0)     // C = W^2-bX^2
+	d := P.y.Copy()        // D = Y
+	z := F.Add(t1, t0)     // Z = W^2+bX^2
+	return &ptJi{r.E0, s, c, d, z}
+}
+
+// ptJi is a point on a Jacobi intersection curve in projective coordinates
+// (S:C:D:Z), such that s=S/Z, c=C/Z and d=D/Z.
+type ptJi struct {
+	*jiCurve
+	s, c, d, z GF.Elt
+}
+
+func (p *ptJi) String() string { return fmt.Sprintf("(%v: %v: %v: %v)", p.s, p.c, p.d, p.z) }
+func (p *ptJi) Copy() Point {
+	return &ptJi{p.jiCurve, p.s.Copy(), p.c.Copy(), p.d.Copy(), p.z.Copy()}
+}
+
+// X returns the coordinate s.
+func (p *ptJi) X() GF.Elt {
+	if p.F.IsZero(p.z) {
+		return nil
+	}
+	return p.F.Mul(p.s, p.F.Inv(p.z))
+}
+
+// Y returns the coordinate c.
+func (p *ptJi) Y() GF.Elt {
+	if p.F.IsZero(p.z) {
+		return nil
+	}
+	return p.F.Mul(p.c, p.F.Inv(p.z))
+}
+func (p *ptJi) IsEqual(q Point) bool {
+	qq, ok := q.(*ptJi)
+	if !ok || !p.jiCurve.IsEqual(qq.jiCurve) {
+		return false
+	}
+	F := p.F
+	a := []GF.Elt{p.s, p.c, p.d, p.z}
+	b := []GF.Elt{qq.s, qq.c, qq.d, qq.z}
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if !F.AreEqual(F.Mul(a[i], b[j]), F.Mul(a[j], b[i])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+func (p *ptJi) IsIdentity() bool { return p.IsEqual(p.jiCurve.Identity()) }
+func (p *ptJi) IsTwoTorsion() bool {
+	return !p.IsIdentity() && p.F.IsZero(p.s)
+}
@@ -0,0 +1,71 @@
+package curve_test
+
+import (
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestModels(t *testing.T) {
+	F := GF.NewFp("103", 103)
+	for _, curve := range []struct {
+		model C.Model
+		a, b  int
+	}{
+		{C.TwistedHessian, 2, 5},
+		{C.GeneralizedHessian, 2, 5},
+		{C.JacobiQuartic, 3, 5},
+		{C.JacobiIntersection, 3, 5},
+		{C.Huff, 3, 5},
+	} {
+		E := curve.model.New(curve.model.String(), F, F.Elt(curve.a), F.Elt(curve.b), nil, nil)
+		m := E.(interface{ ToWeierstrass() C.RationalMap }).ToWeierstrass()
+		W := m.Codomain().(C.W)
+		if got, want := E.(C.Invariants).JInvariant(), W.JInvariant(); !F.AreEqual(got, want) {
+			t.Fatalf("%v:\ngot:  %v\nwant: %v", curve.model, got, want)
+		}
+		if _, ok := C.IsIsomorphic(E, W); !ok {
+			t.Fatalf("%v: isomorphism not found", curve.model)
+		}
+
+		// Every point of E is the pullback of a point of W.
+		points := []C.Point{E.Identity()}
+		for i := 0; i < 103; i++ {
+			x := F.Elt(i)
+			y2 := F.Add(F.Mul(F.Add(F.Sqr(x), W.A), x), W.B)
+			if F.IsSquare(y2) {
+				y := F.Sqrt(y2)
+				points = append(points, m.Pull(W.NewPoint(x, y)))
+				if !F.IsZero(y) {
+					points = append(points, m.Pull(W.NewPoint(x, F.Neg(y))))
+				}
+			}
+		}
+		for _, P := range points {
+			if !E.IsOnCurve(P) {
+				t.Fatalf("%v: point not in the curve: %v", curve.model, P)
+			}
+			if got := m.Pull(m.Push(P)); !got.IsEqual(P) {
+				t.Fatalf("%v:\ngot:  %v\nwant: %v", curve.model, got, P)
+			}
+			if got, want := m.Push(E.Neg(P)), W.Neg(m.Push(P)); !got.IsEqual(want) {
+				t.Fatalf("%v:\ngot:  %v\nwant: %v", curve.model, got, want)
+			}
+			if got, want := m.Push(E.Double(P)), W.Double(m.Push(P)); !got.IsEqual(want) {
+				t.Fatalf("%v:\ngot:  %v\nwant: %v", curve.model, got, want)
+			}
+			for _, Q := range points {
+				R := E.Add(P, Q)
+				if !E.IsOnCurve(R) {
+					t.Fatalf("%v: point not in the curve: %v", curve.model, R)
+				}
+				got := m.Push(R)
+				want := W.Add(m.Push(P), m.Push(Q))
+				if !got.IsEqual(want) {
+					t.Fatalf("%v: %v+%v\ngot:  %v\nwant: %v", curve.model, P, Q, got, want)
+				}
+			}
+		}
+	}
+}
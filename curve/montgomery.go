@@ -27,6 +27,29 @@ func (e *mtCurve) NewPoint(x, y GF.Elt) (P Point) {
 	}
 	panic(fmt.Errorf("p:%v not on %v", P, e))
 }
+
+// Discriminant returns 16(A^2-4)/B^6, the discriminant of the curve given by
+// ToWeierstrassC.
+func (e *mtCurve) Discriminant() GF.Elt {
+	F := e.F
+	t0 := F.Sqr(e.A)            // A^2
+	t0 = F.Sub(t0, F.Elt(4))    // A^2-4
+	t0 = F.Mul(F.Elt(16), t0)   // 16(A^2-4)
+	t1 := F.Sqr(e.B)            // B^2
+	t1 = F.Mul(F.Sqr(t1), t1)   // B^6
+	return F.Mul(t0, F.Inv(t1)) // 16(A^2-4)/B^6
+}
+
+// JInvariant returns 256(A^2-3)^3/(A^2-4).
+func (e *mtCurve) JInvariant() GF.Elt {
+	F := e.F
+	t0 := F.Sqr(e.A)            // A^2
+	t1 := F.Sub(t0, F.Elt(3))   // A^2-3
+	t0 = F.Sub(t0, F.Elt(4))    // A^2-4
+	t1 = F.Mul(F.Sqr(t1), t1)   // (A^2-3)^3
+	t1 = F.Mul(F.Elt(256), t1)  // 256(A^2-3)^3
+	return F.Mul(t1, F.Inv(t0)) // 256(A^2-3)^3/(A^2-4)
+}
 func (e *mtCurve) IsValid() bool {
 	F := e.F
 	t0 := F.Sqr(e.A)         // A^2
@@ -35,8 +58,8 @@ func (e *mtCurve) IsValid() bool {
 	return !F.IsZero(t0)     // B(A^2-4) != 0
 }
 func (e *mtCurve) IsEqual(ec EllCurve) bool {
-	e0 := ec.(*mtCurve)
-	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+	e0, ok := ec.(*mtCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
 }
 func (e *mtCurve) IsOnCurve(p Point) bool {
 	if _, isZero := p.(*infPoint); isZero {
@@ -147,8 +170,8 @@ type ptMt struct {
 func (p *ptMt) String() string { return p.afPoint.String() }
 func (p *ptMt) Copy() Point    { return &ptMt{p.mtCurve, p.copy()} }
 func (p *ptMt) IsEqual(q Point) bool {
-	qq := q.(*ptMt)
-	return p.mtCurve.IsEqual(qq.mtCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptMt)
+	return ok && p.mtCurve.IsEqual(qq.mtCurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptMt) IsIdentity() bool   { return false }
 func (p *ptMt) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
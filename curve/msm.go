@@ -0,0 +1,51 @@
+package curve
+
+import "math/big"
+
+// msmWindow is the bit width of the windows of MultiScalarMult.
+const msmWindow = 4
+
+// MultiScalarMult returns the sum of [k[i]]P[i]. It uses Straus' interleaved
+// method with fixed windows, so the doublings are shared among all the
+// points. Negative scalars are supported. It panics if the lengths of P and
+// k differ.
+func MultiScalarMult(E EllCurve, P []Point, k []*big.Int) Point {
+	if len(P) != len(k) {
+		panic("mismatched number of points and scalars")
+	}
+	// table[i][j] = [j]P[i] with the sign of k[i], for 0<=j<2^w.
+	table := make([][]Point, len(P))
+	scalars := make([]*big.Int, len(P))
+	bits := 0
+	for i := range P {
+		Q := P[i]
+		scalars[i] = k[i]
+		if k[i].Sign() < 0 {
+			Q, scalars[i] = E.Neg(Q), new(big.Int).Neg(k[i])
+		}
+		if n := scalars[i].BitLen(); n > bits {
+			bits = n
+		}
+		table[i] = make([]Point, 1<<msmWindow)
+		table[i][0] = E.Identity()
+		for j := 1; j < len(table[i]); j++ {
+			table[i][j] = E.Add(table[i][j-1], Q)
+		}
+	}
+	R := E.Identity()
+	for w := (bits+msmWindow-1)/msmWindow - 1; w >= 0; w-- {
+		for j := 0; j < msmWindow; j++ {
+			R = E.Double(R)
+		}
+		for i, s := range scalars {
+			d := 0
+			for j := msmWindow - 1; j >= 0; j-- {
+				d = d<<1 | int(s.Bit(w*msmWindow+j))
+			}
+			if d != 0 {
+				R = E.Add(R, table[i][d])
+			}
+		}
+	}
+	return R
+}
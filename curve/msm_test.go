@@ -0,0 +1,43 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+)
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		n := new(big.Int).Mul(E.Order(), E.Cofactor())
+		R, err := C.RandomPoint(E, rand.Reader, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		P := []C.Point{g, E.Double(g), R, E.Identity()}
+		k := make([]*big.Int, len(P))
+		for i := range k {
+			k[i], _ = rand.Int(rand.Reader, new(big.Int).Lsh(n, 2))
+		}
+		k[1].Neg(k[1])
+		k[2].SetInt64(0)
+
+		want := E.Identity()
+		for i := range P {
+			Q, s := P[i], k[i]
+			if s.Sign() < 0 {
+				Q, s = E.Neg(Q), new(big.Int).Neg(s)
+			}
+			want = E.Add(want, E.ScalarMult(Q, s))
+		}
+		if got := C.MultiScalarMult(E, P, k); !got.IsEqual(want) {
+			t.Fatalf("%v: got: %v\nwant: %v", curveID, got, want)
+		}
+		if !C.MultiScalarMult(E, nil, nil).IsIdentity() {
+			t.Fatalf("%v: empty sum must be the identity", curveID)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package curve
 
-import GF "github.com/armfazh/tozan-ecc/field"
+import (
+	"errors"
+	"fmt"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
 
 type mt2wec struct {
 	E0   *mtCurve
@@ -69,14 +74,14 @@ func (r *te2wec) Push(p Point) Point {
 		return r.E1.Identity()
 	}
 	F := r.E0.Field()
-	P := p.(*ptTe)
-	t0 := F.Add(F.One(), P.y)  // 1+y
-	t1 := F.Sub(F.One(), P.y)  // 1-y
-	t1 = F.Mul(t1, r.invSqrtD) // invSqrtD*(1-y)
-	t1 = F.Inv(t1)             // 1/(invSqrtD*(1-y))
-	x := F.Mul(t0, t1)         // x = (1+y)/(invSqrtD*(1-y))
-	t0 = F.Inv(P.y)            // 1/y
-	y := F.Mul(x, t0)          // y = x/y
+	X, Y := p.(*ptTe).affine()
+	t0 := F.Add(F.One(), Y)    // 1+Y
+	t1 := F.Sub(F.One(), Y)    // 1-Y
+	t1 = F.Mul(t1, r.invSqrtD) // invSqrtD*(1-Y)
+	t1 = F.Inv(t1)             // 1/(invSqrtD*(1-Y))
+	x := F.Mul(t0, t1)         // x = (1+Y)/(invSqrtD*(1-Y))
+	t0 = F.Inv(X)              // 1/X
+	y := F.Mul(x, t0)          // y = x/X
 	return r.E1.NewPoint(x, y)
 }
 func (r *te2wec) Pull(p Point) Point {
@@ -85,13 +90,18 @@ func (r *te2wec) Pull(p Point) Point {
 	}
 	P := p.(*ptWc)
 	F := r.E0.Field()
-	if P.IsTwoTorsion() {
+	if P.IsTwoTorsion() && F.IsZero(P.x) {
 		return r.E0.NewPoint(F.Zero(), F.Elt(-1))
 	}
-	t0 := F.Inv(P.y)            // 1/y
-	x := F.Mul(P.x, t0)         // X = x/y
-	t0 = F.Mul(r.invSqrtD, P.x) // invSqrtD*x
-	t1 := F.Add(t0, F.One())    // invSqrtD*x+1
+	t0 := F.Mul(r.invSqrtD, P.x) // invSqrtD*x
+	t1 := F.Add(t0, F.One())     // invSqrtD*x+1
+	// The other points of order two, and the points with invSqrtD*x=-1,
+	// correspond to points at infinity of the twisted Edwards curve, which
+	// only exist over the base field if d or ad is a square.
+	if P.IsTwoTorsion() || F.IsZero(t1) {
+		panic(fmt.Errorf("p:%v has no affine image on %v", p, r.E0))
+	}
+	x := F.Mul(P.x, F.Inv(P.y)) // X = x/y
 	t2 := F.Sub(t0, F.One())    // invSqrtD*x-1
 	t1 = F.Inv(t1)              // 1/(invSqrtD*x+1)
 	y := F.Mul(t1, t2)          // Y = (invSqrtD*x-1)/(invSqrtD*x+1)
@@ -143,3 +153,101 @@ func (r *wc2we) Pull(p Point) Point {
 	xx := F.Sub(P.x, r.Adiv3)
 	return r.E0.NewPoint(xx, P.y)
 }
+
+// toMontgomery returns a map from e to a Montgomery curve; it requires B to
+// be a square.
+func (e *wcCurve) toMontgomery() (RationalMap, error) {
+	F := e.Field()
+	if !F.IsSquare(e.B) {
+		return nil, errors.New("B is not a square")
+	}
+	invB := F.Sqrt(e.B) // 1/b = sqrt(B)
+	b := F.Inv(invB)    // b = 1/sqrt(B)
+	a := F.Mul(e.A, b)  // a = A*b
+	e0 := Montgomery.New("M from "+e.Name, F, a, b, e.params.R, e.params.H)
+	return Invert(&mt2wec{E0: e0.(*mtCurve), E1: e, invB: invB}), nil
+}
+
+// toTwistedEdwards returns a map from e to a twisted Edwards curve; it
+// requires B to be a square.
+func (e *wcCurve) toTwistedEdwards() (RationalMap, error) {
+	F := e.Field()
+	if !F.IsSquare(e.B) {
+		return nil, errors.New("B is not a square")
+	}
+	t0 := F.Sqrt(e.B)   // (a-d)/4 = sqrt(B)
+	t1 := F.Add(t0, t0) // (a-d)/2
+	a := F.Add(e.A, t1) // a = A + (a-d)/2
+	d := F.Sub(e.A, t1) // d = A - (a-d)/2
+	e0 := TwistedEdwards.New("TE from "+e.Name, F, a, d, e.params.R, e.params.H)
+	return Invert(&te2wec{E0: e0.(*teCurve), E1: e, invSqrtD: F.Inv(t0)}), nil
+}
+
+// toWeierstrassC returns a map from e to a WeierstrassC curve, where alpha is
+// a root of x^3+Ax+B.
+func (e *weCurve) toWeierstrassC(alpha GF.Elt) RationalMap {
+	F := e.Field()
+	a := F.Mul(F.Elt(3), alpha) // A = 3alpha
+	b := F.Mul(a, alpha)        // 3alpha^2
+	b = F.Add(b, e.A)           // B = 3alpha^2+A
+	e0 := WeierstrassC.New("WC from "+e.Name, F, a, b, e.params.R, e.params.H)
+	return Invert(&wc2we{E0: e0.(*wcCurve), E1: e, Adiv3: alpha})
+}
+
+// Invert returns the inverse of a birational map.
+func Invert(m RationalMap) RationalMap {
+	if r, ok := m.(*invMap); ok {
+		return r.RationalMap
+	}
+	return &invMap{m}
+}
+
+type invMap struct{ RationalMap }
+
+func (r *invMap) Domain() EllCurve   { return r.RationalMap.Codomain() }
+func (r *invMap) Codomain() EllCurve { return r.RationalMap.Domain() }
+func (r *invMap) Push(p Point) Point { return r.RationalMap.Pull(p) }
+func (r *invMap) Pull(p Point) Point { return r.RationalMap.Push(p) }
+
+// Compose returns the birational map that applies maps[0] first and
+// maps[len(maps)-1] last. The codomain of each map must be equal to the domain
+// of the next one.
+func Compose(maps ...RationalMap) RationalMap {
+	if len(maps) == 0 {
+		panic(errors.New("no maps to compose"))
+	}
+	for i := 1; i < len(maps); i++ {
+		if !maps[i-1].Codomain().IsEqual(maps[i].Domain()) {
+			panic(fmt.Errorf("map %v and map %v can't be composed", i-1, i))
+		}
+	}
+	if len(maps) == 1 {
+		return maps[0]
+	}
+	return append(compMap{}, maps...)
+}
+
+type compMap []RationalMap
+
+func (r compMap) Domain() EllCurve   { return r[0].Domain() }
+func (r compMap) Codomain() EllCurve { return r[len(r)-1].Codomain() }
+func (r compMap) Push(p Point) Point {
+	for i := range r {
+		p = r[i].Push(p)
+	}
+	return p
+}
+func (r compMap) Pull(p Point) Point {
+	for i := len(r) - 1; i >= 0; i-- {
+		p = r[i].Pull(p)
+	}
+	return p
+}
+
+// idMap is the identity map on an elliptic curve.
+type idMap struct{ E EllCurve }
+
+func (r *idMap) Domain() EllCurve   { return r.E }
+func (r *idMap) Codomain() EllCurve { return r.E }
+func (r *idMap) Push(p Point) Point { return p.Copy() }
+func (r *idMap) Pull(p Point) Point { return p.Copy() }
@@ -0,0 +1,239 @@
+package curve
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// LiftX returns a point of E whose x-coordinate is x. When there are two such
+// points, sign selects the one whose y-coordinate has Sgn0(y)=sign; if both
+// have the same sign, sign=0 selects y=(-b+s)/2a and sign=1 selects
+// y=(-b-s)/2a, where ay^2+by+c=0 is the curve equation for the given x and s
+// is the square root of the discriminant of sign zero.
+//
+// It is supported by the models whose equation is quadratic in y:
+// Weierstrass, WeierstrassC, WeierstrassGeneral, Montgomery, TwistedEdwards,
+// JacobiQuartic and Huff.
+func LiftX(E EllCurve, x GF.Elt, sign int) (Point, error) {
+	a, b, c, err := liftCoeffs(E, x)
+	if err != nil {
+		return nil, err
+	}
+	F := E.Field()
+	var y GF.Elt
+	switch {
+	case !F.IsZero(a):
+		// y = (-b +- sqrt(b^2-4ac))/2a
+		t0 := F.Sqr(b)                     // b^2
+		t1 := F.Mul(F.Elt(4), F.Mul(a, c)) // 4ac
+		t0 = F.Sub(t0, t1)                 // b^2-4ac
+		if !F.IsZero(t0) && !F.IsSquare(t0) {
+			return nil, fmt.Errorf("no point with x=%v", x)
+		}
+		s := F.Sqrt(t0)
+		if F.Sgn0(s) == 1 {
+			s = F.Neg(s)
+		}
+		t1 = F.Inv(F.Add(a, a))             // 1/2a
+		y0 := F.Mul(F.Sub(s, b), t1)        // (-b+s)/2a
+		y1 := F.Mul(F.Neg(F.Add(s, b)), t1) // (-b-s)/2a
+		if F.Sgn0(y0) != F.Sgn0(y1) {
+			y = F.CMov(y0, y1, F.Sgn0(y1) == sign)
+		} else {
+			y = F.CMov(y0, y1, sign == 1)
+		}
+	case !F.IsZero(b):
+		y = F.Neg(F.Mul(c, F.Inv(b))) // y = -c/b
+	default:
+		return nil, fmt.Errorf("no affine point with x=%v", x)
+	}
+	return E.NewPoint(x, y), nil
+}
+
+// liftCoeffs returns (a,b,c) such that the equation of E for a fixed x is
+// ay^2+by+c=0.
+func liftCoeffs(E EllCurve, x GF.Elt) (a, b, c GF.Elt, err error) {
+	F := E.Field()
+	one, z := F.One(), F.Zero()
+	xx := F.Sqr(x)
+	switch e := E.(type) {
+	case *weCurve: // y^2 = x^3+Ax+B
+		return one, z, F.Neg(e.EvalRHS(x)), nil
+	case *wcCurve: // y^2 = x^3+Ax^2+Bx
+		t0 := F.Add(x, e.A)                     // x+A
+		t0 = F.Add(F.Mul(t0, x), e.B)           // (x+A)x+B
+		return one, z, F.Neg(F.Mul(t0, x)), nil // -((x+A)x+B)x
+	case *mtCurve: // By^2 = x^3+Ax^2+x
+		t0 := F.Add(x, e.A)                     // x+A
+		t0 = F.Add(F.Mul(t0, x), one)           // (x+A)x+1
+		return e.B, z, F.Neg(F.Mul(t0, x)), nil // -((x+A)x+1)x
+	case *teCurve: // (1-Dx^2)y^2 = 1-Ax^2
+		a = F.Sub(one, F.Mul(e.D, xx)) // 1-Dx^2
+		c = F.Sub(F.Mul(e.A, xx), one) // Ax^2-1
+		return a, z, c, nil
+	case *geCurve: // y^2+(a1x+a3)y = x^3+a2x^2+a4x+a6
+		t0 := F.Add(x, e.a2)            // x+a2
+		t0 = F.Add(F.Mul(t0, x), e.a4)  // (x+a2)x+a4
+		t0 = F.Add(F.Mul(t0, x), e.a6)  // ((x+a2)x+a4)x+a6
+		b = F.Add(F.Mul(e.a1, x), e.a3) // a1x+a3
+		return one, b, F.Neg(t0), nil
+	case *jqCurve: // y^2 = dx^4+2ax^2+1
+		t0 := F.Mul(e.D, xx)            // dx^2
+		t0 = F.Add(t0, F.Add(e.A, e.A)) // dx^2+2a
+		t0 = F.Add(F.Mul(t0, xx), one)  // (dx^2+2a)x^2+1
+		return one, z, F.Neg(t0), nil
+	case *hfCurve: // ax(y^2-1) = by(x^2-1)
+		a = F.Mul(e.A, x)              // ax
+		b = F.Mul(e.B, F.Sub(xx, one)) // b(x^2-1)
+		return a, F.Neg(b), F.Neg(a), nil
+	default:
+		return nil, nil, nil, errors.New("LiftX is not supported by this model")
+	}
+}
+
+// maxSampleTries bounds the number of candidates tried by RandomPoint and
+// HashToPoint. Each candidate gives a point with probability close to 1/2, so
+// the bound is only reached if clearing the cofactor always gives the
+// identity, as when Cofactor() is a multiple of the number of points.
+const maxSampleTries = 256
+
+var (
+	errSampleTries     = errors.New("no point was found in the maximum number of tries")
+	errUnknownCofactor = errors.New("cofactor of the curve is unknown")
+)
+
+// RandomPoint returns a random point of E read from rnd, by sampling an
+// x-coordinate and a sign until a point is found. If subgroup is true, the
+// cofactor is cleared and the point is in the subgroup of order Order() and
+// is not the identity. It returns an error if no point is found after a
+// bounded number of tries, or if subgroup is true and Cofactor() is not given.
+//
+// Models not supported by LiftX sample the point on a Weierstrass model and
+// map it back.
+func RandomPoint(E EllCurve, rnd io.Reader, subgroup bool) (Point, error) {
+	if subgroup && E.Cofactor() == nil {
+		return nil, errUnknownCofactor
+	}
+	F := E.Field()
+	lift := lifter(E)
+	for i := 0; i < maxSampleTries; i++ {
+		var s [1]byte
+		if _, err := io.ReadFull(rnd, s[:]); err != nil {
+			return nil, err
+		}
+		if P, ok := lift(F.Rand(rnd), int(s[0]&1)); ok {
+			if P, ok = toSubgroup(E, P, subgroup); ok {
+				return P, nil
+			}
+		}
+	}
+	return nil, errSampleTries
+}
+
+// randomPoint returns a random point of E, which is found with overwhelming
+// probability as the cofactor is not cleared.
+func randomPoint(E EllCurve) Point {
+	P, err := RandomPoint(E, rand.Reader, false)
+	if err != nil {
+		panic(err)
+	}
+	return P
+}
+
+// HashToPoint deterministically maps msg to a point of E, for testing. It uses
+// the try-and-increment method: for a counter i=0,1,..., it hashes the domain
+// separation tag dst, the counter and msg to an x-coordinate and a sign, and
+// returns the first point found with LiftX. If subgroup is true, the cofactor
+// is cleared and counters that give the identity are skipped. It returns an
+// error if no point is found after a bounded number of counters, or if
+// subgroup is true and Cofactor() is not given.
+//
+// As the number of tries depends on msg, it must not be used with secret
+// inputs.
+func HashToPoint(E EllCurve, msg, dst []byte, subgroup bool) (Point, error) {
+	if subgroup && E.Cofactor() == nil {
+		return nil, errUnknownCofactor
+	}
+	lift := lifter(E)
+	for i := uint32(0); i < maxSampleTries; i++ {
+		x, sign := hashToField(E.Field(), msg, dst, i)
+		if P, ok := lift(x, sign); ok {
+			if P, ok = toSubgroup(E, P, subgroup); ok {
+				return P, nil
+			}
+		}
+	}
+	return nil, errSampleTries
+}
+
+// hashToField returns an element of F and a sign derived from SHA-256 of
+// (dst, len(dst), ctr, j, msg) for j=0,1,..., with 128 extra bits per
+// coordinate to reduce the bias.
+func hashToField(F GF.Field, msg, dst []byte, ctr uint32) (GF.Elt, int) {
+	n := (F.P().BitLen() + 128 + 7) / 8
+	m := int(F.Ext())
+	var out []byte
+	for j := uint32(0); len(out) < n*m+1; j++ {
+		h := sha256.New()
+		var buf [12]byte
+		binary.BigEndian.PutUint32(buf[0:], uint32(len(dst)))
+		binary.BigEndian.PutUint32(buf[4:], ctr)
+		binary.BigEndian.PutUint32(buf[8:], j)
+		_, _ = h.Write(dst)
+		_, _ = h.Write(buf[:])
+		_, _ = h.Write(msg)
+		out = h.Sum(out)
+	}
+	c := make([]interface{}, m)
+	for i := range c {
+		c[i] = new(big.Int).SetBytes(out[i*n : (i+1)*n])
+	}
+	return F.Elt(c), int(out[n*m] & 1)
+}
+
+// lifter returns a function that finds a point of E with a given
+// x-coordinate and sign, which uses a Weierstrass model of E if LiftX doesn't
+// support it.
+func lifter(E EllCurve) func(x GF.Elt, sign int) (Point, bool) {
+	if _, _, _, err := liftCoeffs(E, E.Field().Zero()); err == nil {
+		return func(x GF.Elt, sign int) (Point, bool) {
+			P, err := LiftX(E, x, sign)
+			return P, err == nil
+		}
+	}
+	e, m := weierstrassOf(E)
+	return func(x GF.Elt, sign int) (Point, bool) {
+		P, err := LiftX(e, x, sign)
+		if err != nil {
+			return nil, false
+		}
+		return tryPull(m, P)
+	}
+}
+
+// tryPull returns m.Pull(P), or false if P is an exceptional point of m.
+func tryPull(m RationalMap, P Point) (Q Point, ok bool) {
+	defer func() {
+		if recover() != nil {
+			Q, ok = nil, false
+		}
+	}()
+	return m.Pull(P), true
+}
+
+// toSubgroup clears the cofactor of P if subgroup is true, and returns false
+// if the result is the identity. The cofactor of E must be given.
+func toSubgroup(E EllCurve, P Point, subgroup bool) (Point, bool) {
+	if !subgroup {
+		return P, true
+	}
+	P = E.ClearCofactor(P)
+	return P, !P.IsIdentity()
+}
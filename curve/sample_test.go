@@ -0,0 +1,130 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+var allModels = []C.Model{C.Weierstrass, C.WeierstrassC, C.WeierstrassGeneral,
+	C.TwistedEdwards, C.Montgomery, C.TwistedHessian, C.GeneralizedHessian,
+	C.JacobiQuartic, C.JacobiIntersection, C.Huff}
+
+// noLiftX are the models whose equation is not quadratic in y.
+var noLiftX = map[C.Model]bool{C.TwistedHessian: true, C.GeneralizedHessian: true,
+	C.JacobiIntersection: true}
+
+func TestLiftX(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, g, _ := curveID.New()
+		for _, model := range allModels {
+			E0, m, err := C.ConvertTo(E, model)
+			if err != nil {
+				continue
+			}
+			if noLiftX[model] {
+				if _, err := C.LiftX(E0, E0.Field().Zero(), 0); err == nil {
+					t.Fatalf("%v %v: LiftX must not be supported", curveID, model)
+				}
+				continue
+			}
+			P := g
+			for i := 0; i < 10; i++ {
+				P0 := m.Push(P)
+				P = E.Add(P, g)
+				if P0.IsIdentity() {
+					continue
+				}
+				Q0, err0 := C.LiftX(E0, P0.X(), 0)
+				Q1, err1 := C.LiftX(E0, P0.X(), 1)
+				if err0 != nil || err1 != nil {
+					t.Fatalf("%v %v: %v %v", curveID, model, err0, err1)
+				}
+				for _, Q := range []C.Point{Q0, Q1} {
+					if !E0.IsOnCurve(Q) || !E0.Field().AreEqual(Q.X(), P0.X()) {
+						t.Fatalf("%v %v: wrong point %v", curveID, model, Q)
+					}
+				}
+				if !P0.IsEqual(Q0) && !P0.IsEqual(Q1) {
+					t.Fatalf("%v %v: %v not found", curveID, model, P0)
+				}
+			}
+		}
+	}
+}
+
+func TestRandomPoint(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		for _, model := range allModels {
+			E0, _, err := C.ConvertTo(E, model)
+			if err != nil {
+				continue
+			}
+			for i := 0; i < 10; i++ {
+				P, err := C.RandomPoint(E0, rand.Reader, false)
+				if err != nil || !E0.IsOnCurve(P) {
+					t.Fatalf("%v %v: point not on curve %v %v", curveID, model, P, err)
+				}
+				P, err = C.RandomPoint(E0, rand.Reader, true)
+				if err != nil || P.IsIdentity() || !E0.ScalarMult(P, E.Order()).IsIdentity() {
+					t.Fatalf("%v %v: point not in the subgroup %v", curveID, model, P)
+				}
+			}
+		}
+	}
+}
+
+func TestHashToPoint(t *testing.T) {
+	dst := []byte("TEST-HASH-TO-POINT")
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		for _, model := range allModels {
+			E0, _, err := C.ConvertTo(E, model)
+			if err != nil {
+				continue
+			}
+			for _, msg := range []string{"", "abc", "message"} {
+				P, err0 := C.HashToPoint(E0, []byte(msg), dst, false)
+				Q, err1 := C.HashToPoint(E0, []byte(msg), dst, false)
+				if err0 != nil || err1 != nil || !E0.IsOnCurve(P) || !P.IsEqual(Q) {
+					t.Fatalf("%v %v: got: %v\nwant: %v", curveID, model, Q, P)
+				}
+				P, err := C.HashToPoint(E0, []byte(msg), dst, true)
+				if err != nil || P.IsIdentity() || !E0.ScalarMult(P, E.Order()).IsIdentity() {
+					t.Fatalf("%v %v: point not in the subgroup %v", curveID, model, P)
+				}
+			}
+		}
+	}
+}
+
+func TestSampleEmptySubgroup(t *testing.T) {
+	// The cofactor is the number of points, so it clears every point.
+	F := GF.NewFp("53", 53)
+	E := C.Weierstrass.New("", F, F.Elt(3), F.Elt(2), big.NewInt(1), big.NewInt(51))
+	if _, err := C.RandomPoint(E, rand.Reader, true); err == nil {
+		t.Fatal("RandomPoint must fail")
+	}
+	if _, err := C.HashToPoint(E, []byte("msg"), []byte("dst"), true); err == nil {
+		t.Fatal("HashToPoint must fail")
+	}
+}
+
+func TestSampleUnknownCofactor(t *testing.T) {
+	F := GF.NewFp("53", 53)
+	E := C.Weierstrass.New("", F, F.Elt(3), F.Elt(2), nil, nil)
+	if _, err := C.RandomPoint(E, rand.Reader, true); err == nil {
+		t.Fatal("RandomPoint must fail")
+	}
+	if _, err := C.HashToPoint(E, []byte("msg"), []byte("dst"), true); err == nil {
+		t.Fatal("HashToPoint must fail")
+	}
+	if _, err := C.RandomPoint(E, rand.Reader, false); err != nil {
+		t.Fatal(err)
+	}
+}
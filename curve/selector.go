@@ -1,6 +1,7 @@
 package curve
 
 import (
+	"fmt"
 	"math/big"
 
 	GF "github.com/armfazh/tozan-ecc/field"
@@ -13,8 +14,41 @@ const (
 	WeierstrassC
 	TwistedEdwards
 	Montgomery
+	WeierstrassGeneral
+	TwistedHessian
+	GeneralizedHessian
+	JacobiQuartic
+	JacobiIntersection
+	Huff
 )
 
+func (m Model) String() string {
+	switch m {
+	case Weierstrass:
+		return "Weierstrass"
+	case WeierstrassC:
+		return "WeierstrassC"
+	case TwistedEdwards:
+		return "TwistedEdwards"
+	case Montgomery:
+		return "Montgomery"
+	case WeierstrassGeneral:
+		return "WeierstrassGeneral"
+	case TwistedHessian:
+		return "TwistedHessian"
+	case GeneralizedHessian:
+		return "GeneralizedHessian"
+	case JacobiQuartic:
+		return "JacobiQuartic"
+	case JacobiIntersection:
+		return "JacobiIntersection"
+	case Huff:
+		return "Huff"
+	default:
+		return fmt.Sprintf("Model(%d)", int(m))
+	}
+}
+
 func (m Model) New(name string, f GF.Field, a, b GF.Elt, r, h *big.Int) EllCurve {
 	p := &params{Name: name, F: f, A: a, B: b, R: r, H: h}
 	switch m {
@@ -23,9 +57,22 @@ func (m Model) New(name string, f GF.Field, a, b GF.Elt, r, h *big.Int) EllCurve
 	case WeierstrassC:
 		return (&wcCurve{params: p}).New()
 	case TwistedEdwards:
-		return (&teCurve{p}).New()
+		return (&teCurve{params: p}).New()
 	case Montgomery:
 		return (&mtCurve{p}).New()
+	case WeierstrassGeneral:
+		z := f.Zero()
+		return (&geCurve{params: p, a1: z, a2: z, a3: z, a4: a, a6: b}).New()
+	case TwistedHessian:
+		return (&thCurve{params: p}).New()
+	case GeneralizedHessian:
+		return (&ghCurve{params: p}).New()
+	case JacobiQuartic:
+		return (&jqCurve{params: p}).New()
+	case JacobiIntersection:
+		return (&jiCurve{params: p}).New()
+	case Huff:
+		return (&hfCurve{params: p}).New()
 	default:
 		panic("elliptic curve model not supported")
 	}
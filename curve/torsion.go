@@ -0,0 +1,232 @@
+package curve
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// GroupStructure describes the group of points of an elliptic curve over its
+// base field, which is isomorphic to Z/N1 x Z/N2 with N2 dividing N1.
+type GroupStructure struct {
+	N1, N2 *big.Int
+	// G1 and G2 are points of order N1 and N2 that generate the group, that
+	// is, every point is [i]G1+[j]G2 for unique 0 <= i < N1 and 0 <= j < N2.
+	G1, G2 Point
+}
+
+func (g *GroupStructure) String() string {
+	if g.IsCyclic() {
+		return fmt.Sprintf("Z/%v", g.N1)
+	}
+	return fmt.Sprintf("Z/%v x Z/%v", g.N1, g.N2)
+}
+
+// IsCyclic returns true if the group is generated by a single point.
+func (g *GroupStructure) IsCyclic() bool { return g.N2.Cmp(big.NewInt(1)) == 0 }
+
+// Structure returns the group structure of the points of E over its base
+// field. The number of points is Order()*Cofactor(), or it is counted if the
+// order is unknown.
+//
+// The exponent N1 is found as the least common multiple of the orders of
+// random points, and the result is certified by building G2 independent from
+// G1 such that N1*N2 is the number of points. The points without an affine
+// image on E, as the points at infinity of an incomplete twisted Edwards
+// curve, are counted but are not taken as generators; an error is returned if
+// no generators with affine images are found.
+func Structure(E EllCurve) (*GroupStructure, error) {
+	N, err := curveOrder(E)
+	if err == errUnknownOrder {
+		N, err = CountPoints(E), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	e, m := weierstrassOf(E)
+	one := big.NewInt(1)
+	if N.Cmp(one) == 0 {
+		return &GroupStructure{N1: one, N2: big.NewInt(1), G1: E.Identity(), G2: E.Identity()}, nil
+	}
+	qm1 := new(big.Int).Sub(e.F.Order(), one)
+	P1, n1 := e.Identity(), big.NewInt(1)
+	tries := 0
+	for {
+		Q := randomPoint(e)
+		nq, err := orderOf(e, Q, N)
+		if err != nil {
+			return nil, err
+		}
+		if new(big.Int).Mod(n1, nq).Sign() != 0 {
+			P1, n1 = combineOrders(e, P1, n1, Q, nq)
+		}
+		// N2 must divide both N1 and q-1.
+		n2 := new(big.Int).Quo(N, n1)
+		if new(big.Int).Mod(n1, n2).Sign() != 0 || new(big.Int).Mod(qm1, n2).Sign() != 0 {
+			continue
+		}
+		P2 := e.Identity()
+		if n2.Cmp(one) != 0 {
+			var ok bool
+			if P2, ok = complement(e, P1, n1, n2, randomPoint(e)); !ok {
+				continue
+			}
+		}
+		G1, ok1 := tryPull(m, P1)
+		G2, ok2 := tryPull(m, P2)
+		if ok1 && ok2 {
+			return &GroupStructure{N1: n1, N2: n2, G1: G1, G2: G2}, nil
+		}
+		if tries++; tries == maxStructureTries {
+			return nil, errors.New("no generators of the group have an affine image on the curve")
+		}
+		// Starts again from a new random point of order N1.
+		P1, n1 = e.Identity(), big.NewInt(1)
+	}
+}
+
+// maxStructureTries bounds the number of bases found by Structure that have
+// a generator without an affine image.
+const maxStructureTries = 64
+
+// combineOrders returns a point of order lcm(a, b), given P of order a and Q
+// of order b. It takes the l-primary part of the point with the largest power
+// of l in its order, for each prime l.
+func combineOrders(e *weCurve, P Point, a *big.Int, Q Point, b *big.Int) (Point, *big.Int) {
+	n := lcm(a, b)
+	R := e.Identity()
+	for _, f := range Factorize(n) {
+		S, s := P, a
+		if valuation(b, f.P) > valuation(a, f.P) {
+			S, s = Q, b
+		}
+		pk := new(big.Int).Exp(f.P, big.NewInt(int64(valuation(s, f.P))), nil)
+		R = e.Add(R, e.ScalarMult(S, new(big.Int).Quo(s, pk)))
+	}
+	return R, n
+}
+
+// valuation returns the largest k such that p^k divides n.
+func valuation(n, p *big.Int) int {
+	k := 0
+	q, r := new(big.Int).Set(n), new(big.Int)
+	for q.Sign() != 0 {
+		if q.QuoRem(q, p, r); r.Sign() != 0 {
+			break
+		}
+		k++
+	}
+	return k
+}
+
+// complement returns a point of order n2 whose subgroup meets the subgroup
+// generated by P1 only at the identity, where P1 has order n1 and n1*n2 is the
+// number of points. It returns false if the random point Q doesn't yield such
+// a point, or if n1 is not the exponent of the group.
+func complement(e *weCurve, P1 Point, n1, n2 *big.Int, Q Point) (Point, bool) {
+	// Writing Q=[a]P1+R with [n2]R=O, [n2]Q=[n2*a]P1 determines a mod n1/n2.
+	d, err := discreteLog(e, P1, e.ScalarMult(Q, n2), n1)
+	if err != nil {
+		return nil, false
+	}
+	c, r := new(big.Int).QuoRem(d, n2, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, false
+	}
+	P2 := e.Add(Q, e.Neg(e.ScalarMult(P1, c)))
+	if !e.ScalarMult(P2, n2).IsIdentity() {
+		return nil, false
+	}
+	// For each prime l dividing n2, the subgroup of order l of <P2> must not
+	// be contained in <P1>.
+	for _, f := range Factorize(n2) {
+		h := e.ScalarMult(P2, new(big.Int).Quo(n2, f.P))
+		g := e.ScalarMult(P1, new(big.Int).Quo(n1, f.P))
+		if _, err := discreteLogPrime(e, g, h, f.P); err == nil {
+			return nil, false
+		}
+	}
+	return P2, true
+}
+
+// Torsion returns the points P of E over its base field such that [n]P is the
+// identity, starting with the identity. The x-coordinates of the affine points
+// are the roots of the n-th division polynomial. The points without an affine
+// image on E, as the points at infinity of an incomplete twisted Edwards
+// curve, are skipped.
+func Torsion(E EllCurve, n int) []Point {
+	if n <= 0 {
+		panic(errors.New("n must be positive"))
+	}
+	points := []Point{E.Identity()}
+	if n == 1 {
+		return points
+	}
+	e, m := weierstrassOf(E)
+	F := e.F
+	d := newDivPolys(e)
+	g := d.get(n)
+	if n%2 == 0 {
+		// psi_n = y*g_n, so the points of order two are also roots.
+		g = d.R.Mul(g, d.f)
+	}
+	for _, x := range d.R.Roots(g) {
+		y2 := e.EvalRHS(x)
+		var ps []Point
+		switch {
+		case F.IsZero(y2):
+			ps = []Point{e.NewPoint(x, y2)}
+		case F.IsSquare(y2):
+			P := e.NewPoint(x, F.Sqrt(y2))
+			ps = []Point{P, e.Neg(P)}
+		}
+		for _, P := range ps {
+			if Q, ok := tryPull(m, P); ok {
+				points = append(points, Q)
+			}
+		}
+	}
+	return points
+}
+
+// PointOfOrder returns a random point of E of order exactly d, or an error if
+// d doesn't divide the exponent of the group.
+func PointOfOrder(E EllCurve, d *big.Int, rnd io.Reader) (Point, error) {
+	s, err := Structure(E)
+	if err != nil {
+		return nil, err
+	}
+	if d.Sign() <= 0 || new(big.Int).Mod(s.N1, d).Sign() != 0 {
+		return nil, fmt.Errorf("there are no points of order %v in %v", d, s)
+	}
+	k := new(big.Int).Quo(s.N1, d)
+	factors := Factorize(d)
+	for {
+		i, err := rand.Int(rnd, s.N1)
+		if err != nil {
+			return nil, err
+		}
+		j, err := rand.Int(rnd, s.N2)
+		if err != nil {
+			return nil, err
+		}
+		P := E.Add(E.ScalarMult(s.G1, i), E.ScalarMult(s.G2, j))
+		P = E.ScalarMult(P, k)
+		if hasOrder(E, P, d, factors) {
+			return P, nil
+		}
+	}
+}
+
+// hasOrder returns true if P has order d, given that [d]P is the identity and
+// the factorization of d.
+func hasOrder(E EllCurve, P Point, d *big.Int, factors []Factor) bool {
+	for _, f := range factors {
+		if E.ScalarMult(P, new(big.Int).Quo(d, f.P)).IsIdentity() {
+			return false
+		}
+	}
+	return true
+}
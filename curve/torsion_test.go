@@ -0,0 +1,172 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// testStructure checks the structure of the group of E against the points
+// generated by G1 and G2.
+func testStructure(t *testing.T, E C.EllCurve) *C.GroupStructure {
+	s, err := C.Structure(E)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := new(big.Int).Mul(s.N1, s.N2)
+	if N := C.CountPoints(E); n.Cmp(N) != 0 {
+		t.Fatalf("%v: got: %v points\nwant: %v", s, n, N)
+	}
+	if new(big.Int).Mod(s.N1, s.N2).Sign() != 0 {
+		t.Fatalf("%v: N2 doesn't divide N1", s)
+	}
+	// All the points [i]G1+[j]G2 must be distinct.
+	n1, n2 := int(s.N1.Int64()), int(s.N2.Int64())
+	var points []C.Point
+	P := E.Identity()
+	for i := 0; i < n1; i++ {
+		Q := P
+		for j := 0; j < n2; j++ {
+			for _, R := range points {
+				if R.IsEqual(Q) {
+					t.Fatalf("%v: [%v]G1+[%v]G2 is repeated", s, i, j)
+				}
+			}
+			points = append(points, Q)
+			Q = E.Add(Q, s.G2)
+		}
+		P = E.Add(P, s.G1)
+	}
+	return s
+}
+
+func TestStructure(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		testStructure(t, E)
+	}
+
+	// y^2=x^3-x over F_103 has 104 points and full 2-torsion.
+	F := GF.NewFp("103", 103)
+	E := C.Weierstrass.New("E", F, F.Elt(-1), F.Zero(), nil, nil)
+	s := testStructure(t, E)
+	if s.N1.Int64() != 52 || s.N2.Int64() != 2 {
+		t.Fatalf("got: %v\nwant: Z/52 x Z/2", s)
+	}
+	// Its twisted Edwards model has points at infinity, which are not
+	// represented, so it is not tested.
+	for _, model := range []C.Model{C.WeierstrassC, C.Montgomery} {
+		E0, _, err := C.ConvertTo(E, model)
+		if err != nil {
+			continue
+		}
+		if s0 := testStructure(t, E0); s0.String() != s.String() {
+			t.Fatalf("%v: got: %v\nwant: %v", model, s0, s)
+		}
+	}
+}
+
+func TestTorsion(t *testing.T) {
+	F := GF.NewFp("103", 103)
+	curves := []C.EllCurve{C.Weierstrass.New("E", F, F.Elt(-1), F.Zero(), nil, nil)}
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		curves = append(curves, E)
+	}
+	for _, E := range curves {
+		s, err := C.Structure(E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for n := 1; n <= 8; n++ {
+			k := big.NewInt(int64(n))
+			got := C.Torsion(E, n)
+			for _, P := range got {
+				if !E.ScalarMult(P, k).IsIdentity() {
+					t.Fatalf("%v: [%v]%v is not the identity", E, n, P)
+				}
+			}
+			// #E[n] = gcd(n, N1)*gcd(n, N2).
+			g1 := new(big.Int).GCD(nil, nil, k, s.N1)
+			g2 := new(big.Int).GCD(nil, nil, k, s.N2)
+			if want := g1.Int64() * g2.Int64(); int64(len(got)) != want {
+				t.Fatalf("%v: #E[%v] got: %v\nwant: %v", s, n, len(got), want)
+			}
+		}
+	}
+}
+
+func TestPointOfOrder(t *testing.T) {
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		s, err := C.Structure(E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range C.Factorize(s.N1) {
+			d := new(big.Int).Set(f.P)
+			P, err := C.PointOfOrder(E, d, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if P.IsIdentity() || !E.ScalarMult(P, d).IsIdentity() {
+				t.Fatalf("%v: %v has not order %v", curveID, P, d)
+			}
+		}
+		d := new(big.Int).Add(s.N1, big.NewInt(1))
+		if _, err := C.PointOfOrder(E, d, rand.Reader); err == nil {
+			t.Fatalf("%v: expected an error for order %v", curveID, d)
+		}
+	}
+}
+
+func TestTorsionIncomplete(t *testing.T) {
+	// d=4 is a square, so the curve has four points at infinity, which have
+	// no affine image and are not represented.
+	F := GF.NewFp("53", 53)
+	E := C.TwistedEdwards.New("", F, F.One(), F.Elt(4), nil, nil)
+	W, m, err := C.ConvertTo(E, C.Weierstrass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var affine []C.Point
+	for i := 0; i < 53; i++ {
+		for j := 0; j < 53; j++ {
+			x, y := F.Elt(i), F.Elt(j)
+			x2, y2 := F.Sqr(x), F.Sqr(y)
+			if F.AreEqual(F.Add(x2, y2), F.Add(F.One(), F.Mul(F.Elt(4), F.Mul(x2, y2)))) {
+				affine = append(affine, E.NewPoint(x, y))
+			}
+		}
+	}
+	if N := C.CountPoints(E); N.Int64() != int64(len(affine))+4 {
+		t.Fatalf("got: %v points\nwant: %v", N, len(affine)+4)
+	}
+	for n := 1; n <= 8; n++ {
+		k := big.NewInt(int64(n))
+		want := 0
+		for _, P := range affine {
+			if W.ScalarMult(m.Push(P), k).IsIdentity() {
+				want++
+			}
+		}
+		got := C.Torsion(E, n)
+		for _, P := range got {
+			if !E.IsOnCurve(P) || !W.ScalarMult(m.Push(P), k).IsIdentity() {
+				t.Fatalf("[%v]%v is not the identity", n, P)
+			}
+		}
+		if len(got) != want {
+			t.Fatalf("#E[%v] got: %v\nwant: %v", n, len(got), want)
+		}
+	}
+	// The points of order two not in the subgroup generated by a point of
+	// order 28 are at infinity.
+	if _, err := C.Structure(E); err == nil {
+		t.Fatal("Structure must fail without generators with affine images")
+	}
+}
@@ -0,0 +1,323 @@
+package toy
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Search describes the curves looked for by Generate.
+type Search struct {
+	Model    C.Model
+	Field    GF.Field
+	Cofactor int // Number of points divided by the prime order r.
+	// CM uses curves with complex multiplication by Z[w] or Z[i], that is,
+	// y^2=x^3+b and y^2=x^3+ax, whose orders are derived from a
+	// representation of p instead of counting points. It is only available
+	// for Weierstrass curves over prime fields.
+	CM bool
+	// MinEmbeddingDegree rejects curves whose embedding degree is smaller,
+	// as they are weak against the MOV attack.
+	MinEmbeddingDegree int
+	// MinCMDiscriminant rejects curves whose CM discriminant has a smaller
+	// absolute value. It must be zero when CM is set.
+	MinCMDiscriminant int64
+	// MaxTries is the number of curves tried before failing; defaults to 1000.
+	MaxTries int
+	Rand     io.Reader // Defaults to crypto/rand.Reader.
+}
+
+// Result is a curve found by Generate.
+type Result struct {
+	Model C.Model
+	E     C.EllCurve
+	G     C.Point  // Point of order R.
+	A, B  GF.Elt   // Parameters given to Model.New.
+	R, H  *big.Int // Prime order of G and cofactor.
+	Trace *big.Int // q+1-R*H.
+	// EmbeddingDegree is the order of q modulo R.
+	EmbeddingDegree *big.Int
+	// CMDiscriminant is the fundamental discriminant of t^2-4q; it is
+	// approximate if the factorization of t^2-4q is incomplete.
+	CMDiscriminant *big.Int
+}
+
+// String returns the curve as a params literal to be registered in this
+// package. It assumes that all the values fit in an int, and that A and B
+// lie in the prime field.
+func (r *Result) String() string {
+	F := r.E.Field()
+	return fmt.Sprintf("&params{model: C.%v, p: %v, m: %v, a: %v, b: %v, r: %v, h: %v, x: %v, y: %v}",
+		r.Model, F.P(), F.Ext(), eltLiteral(r.A), eltLiteral(r.B),
+		r.R, r.H, eltLiteral(r.G.X()), eltLiteral(r.G.Y()))
+}
+
+func eltLiteral(x GF.Elt) string {
+	c := x.Polynomial()
+	if len(c) == 1 {
+		return c[0].String()
+	}
+	s := "[]interface{}{"
+	for i := range c {
+		if i > 0 {
+			s += ", "
+		}
+		s += c[i].String()
+	}
+	return s + "}"
+}
+
+// minCofactor is the order of the rational torsion that every curve of a
+// model has.
+var minCofactor = map[C.Model]int{
+	C.WeierstrassC:       2,
+	C.JacobiQuartic:      2,
+	C.Montgomery:         4,
+	C.TwistedEdwards:     4,
+	C.JacobiIntersection: 4,
+	C.Huff:               8,
+}
+
+// Generate searches for a curve of the given model with R*H points, where R
+// is prime and H is the prescribed cofactor.
+func Generate(s Search) (*Result, error) {
+	F := s.Field
+	if s.Cofactor <= 0 {
+		return nil, errors.New("cofactor must be positive")
+	}
+	if m, ok := minCofactor[s.Model]; ok && s.Cofactor%m != 0 {
+		return nil, fmt.Errorf("%v curves have cofactor divisible by %v", s.Model, m)
+	}
+	if s.CM && (s.Model != C.Weierstrass || F.Ext() != 1) {
+		return nil, errors.New("CM is only supported for Weierstrass curves over prime fields")
+	}
+	if s.CM && s.MinCMDiscriminant != 0 {
+		return nil, errors.New("CM curves have CM discriminant -3 or -4")
+	}
+	rnd := s.Rand
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	tries := s.MaxTries
+	if tries == 0 {
+		tries = 1000
+	}
+	h := big.NewInt(int64(s.Cofactor))
+	q := F.Order()
+
+	var cm *cmCurves
+	if s.CM {
+		cm = newCMCurves(F)
+		if cm == nil {
+			return nil, errors.New("p is neither 1 mod 3 nor 1 mod 4")
+		}
+	}
+	for i := 0; i < tries; i++ {
+		a, b := F.Rand(rnd), F.Rand(rnd)
+		var n *big.Int
+		if s.CM {
+			a, b = cm.params(a, b)
+		}
+		E := newCurve(s.Model, F, a, b)
+		if E == nil {
+			continue
+		}
+		if s.CM {
+			n = cm.order(E, rnd)
+		} else {
+			n = C.CountPoints(E)
+		}
+		r, m := new(big.Int).QuoRem(n, h, new(big.Int))
+		if m.Sign() != 0 || !r.ProbablyPrime(20) || r.Cmp(big.NewInt(3)) < 0 {
+			continue
+		}
+		res := &Result{Model: s.Model, A: a, B: b, R: r, H: h}
+		res.Trace = new(big.Int).Add(q, big.NewInt(1))
+		res.Trace.Sub(res.Trace, n)
+		if res.Trace.Cmp(big.NewInt(1)) == 0 || r.Cmp(F.P()) == 0 {
+			continue // anomalous
+		}
+		res.EmbeddingDegree = embeddingDegree(q, r)
+		if res.EmbeddingDegree.Cmp(big.NewInt(int64(s.MinEmbeddingDegree))) < 0 {
+			continue
+		}
+		res.CMDiscriminant = cmDiscriminant(q, res.Trace)
+		if new(big.Int).Abs(res.CMDiscriminant).Cmp(big.NewInt(s.MinCMDiscriminant)) < 0 {
+			continue
+		}
+		res.E = s.Model.New(fmt.Sprintf("%v over %v", s.Model, F.P()), F, a, b, r, h)
+		if res.G = basePoint(res.E, rnd); res.G == nil {
+			continue
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("no curve found after %v tries", tries)
+}
+
+// newCurve returns a curve of the given model, or nil if the parameters
+// define a singular curve.
+func newCurve(model C.Model, F GF.Field, a, b GF.Elt) (E C.EllCurve) {
+	defer func() {
+		if recover() != nil {
+			E = nil
+		}
+	}()
+	return model.New("", F, a, b, nil, nil)
+}
+
+// basePoint returns a point of order E.Order(), or nil if it fails to find
+// one whose affine coordinates are defined.
+func basePoint(E C.EllCurve, rnd io.Reader) C.Point {
+	W, m, err := C.ConvertTo(E, C.Weierstrass)
+	if err != nil {
+		return nil
+	}
+	F := E.Field()
+	for i := 0; i < 100; i++ {
+		x := F.Rand(rnd)
+		y2 := W.(C.W).EvalRHS(x)
+		if !F.IsSquare(y2) {
+			continue
+		}
+		G := E.ClearCofactor(m.Pull(W.NewPoint(x, F.Sqrt(y2))))
+		if !G.IsIdentity() && G.X() != nil && G.Y() != nil {
+			return G
+		}
+	}
+	return nil
+}
+
+// embeddingDegree returns the order of q in the multiplicative group modulo
+// the prime r.
+func embeddingDegree(q, r *big.Int) *big.Int {
+	k := new(big.Int).Sub(r, big.NewInt(1))
+	qr := new(big.Int).Mod(q, r)
+	for _, f := range C.Factorize(k) {
+		for i := 0; i < f.E; i++ {
+			m := new(big.Int).Quo(k, f.P)
+			if new(big.Int).Exp(qr, m, r).Cmp(big.NewInt(1)) != 0 {
+				break
+			}
+			k = m
+		}
+	}
+	return k
+}
+
+// cmDiscriminant returns the fundamental discriminant of t^2-4q.
+func cmDiscriminant(q, t *big.Int) *big.Int {
+	d := new(big.Int).Mul(t, t)
+	d.Sub(d, new(big.Int).Lsh(q, 2))
+	// Removes the square factors of |d|.
+	s := big.NewInt(1)
+	for _, f := range C.Factorize(new(big.Int).Abs(d)) {
+		if f.E%2 == 1 {
+			s.Mul(s, f.P)
+		}
+	}
+	s.Neg(s)
+	if m := new(big.Int).Mod(s, big.NewInt(4)); m.Cmp(big.NewInt(1)) != 0 {
+		s.Lsh(s, 2)
+	}
+	return s
+}
+
+// cmCurves generates curves with j-invariant 0 if p=1 mod 3, and 1728
+// otherwise, using the traces of their twists obtained from p=a^2+3b^2 or
+// p=a^2+b^2.
+type cmCurves struct {
+	F      GF.Field
+	jZero  bool
+	traces []*big.Int
+}
+
+func newCMCurves(F GF.Field) *cmCurves {
+	p := F.P()
+	c := &cmCurves{F: F}
+	var a, b *big.Int
+	switch {
+	case new(big.Int).Mod(p, big.NewInt(3)).Int64() == 1:
+		c.jZero = true
+		a, b = cornacchia(F, 3)
+		// Traces are ±2a, ±(a+3b) and ±(a-3b).
+		b3 := new(big.Int).Mul(b, big.NewInt(3))
+		c.traces = []*big.Int{
+			new(big.Int).Lsh(a, 1),
+			new(big.Int).Add(a, b3),
+			new(big.Int).Sub(a, b3),
+		}
+	case new(big.Int).Mod(p, big.NewInt(4)).Int64() == 1:
+		a, b = cornacchia(F, 1)
+		// Traces are ±2a and ±2b.
+		c.traces = []*big.Int{new(big.Int).Lsh(a, 1), new(big.Int).Lsh(b, 1)}
+	default:
+		return nil
+	}
+	for _, t := range c.traces {
+		c.traces = append(c.traces, new(big.Int).Neg(t))
+	}
+	return c
+}
+
+// params returns the parameters of y^2=x^3+b or y^2=x^3+ax.
+func (c *cmCurves) params(a, b GF.Elt) (GF.Elt, GF.Elt) {
+	if c.jZero {
+		return c.F.Zero(), b
+	}
+	return a, c.F.Zero()
+}
+
+// order returns the number of points of E, which is the only candidate
+// q+1-t that annihilates enough random points.
+func (c *cmCurves) order(E C.EllCurve, rnd io.Reader) *big.Int {
+	W := E.(C.W)
+	F := c.F
+	candidates := make([]*big.Int, len(c.traces))
+	for i, t := range c.traces {
+		candidates[i] = new(big.Int).Add(F.Order(), big.NewInt(1))
+		candidates[i].Sub(candidates[i], t)
+	}
+	for len(candidates) > 1 {
+		x := F.Rand(rnd)
+		y2 := W.EvalRHS(x)
+		if !F.IsSquare(y2) {
+			continue
+		}
+		P := W.NewPoint(x, F.Sqrt(y2))
+		var next []*big.Int
+		for _, n := range candidates {
+			if W.ScalarMult(P, n).IsIdentity() {
+				next = append(next, n)
+			}
+		}
+		if len(next) == len(candidates) && F.Order().Cmp(big.NewInt(1000)) < 0 {
+			// Small fields may not distinguish the candidates.
+			return C.CountPoints(E)
+		}
+		candidates = next
+	}
+	return candidates[0]
+}
+
+// cornacchia returns a, b > 0 such that p=a^2+db^2.
+func cornacchia(F GF.Field, d int64) (a, b *big.Int) {
+	p := F.P()
+	r := F.Sqrt(F.Elt(-d)).Polynomial()[0]
+	if h := new(big.Int).Rsh(p, 1); r.Cmp(h) <= 0 {
+		r.Sub(p, r)
+	}
+	a, b = new(big.Int).Set(p), r
+	l := new(big.Int).Sqrt(p)
+	for b.Cmp(l) > 0 {
+		a, b = b, new(big.Int).Mod(a, b)
+	}
+	c := new(big.Int).Mul(b, b)
+	c.Sub(p, c)
+	c.Quo(c, big.NewInt(d))
+	return b, new(big.Int).Sqrt(c)
+}
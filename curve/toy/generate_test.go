@@ -0,0 +1,59 @@
+package toy_test
+
+import (
+	"strings"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func TestGenerateCurves(t *testing.T) {
+	for _, s := range []toy.Search{
+		{Model: C.Weierstrass, Field: GF.NewFp("103", 103), Cofactor: 1},
+		{Model: C.Weierstrass, Field: GF.NewFp2("19", 19), Cofactor: 1},
+		{Model: C.Montgomery, Field: GF.NewFp("1019", 1019), Cofactor: 4},
+		{Model: C.TwistedEdwards, Field: GF.NewFp("1019", 1019), Cofactor: 8},
+		{Model: C.Weierstrass, Field: GF.NewFp("100003", 100003), Cofactor: 1,
+			MinEmbeddingDegree: 100, MinCMDiscriminant: 1000},
+		{Model: C.Weierstrass, Field: GF.NewFp("1000033", 1000033), Cofactor: 1, CM: true},
+		{Model: C.Weierstrass, Field: GF.NewFp("1000541", 1000541), Cofactor: 4, CM: true},
+	} {
+		r, err := toy.Generate(s)
+		if err != nil {
+			t.Fatalf("%v over %v: %v", s.Model, s.Field, err)
+		}
+		if err := C.VerifyOrder(r.E); err != nil {
+			t.Fatalf("%v: %v", s.Model, err)
+		}
+		if !r.R.ProbablyPrime(20) || r.H.Int64() != int64(s.Cofactor) {
+			t.Fatalf("%v: wrong order %v*%v", s.Model, r.R, r.H)
+		}
+		if r.G.IsIdentity() || !r.E.ScalarMult(r.G, r.R).IsIdentity() {
+			t.Fatalf("%v: %v has not order %v", s.Model, r.G, r.R)
+		}
+		if r.EmbeddingDegree.Int64() < int64(s.MinEmbeddingDegree) {
+			t.Fatalf("%v: embedding degree %v", s.Model, r.EmbeddingDegree)
+		}
+		if d := r.CMDiscriminant.Int64(); d >= 0 || -d < s.MinCMDiscriminant || (s.CM && d != -3 && d != -4) {
+			t.Fatalf("%v: CM discriminant %v", s.Model, d)
+		}
+		if !strings.HasPrefix(r.String(), "&params{model: C."+s.Model.String()) {
+			t.Fatalf("%v: %v", s.Model, r)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	F := GF.NewFp("103", 103)
+	for _, s := range []toy.Search{
+		{Model: C.Montgomery, Field: F, Cofactor: 1},
+		{Model: C.Weierstrass, Field: F, Cofactor: 0},
+		{Model: C.TwistedEdwards, Field: F, Cofactor: 4, CM: true},
+	} {
+		if _, err := toy.Generate(s); err == nil {
+			t.Fatalf("%v: expected an error", s.Model)
+		}
+	}
+}
@@ -26,6 +26,8 @@ const (
 	E1    ID = "E1"
 )
 
+// params describes a toy curve; r is the largest prime factor of its number
+// of points, and h is its cofactor.
 type params struct {
 	model C.Model
 	p, m  int
@@ -42,17 +44,17 @@ func init() {
 	Curves = make([]ID, 0, 10)
 	toyCurves = make(map[ID]*params)
 
-	W0.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 3, b: 2, r: 51, h: 3, x: 46, y: 3})
-	W1.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 0, b: 1, r: 54, h: 2, x: 13, y: 5})
-	W1ISO.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 38, b: 22, r: 54, h: 2, x: 41, y: 45})
-	W2.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 0, b: 2, r: 51, h: 3, x: 37, y: 27})
-	W3.register(&params{model: C.Weierstrass, p: 59, m: 1, a: 16, b: 0, r: 60, h: 4, x: 33, y: 11})
-	WC0.register(&params{model: C.WeierstrassC, p: 53, m: 1, a: 2, b: 3, r: 66, h: 6, x: 45, y: 4})
-	M0.register(&params{model: C.Montgomery, p: 53, m: 1, a: 4, b: 3, r: 44, h: 4, x: 16, y: 4})
-	M1.register(&params{model: C.Montgomery, p: 53, m: 1, a: 3, b: 1, r: 48, h: 4, x: 14, y: 22})
-	E0.register(&params{model: C.TwistedEdwards, p: 53, m: 1, a: 1, b: 3, r: 44, h: 4, x: 17, y: 49})
-	E1.register(&params{model: C.TwistedEdwards, p: 53, m: 1, a: -1, b: 12, r: 48, h: 4, x: 3, y: 19})
-	W4.register(&params{model: C.Weierstrass, p: 19, m: 2, a: 1, b: 4, r: 399, h: 3, x: []interface{}{0, 1}, y: 17})
+	W0.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 3, b: 2, r: 17, h: 3, x: 46, y: 3})
+	W1.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 0, b: 1, r: 3, h: 18, x: 13, y: 5})
+	W1ISO.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 38, b: 22, r: 3, h: 18, x: 41, y: 45})
+	W2.register(&params{model: C.Weierstrass, p: 53, m: 1, a: 0, b: 2, r: 3, h: 18, x: 37, y: 27})
+	W3.register(&params{model: C.Weierstrass, p: 59, m: 1, a: 16, b: 0, r: 5, h: 12, x: 33, y: 11})
+	WC0.register(&params{model: C.WeierstrassC, p: 53, m: 1, a: 2, b: 3, r: 11, h: 6, x: 45, y: 4})
+	M0.register(&params{model: C.Montgomery, p: 53, m: 1, a: 4, b: 3, r: 11, h: 4, x: 16, y: 4})
+	M1.register(&params{model: C.Montgomery, p: 53, m: 1, a: 3, b: 1, r: 3, h: 16, x: 14, y: 22})
+	E0.register(&params{model: C.TwistedEdwards, p: 53, m: 1, a: 1, b: 3, r: 11, h: 4, x: 17, y: 49})
+	E1.register(&params{model: C.TwistedEdwards, p: 53, m: 1, a: -1, b: 12, r: 3, h: 16, x: 3, y: 19})
+	W4.register(&params{model: C.Weierstrass, p: 19, m: 2, a: 1, b: 4, r: 19, h: 21, x: []interface{}{0, 1}, y: 17})
 }
 
 func (id ID) register(p *params) { toyCurves[id] = p; Curves = append(Curves, id) }
@@ -1,6 +1,7 @@
 package toy_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/armfazh/tozan-ecc/curve/toy"
@@ -13,3 +14,19 @@ func TestGenerate(t *testing.T) {
 		}
 	}
 }
+
+func TestOrder(t *testing.T) {
+	for _, curveId := range toy.Curves {
+		E, g, _ := curveId.New()
+		if !E.Order().ProbablyPrime(20) {
+			t.Fatalf("Curve: %v order %v is not prime", curveId, E.Order())
+		}
+		n := new(big.Int).Mul(E.Order(), E.Cofactor())
+		if !E.ScalarMult(g, n).IsIdentity() {
+			t.Fatalf("Curve: %v order of %v does not divide %v", curveId, g, n)
+		}
+		if E.ClearCofactor(g).IsIdentity() {
+			t.Fatalf("Curve: %v %v has no component of order %v", curveId, g, E.Order())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Twist returns the quadratic twist of E by the non-square d. The twist has
+// the same model as E, except for Hessian and Huff curves, whose twists are
+// returned in short Weierstrass form. The order of the twist is derived from
+// the trace t=q+1-#E as #E'=q+1+t; its cofactor is chosen so that Order()
+// returns the largest prime factor of #E'.
+func Twist(E EllCurve, d GF.Elt) EllCurve {
+	F := E.Field()
+	if F.IsZero(d) || F.IsSquare(d) {
+		panic(errors.New("twisting element must be a non-square"))
+	}
+	r, h := twistOrder(E)
+	switch e := E.(type) {
+	case *weCurve:
+		t0 := F.Sqr(d) // d^2
+		name := "twist of " + e.Name
+		return Weierstrass.New(name, F, F.Mul(e.A, t0), F.Mul(e.B, F.Mul(t0, d)), r, h)
+	case *wcCurve:
+		name := "twist of " + e.Name
+		return WeierstrassC.New(name, F, F.Mul(e.A, d), F.Mul(e.B, F.Sqr(d)), r, h)
+	case *geCurve:
+		// Completing the square gives y^2=x^3+(b2/4)x^2+(b4/2)x+b6/4,
+		// which is twisted as a WeierstrassC curve.
+		b2, b4, b6, _ := e.bInvariants()
+		quarter := F.Inv(F.Elt(4))
+		t0 := F.Sqr(d)                                      // d^2
+		a2 := F.Mul(F.Mul(b2, quarter), d)                  // db2/4
+		a4 := F.Mul(F.Mul(b4, F.Add(quarter, quarter)), t0) // d^2b4/2
+		a6 := F.Mul(F.Mul(b6, quarter), F.Mul(t0, d))       // d^3b6/4
+		z := F.Zero()
+		return NewWeierstrassGeneral("twist of "+e.Name, F, z, a2, z, a4, a6, r, h)
+	case *mtCurve:
+		return Montgomery.New("twist of "+e.Name, F, e.A, F.Mul(e.B, d), r, h)
+	case *teCurve:
+		return TwistedEdwards.New("twist of "+e.Name, F, F.Mul(e.A, d), F.Mul(e.D, d), r, h)
+	case *jqCurve:
+		return JacobiQuartic.New("twist of "+e.Name, F, F.Mul(e.A, d), F.Mul(e.D, F.Sqr(d)), r, h)
+	case *jiCurve:
+		return JacobiIntersection.New("twist of "+e.Name, F, F.Mul(e.A, d), F.Mul(e.B, d), r, h)
+	case *thCurve:
+		return Twist(e.ToWeierstrass().Codomain(), d)
+	case *ghCurve:
+		return Twist(e.ToWeierstrass().Codomain(), d)
+	case *hfCurve:
+		return Twist(e.ToWeierstrass().Codomain(), d)
+	default:
+		panic(fmt.Errorf("elliptic curve model not supported: %T", E))
+	}
+}
+
+// twistOrder returns the largest prime factor r of the order of the twist of
+// E and the cofactor h=#E'/r. Both are nil if the order of E is unknown.
+func twistOrder(E EllCurve) (r, h *big.Int) {
+	n, err := curveOrder(E)
+	if err != nil {
+		return nil, nil
+	}
+	n = twistCardinality(E.Field(), n)
+	factors := Factorize(n)
+	r = factors[len(factors)-1].P
+	if !factors[len(factors)-1].Prime {
+		// The largest factor is composite; uses the largest prime instead.
+		r = big.NewInt(1)
+		for i := len(factors) - 1; i >= 0; i-- {
+			if factors[i].Prime {
+				r = factors[i].P
+				break
+			}
+		}
+	}
+	return r, new(big.Int).Quo(n, r)
+}
+
+var errUnknownOrder = errors.New("order of the curve is unknown")
+
+// curveOrder returns the number of points of E, or errUnknownOrder if it is
+// unknown.
+func curveOrder(E EllCurve) (*big.Int, error) {
+	if E.Order() == nil || E.Cofactor() == nil {
+		return nil, errUnknownOrder
+	}
+	return new(big.Int).Mul(E.Order(), E.Cofactor()), nil
+}
+
+// twistCardinality returns 2(q+1)-n, the number of points of the quadratic
+// twist of a curve with n points over a field with q elements.
+func twistCardinality(F GF.Field, n *big.Int) *big.Int {
+	q := F.Order()
+	q.Add(q, big.NewInt(1))
+	q.Lsh(q, 1)
+	return q.Sub(q, n)
+}
+
+// TwistReport describes the security of a curve against attacks that move
+// the computation to its quadratic twist, such as invalid-point attacks on
+// x-only ladders.
+type TwistReport struct {
+	Order   *big.Int // Number of points of the curve.
+	Trace   *big.Int // Trace of Frobenius, q+1-Order.
+	Twist   *big.Int // Number of points of the twist, q+1+Trace.
+	Factors []Factor // Factorization of Twist.
+	// Security is an estimate, in bits, of the cost of solving discrete
+	// logarithms in the twist using Pollard's rho method on its largest
+	// prime-order subgroup. It is zero if no prime factor was found.
+	Security int
+}
+
+func (r *TwistReport) String() string {
+	f := make([]string, len(r.Factors))
+	for i := range r.Factors {
+		f[i] = r.Factors[i].String()
+	}
+	return fmt.Sprintf("#E: %v\ntrace: %v\n#E': %v\nfactors: %v\nsecurity: %v bits\n",
+		r.Order, r.Trace, r.Twist, strings.Join(f, " * "), r.Security)
+}
+
+// TwistSecurity factors the order of the quadratic twist of E and estimates
+// its security. It returns an error if the order of E is unknown.
+func TwistSecurity(E EllCurve) (*TwistReport, error) {
+	n, err := curveOrder(E)
+	if err != nil {
+		return nil, err
+	}
+	q := E.Field().Order()
+	r := &TwistReport{Order: n}
+	r.Trace = q.Add(q, big.NewInt(1))
+	r.Trace.Sub(r.Trace, n)
+	r.Twist = twistCardinality(E.Field(), n)
+	r.Factors = Factorize(r.Twist)
+	for i := len(r.Factors) - 1; i >= 0; i-- {
+		if r.Factors[i].Prime {
+			r.Security = r.Factors[i].P.BitLen() / 2
+			break
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,79 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func nonSquare(F GF.Field) GF.Elt {
+	for {
+		if d := F.Rand(rand.Reader); !F.IsZero(d) && !F.IsSquare(d) {
+			return d
+		}
+	}
+}
+
+func TestTwist(t *testing.T) {
+	models := []C.Model{C.Weierstrass, C.WeierstrassC, C.WeierstrassGeneral,
+		C.Montgomery, C.TwistedEdwards, C.JacobiQuartic, C.JacobiIntersection, C.Huff}
+	for _, curveID := range toy.Curves {
+		E, _, _ := curveID.New()
+		F := E.Field()
+		d := nonSquare(F)
+		n := new(big.Int).Mul(E.Order(), E.Cofactor())
+		for _, model := range models {
+			E0, _, err := C.ConvertTo(E, model)
+			if err != nil {
+				continue
+			}
+			E1 := C.Twist(E0, d)
+			if got, want := E1.(C.Invariants).JInvariant(), E0.(C.Invariants).JInvariant(); !F.AreEqual(got, want) {
+				t.Fatalf("%v %v:\ngot:  %v\nwant: %v", curveID, model, got, want)
+			}
+			if _, ok := C.IsIsomorphic(E0, C.Twist(E1, d)); !ok {
+				t.Fatalf("%v %v: twist of twist is not isomorphic to the curve", curveID, model)
+			}
+
+			// #E+#E' = 2(q+1)
+			n1 := new(big.Int).Mul(E1.Order(), E1.Cofactor())
+			got := new(big.Int).Add(n, n1)
+			want := new(big.Int).Add(F.Order(), big.NewInt(1))
+			want.Lsh(want, 1)
+			if got.Cmp(want) != 0 {
+				t.Fatalf("%v %v:\ngot:  %v\nwant: %v", curveID, model, got, want)
+			}
+			W, _, _ := C.ConvertTo(E1, C.Weierstrass)
+			for i := 0; i < 20; i++ {
+				x := F.Elt(i)
+				y2 := W.(C.W).EvalRHS(x)
+				if !F.IsSquare(y2) {
+					continue
+				}
+				P := W.NewPoint(x, F.Sqrt(y2))
+				if Q := W.ScalarMult(P, n1); !Q.IsIdentity() {
+					t.Fatalf("%v %v: order of %v does not divide %v", curveID, model, P, n1)
+				}
+			}
+		}
+	}
+}
+
+func TestTwistSecurity(t *testing.T) {
+	E, _, _ := toy.W0.New()
+	r, err := C.TwistSecurity(E)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// W0 has 51 points over F53, so its twist has 57 = 3*19 points.
+	if r.Twist.Int64() != 57 || r.Trace.Int64() != 3 || r.Security != 2 {
+		t.Fatalf("unexpected report:\n%v", r)
+	}
+	if len(r.Factors) != 2 || r.Factors[0].P.Int64() != 3 || r.Factors[1].P.Int64() != 19 {
+		t.Fatalf("unexpected factors: %v", r.Factors)
+	}
+}
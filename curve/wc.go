@@ -9,17 +9,13 @@ import (
 )
 
 // wcCurve is a Weierstrass curve
-type wcCurve struct {
-	*params
-	RationalMap
-}
+type wcCurve struct{ *params }
 
 type WC = *wcCurve
 
 func (e *wcCurve) String() string { return "y^2=x^3+Ax^2+Bx\n" + e.params.String() }
 func (e *wcCurve) New() EllCurve {
 	if e.IsValid() {
-		e.RationalMap = e.ToWeierstrass()
 		return e
 	}
 	panic(errors.New("can't instantiate a WeierstrassC curve"))
@@ -31,26 +27,262 @@ func (e *wcCurve) NewPoint(x, y GF.Elt) (P Point) {
 	panic(fmt.Errorf("%v not on %v", P, e))
 }
 
-func (e *wcCurve) IsValid() bool {
+// Discriminant returns 16B^2(A^2-4B).
+func (e *wcCurve) Discriminant() GF.Elt {
+	F := e.F
+	t0 := F.Sqr(e.A)            // A^2
+	t1 := F.Mul(F.Elt(4), e.B)  // 4B
+	t0 = F.Sub(t0, t1)          // A^2-4B
+	t1 = F.Sqr(e.B)             // B^2
+	t0 = F.Mul(t0, t1)          // B^2(A^2-4B)
+	return F.Mul(F.Elt(16), t0) // 16B^2(A^2-4B)
+}
+
+// JInvariant returns 256(A^2-3B)^3/(B^2(A^2-4B)).
+func (e *wcCurve) JInvariant() GF.Elt {
 	F := e.F
-	t0 := F.Sqr(e.A)      // A^2
-	t1 := F.Add(e.B, e.B) // 2B
-	t1 = F.Add(t1, t1)    // 4B
-	t0 = F.Sub(t0, t1)    // A^2-4B
-	t0 = F.Mul(t0, e.B)   // B(A^2-4B)
-	return !F.IsZero(t0)  // B(A^2-4B) != 0
+	t0 := F.Sqr(e.A)                      // A^2
+	t1 := F.Sub(t0, F.Mul(F.Elt(3), e.B)) // A^2-3B
+	t0 = F.Sub(t0, F.Mul(F.Elt(4), e.B))  // A^2-4B
+	t0 = F.Mul(t0, F.Sqr(e.B))            // B^2(A^2-4B)
+	t1 = F.Mul(F.Sqr(t1), t1)             // (A^2-3B)^3
+	t1 = F.Mul(F.Elt(256), t1)            // 256(A^2-3B)^3
+	return F.Mul(t1, F.Inv(t0))           // 256(A^2-3B)^3/(B^2(A^2-4B))
 }
+
+func (e *wcCurve) IsValid() bool { return !e.F.IsZero(e.Discriminant()) }
 func (e *wcCurve) IsEqual(ec EllCurve) bool {
-	e0 := ec.(*weCurve)
-	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+	e0, ok := ec.(*wcCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+}
+func (e *wcCurve) IsOnCurve(p Point) bool {
+	if _, isZero := p.(*infPoint); isZero {
+		return isZero
+	}
+	P := p.(*ptWc)
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Add(P.x, e.A) // x+A
+	t0 = F.Mul(t0, P.x)  // (x+A)x
+	t0 = F.Add(t0, e.B)  // (x+A)x+B
+	t0 = F.Mul(t0, P.x)  // ((x+A)x+B)x
+	t1 = F.Sqr(P.y)      // y^2
+	return F.AreEqual(t0, t1)
+}
+func (e *wcCurve) Identity() Point { return &infPoint{} }
+func (e *wcCurve) Add(p, q Point) Point {
+	if p.IsIdentity() {
+		return q.Copy()
+	} else if q.IsIdentity() {
+		return p.Copy()
+	} else if p.IsEqual(e.Neg(q)) {
+		return e.Identity()
+	} else if p.IsEqual(q) {
+		return e.Double(p)
+	} else {
+		return e.add(p, q)
+	}
+}
+func (e *wcCurve) Neg(p Point) Point {
+	if _, isZero := p.(*infPoint); isZero {
+		return e.Identity()
+	}
+	P := p.(*ptWc)
+	return &ptWc{e, &afPoint{x: P.x.Copy(), y: e.F.Neg(P.y)}}
+}
+func (e *wcCurve) add(p, q Point) Point {
+	P := p.(*ptWc)
+	Q := q.(*ptWc)
+	F := e.F
+
+	if F.AreEqual(P.x, Q.x) {
+		panic("wrong inputs")
+	}
+
+	var t0, t1, ll GF.Elt
+	t0 = F.Sub(Q.y, P.y) // (y2-y1)
+	t1 = F.Sub(Q.x, P.x) // (x2-x1)
+	t1 = F.Inv(t1)       // 1/(x2-x1)
+	ll = F.Mul(t0, t1)   // l = (y2-y1)/(x2-x1)
+
+	t0 = F.Sqr(ll)      // l^2
+	t0 = F.Sub(t0, e.A) // l^2-A
+	t0 = F.Sub(t0, P.x) // l^2-A-x1
+	x := F.Sub(t0, Q.x) // x' = l^2-A-x1-x2
+
+	t0 = F.Sub(P.x, x)  // x1-x3
+	t0 = F.Mul(t0, ll)  // l(x1-x3)
+	y := F.Sub(t0, P.y) // y3 = l(x1-x3)-y1
+
+	return &ptWc{e, &afPoint{x: x, y: y}}
+}
+func (e *wcCurve) Double(p Point) Point {
+	if _, ok := p.(*infPoint); ok {
+		return e.Identity()
+	}
+	P := p.(*ptWc)
+	if P.IsTwoTorsion() {
+		return e.Identity()
+	}
+
+	F := e.F
+	var t0, t1, ll GF.Elt
+	t0 = F.Mul(F.Elt(3), P.x) // 3x
+	t1 = F.Add(e.A, e.A)      // 2A
+	t0 = F.Add(t0, t1)        // 3x+2A
+	t0 = F.Mul(t0, P.x)       // (3x+2A)x
+	t0 = F.Add(t0, e.B)       // (3x+2A)x+B
+	t1 = F.Add(P.y, P.y)      // 2y
+	t1 = F.Inv(t1)            // 1/2y
+	ll = F.Mul(t0, t1)        // l = (3x^2+2Ax+B)/(2y)
+
+	t0 = F.Sqr(ll)      // l^2
+	t0 = F.Sub(t0, e.A) // l^2-A
+	t0 = F.Sub(t0, P.x) // l^2-A-x
+	x := F.Sub(t0, P.x) // x' = l^2-A-2x
+
+	t0 = F.Sub(P.x, x)  // x-x'
+	t0 = F.Mul(t0, ll)  // l(x-x')
+	y := F.Sub(t0, P.y) // y3 = l(x-x')-y1
+
+	return &ptWc{e, &afPoint{x: x, y: y}}
+}
+func (e *wcCurve) ClearCofactor(p Point) Point { return e.ScalarMult(p, e.H) }
+
+// Domain, Codomain, Push and Pull let the curve act as its map to the short
+// Weierstrass model, as it did when it was used for the arithmetic.
+//
+// Deprecated: Use the map returned by ToWeierstrass instead.
+func (e *wcCurve) Domain() EllCurve { return e }
+
+// Codomain returns the short Weierstrass curve isomorphic to e.
+//
+// Deprecated: Use the map returned by ToWeierstrass instead.
+func (e *wcCurve) Codomain() EllCurve { return e.ToWeierstrass().Codomain() }
+
+// Push maps p to the short Weierstrass curve.
+//
+// Deprecated: Use the map returned by ToWeierstrass instead.
+func (e *wcCurve) Push(p Point) Point { return e.ToWeierstrass().Push(p) }
+
+// Pull maps p from the short Weierstrass curve.
+//
+// Deprecated: Use the map returned by ToWeierstrass instead.
+func (e *wcCurve) Pull(p Point) Point { return e.ToWeierstrass().Pull(p) }
+
+// ScalarMult calculates kP using projective coordinates, so only one
+// inversion is performed.
+func (e *wcCurve) ScalarMult(p Point, k *big.Int) Point {
+	P := e.toProjective(p)
+	Q := e.identityProj()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		Q = e.doubleProj(Q)
+		if k.Bit(i) != 0 {
+			Q = e.addProj(Q, P)
+		}
+	}
+	return e.toAffine(Q)
+}
+
+// prWc is a point on a wcCurve curve in projective coordinates, such that
+// x=X/Z and y=Y/Z satisfy y^2=x^3+Ax^2+Bx. The identity is (0:1:0).
+type prWc struct{ X, Y, Z GF.Elt }
+
+func (e *wcCurve) identityProj() *prWc { return &prWc{e.F.Zero(), e.F.One(), e.F.Zero()} }
+func (e *wcCurve) toProjective(p Point) *prWc {
+	if p.IsIdentity() {
+		return e.identityProj()
+	}
+	P := p.(*ptWc)
+	return &prWc{P.x.Copy(), P.y.Copy(), e.F.One()}
+}
+func (e *wcCurve) toAffine(P *prWc) Point {
+	F := e.F
+	if F.IsZero(P.Z) {
+		return e.Identity()
+	}
+	invZ := F.Inv(P.Z)
+	return &ptWc{e, &afPoint{x: F.Mul(P.X, invZ), y: F.Mul(P.Y, invZ)}}
+}
+func (e *wcCurve) addProj(P, Q *prWc) *prWc {
+	F := e.F
+	if F.IsZero(P.Z) {
+		return Q
+	} else if F.IsZero(Q.Z) {
+		return P
+	}
+
+	var t0, t1, u, v, vv, vvv, w GF.Elt
+	t0 = F.Mul(Q.Y, P.Z) // Y2Z1
+	t1 = F.Mul(P.Y, Q.Z) // Y1Z2
+	u = F.Sub(t0, t1)    // u = Y2Z1-Y1Z2
+	t0 = F.Mul(Q.X, P.Z) // X2Z1
+	t1 = F.Mul(P.X, Q.Z) // X1Z2
+	v = F.Sub(t0, t1)    // v = X2Z1-X1Z2
+	if F.IsZero(v) {
+		if F.IsZero(u) {
+			return e.doubleProj(P)
+		}
+		return e.identityProj()
+	}
+	t0 = F.Add(t0, t1)    // X2Z1+X1Z2
+	zz := F.Mul(P.Z, Q.Z) // Z1Z2
+	vv = F.Sqr(v)         // v^2
+	vvv = F.Mul(vv, v)    // v^3
+	w = F.Mul(e.A, zz)    // AZ1Z2
+	w = F.Add(w, t0)      // AZ1Z2+X1Z2+X2Z1
+	w = F.Mul(w, vv)      // v^2(AZ1Z2+X1Z2+X2Z1)
+	t0 = F.Sqr(u)         // u^2
+	t0 = F.Mul(t0, zz)    // u^2Z1Z2
+	w = F.Sub(t0, w)      // w = u^2Z1Z2-v^2(AZ1Z2+X1Z2+X2Z1)
+
+	X := F.Mul(v, w)     // X3 = vw
+	t1 = F.Mul(t1, vv)   // v^2X1Z2
+	t1 = F.Sub(t1, w)    // v^2X1Z2-w
+	t1 = F.Mul(t1, u)    // u(v^2X1Z2-w)
+	t0 = F.Mul(P.Y, Q.Z) // Y1Z2
+	t0 = F.Mul(t0, vvv)  // v^3Y1Z2
+	Y := F.Sub(t1, t0)   // Y3 = u(v^2X1Z2-w)-v^3Y1Z2
+	Z := F.Mul(vvv, zz)  // Z3 = v^3Z1Z2
+	return &prWc{X, Y, Z}
+}
+func (e *wcCurve) doubleProj(P *prWc) *prWc {
+	F := e.F
+	if F.IsZero(P.Z) || F.IsZero(P.Y) {
+		return e.identityProj()
+	}
+
+	var t0, t1, t, tt, w, h GF.Elt
+	t0 = F.Mul(F.Elt(3), P.X) // 3X
+	t1 = F.Add(e.A, e.A)      // 2A
+	t1 = F.Mul(t1, P.Z)       // 2AZ
+	t0 = F.Add(t0, t1)        // 3X+2AZ
+	t0 = F.Mul(t0, P.X)       // (3X+2AZ)X
+	t1 = F.Sqr(P.Z)           // Z^2
+	t1 = F.Mul(t1, e.B)       // BZ^2
+	w = F.Add(t0, t1)         // w = 3X^2+2AXZ+BZ^2
+	t = F.Mul(P.Y, P.Z)       // YZ
+	t = F.Add(t, t)           // t = 2YZ
+	tt = F.Sqr(t)             // t^2
+
+	t0 = F.Mul(e.A, P.Z) // AZ
+	t0 = F.Add(t0, P.X)  // AZ+X
+	t0 = F.Add(t0, P.X)  // AZ+2X
+	t0 = F.Mul(t0, tt)   // t^2(AZ+2X)
+	h = F.Sqr(w)         // w^2
+	h = F.Mul(h, P.Z)    // w^2Z
+	h = F.Sub(h, t0)     // h = w^2Z-t^2(AZ+2X)
+
+	X := F.Mul(t, h)    // X3 = th
+	t0 = F.Mul(tt, P.X) // t^2X
+	t0 = F.Sub(t0, h)   // t^2X-h
+	t0 = F.Mul(t0, w)   // w(t^2X-h)
+	tt = F.Mul(tt, t)   // t^3
+	t1 = F.Mul(tt, P.Y) // t^3Y
+	Y := F.Sub(t0, t1)  // Y3 = w(t^2X-h)-t^3Y
+	Z := F.Mul(tt, P.Z) // Z3 = t^3Z
+	return &prWc{X, Y, Z}
 }
-func (e *wcCurve) Identity() Point                      { return &infPoint{} }
-func (e *wcCurve) IsOnCurve(p Point) bool               { return e.Codomain().IsOnCurve(e.Push(p)) }
-func (e *wcCurve) Add(p, q Point) Point                 { return e.Pull(e.Codomain().Add(e.Push(p), e.Push(q))) }
-func (e *wcCurve) Double(p Point) Point                 { return e.Pull(e.Codomain().Double(e.Push(p))) }
-func (e *wcCurve) Neg(p Point) Point                    { return e.Pull(e.Codomain().Neg(e.Push(p))) }
-func (e *wcCurve) ClearCofactor(p Point) Point          { return e.Pull(e.Codomain().ClearCofactor(e.Push(p))) }
-func (e *wcCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
 
 // ptWc is an affine point on a wcCurve curve.
 type ptWc struct {
@@ -61,8 +293,8 @@ type ptWc struct {
 func (p *ptWc) String() string { return p.afPoint.String() }
 func (p *ptWc) Copy() Point    { return &ptWc{p.wcCurve, p.copy()} }
 func (p *ptWc) IsEqual(q Point) bool {
-	qq := q.(*ptWc)
-	return p.wcCurve.IsEqual(qq.wcCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptWc)
+	return ok && p.wcCurve.IsEqual(qq.wcCurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptWc) IsIdentity() bool   { return false }
 func (p *ptWc) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
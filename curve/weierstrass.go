@@ -29,25 +29,40 @@ func (e *weCurve) NewPoint(x, y GF.Elt) (P Point) {
 	panic(fmt.Errorf("p=%v not on curve", P))
 }
 
-func (e *weCurve) IsValid() bool {
+// Discriminant returns -16(4A^3+27B^2).
+func (e *weCurve) Discriminant() GF.Elt {
 	F := e.F
 	t0 := F.Sqr(e.A)          // A^2
 	t0 = F.Mul(t0, e.A)       // A^3
 	t0 = F.Add(t0, t0)        // 2A^3
 	t0 = F.Add(t0, t0)        // 4A^3
-	t1 := F.Sqr(e.B)          // B^3
+	t1 := F.Sqr(e.B)          // B^2
 	t1 = F.Mul(t1, F.Elt(27)) // 27B^2
 	t0 = F.Add(t0, t1)        // 4A^3+27B^2
 	t0 = F.Add(t0, t0)        // 2(4A^3+27B^2)
 	t0 = F.Add(t0, t0)        // 4(4A^3+27B^2)
 	t0 = F.Add(t0, t0)        // 8(4A^3+27B^2)
 	t0 = F.Add(t0, t0)        // 16(4A^3+27B^2)
-	t0 = F.Neg(t0)            // -16(4A^3+27B^2)
-	return !F.IsZero(t0)      // -16(4A^3+27B^2) != 0
+	return F.Neg(t0)          // -16(4A^3+27B^2)
 }
+
+// JInvariant returns 1728*4A^3/(4A^3+27B^2).
+func (e *weCurve) JInvariant() GF.Elt {
+	F := e.F
+	t0 := F.Sqr(e.A)            // A^2
+	t0 = F.Mul(t0, e.A)         // A^3
+	t0 = F.Mul(t0, F.Elt(4))    // 4A^3
+	t1 := F.Sqr(e.B)            // B^2
+	t1 = F.Mul(t1, F.Elt(27))   // 27B^2
+	t1 = F.Add(t0, t1)          // 4A^3+27B^2
+	t0 = F.Mul(t0, F.Elt(1728)) // 1728*4A^3
+	return F.Mul(t0, F.Inv(t1)) // 1728*4A^3/(4A^3+27B^2)
+}
+
+func (e *weCurve) IsValid() bool { return !e.F.IsZero(e.Discriminant()) }
 func (e *weCurve) IsEqual(ec EllCurve) bool {
-	e0 := ec.(*weCurve)
-	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
+	e0, ok := ec.(*weCurve)
+	return ok && e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
 }
 func (e *weCurve) IsOnCurve(p Point) bool {
 	if _, isZero := p.(*infPoint); isZero {
@@ -152,8 +167,8 @@ type ptWe struct {
 func (p *ptWe) String() string { return p.afPoint.String() }
 func (p *ptWe) Copy() Point    { return &ptWe{p.weCurve, p.copy()} }
 func (p *ptWe) IsEqual(q Point) bool {
-	qq := q.(*ptWe)
-	return p.weCurve.IsEqual(qq.weCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptWe)
+	return ok && p.weCurve.IsEqual(qq.weCurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptWe) IsIdentity() bool   { return false }
 func (p *ptWe) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
@@ -0,0 +1,307 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// geCurve is a Weierstrass curve in general form.
+type geCurve struct {
+	*params
+	a1, a2, a3, a4, a6 GF.Elt
+}
+
+type WG = *geCurve
+
+// NewWeierstrassGeneral returns the curve y^2+a1xy+a3y=x^3+a2x^2+a4x+a6.
+func NewWeierstrassGeneral(name string, f GF.Field, a1, a2, a3, a4, a6 GF.Elt, r, h *big.Int) EllCurve {
+	p := &params{Name: name, F: f, A: a4, B: a6, R: r, H: h}
+	return (&geCurve{params: p, a1: a1, a2: a2, a3: a3, a4: a4, a6: a6}).New()
+}
+
+func (e *geCurve) String() string {
+	return fmt.Sprintf("y^2+a1xy+a3y=x^3+a2x^2+a4x+a6\nName: %v\nF: %v\na1: %v\na2: %v\na3: %v\na4: %v\na6: %v\n",
+		e.Name, e.F, e.a1, e.a2, e.a3, e.a4, e.a6)
+}
+func (e *geCurve) New() EllCurve {
+	if e.IsValid() {
+		return e
+	}
+	panic(errors.New("can't instantiate a WeierstrassGeneral curve"))
+}
+func (e *geCurve) NewPoint(x, y GF.Elt) (P Point) {
+	if P = (&ptGe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+		return P
+	}
+	panic(fmt.Errorf("p=%v not on curve", P))
+}
+
+// bInvariants returns the quantities b2, b4, b6, and b8 of the curve.
+func (e *geCurve) bInvariants() (b2, b4, b6, b8 GF.Elt) {
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(e.a1)           // a1^2
+	t1 = F.Mul(F.Elt(4), e.a2) // 4a2
+	b2 = F.Add(t0, t1)         // b2 = a1^2+4a2
+
+	t0 = F.Add(e.a4, e.a4) // 2a4
+	t1 = F.Mul(e.a1, e.a3) // a1a3
+	b4 = F.Add(t0, t1)     // b4 = 2a4+a1a3
+
+	t0 = F.Sqr(e.a3)           // a3^2
+	t1 = F.Mul(F.Elt(4), e.a6) // 4a6
+	b6 = F.Add(t0, t1)         // b6 = a3^2+4a6
+
+	t0 = F.Mul(b2, e.a6)   // (a1^2+4a2)a6
+	t1 = F.Mul(e.a1, e.a3) // a1a3
+	t1 = F.Mul(t1, e.a4)   // a1a3a4
+	t0 = F.Sub(t0, t1)     // a1^2a6+4a2a6-a1a3a4
+	t1 = F.Sqr(e.a3)       // a3^2
+	t1 = F.Mul(t1, e.a2)   // a2a3^2
+	t0 = F.Add(t0, t1)     // a1^2a6+4a2a6-a1a3a4+a2a3^2
+	t1 = F.Sqr(e.a4)       // a4^2
+	b8 = F.Sub(t0, t1)     // b8 = a1^2a6+4a2a6-a1a3a4+a2a3^2-a4^2
+	return
+}
+
+// cInvariants returns the quantities c4 and c6 of the curve.
+func (e *geCurve) cInvariants() (c4, c6 GF.Elt) {
+	F := e.F
+	b2, b4, b6, _ := e.bInvariants()
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(b2)             // b2^2
+	t1 = F.Mul(F.Elt(24), b4)  // 24b4
+	c4 = F.Sub(t0, t1)         // c4 = b2^2-24b4
+	t0 = F.Mul(F.Elt(36), b4)  // 36b4
+	t1 = F.Sqr(b2)             // b2^2
+	t0 = F.Sub(t0, t1)         // -b2^2+36b4
+	t0 = F.Mul(t0, b2)         // -b2^3+36b2b4
+	t1 = F.Mul(F.Elt(216), b6) // 216b6
+	c6 = F.Sub(t0, t1)         // c6 = -b2^3+36b2b4-216b6
+	return
+}
+
+// Discriminant returns -b2^2b8-8b4^3-27b6^2+9b2b4b6.
+func (e *geCurve) Discriminant() GF.Elt {
+	F := e.F
+	b2, b4, b6, b8 := e.bInvariants()
+	var t0, t1 GF.Elt
+	t0 = F.Sqr(b2)            // b2^2
+	t0 = F.Mul(t0, b8)        // b2^2b8
+	t0 = F.Neg(t0)            // -b2^2b8
+	t1 = F.Sqr(b4)            // b4^2
+	t1 = F.Mul(t1, b4)        // b4^3
+	t1 = F.Mul(F.Elt(8), t1)  // 8b4^3
+	t0 = F.Sub(t0, t1)        // -b2^2b8-8b4^3
+	t1 = F.Sqr(b6)            // b6^2
+	t1 = F.Mul(F.Elt(27), t1) // 27b6^2
+	t0 = F.Sub(t0, t1)        // -b2^2b8-8b4^3-27b6^2
+	t1 = F.Mul(b2, b4)        // b2b4
+	t1 = F.Mul(t1, b6)        // b2b4b6
+	t1 = F.Mul(F.Elt(9), t1)  // 9b2b4b6
+	return F.Add(t0, t1)      // -b2^2b8-8b4^3-27b6^2+9b2b4b6
+}
+
+// JInvariant returns c4^3/Discriminant.
+func (e *geCurve) JInvariant() GF.Elt {
+	F := e.F
+	c4, _ := e.cInvariants()
+	t0 := F.Sqr(c4)                           // c4^2
+	t0 = F.Mul(t0, c4)                        // c4^3
+	return F.Mul(t0, F.Inv(e.Discriminant())) // c4^3/Discriminant
+}
+
+func (e *geCurve) IsValid() bool { return !e.F.IsZero(e.Discriminant()) }
+func (e *geCurve) IsEqual(ec EllCurve) bool {
+	e0, ok := ec.(*geCurve)
+	F := e.F
+	return ok && F.IsEqual(e0.F) &&
+		F.AreEqual(e.a1, e0.a1) && F.AreEqual(e.a2, e0.a2) && F.AreEqual(e.a3, e0.a3) &&
+		F.AreEqual(e.a4, e0.a4) && F.AreEqual(e.a6, e0.a6)
+}
+func (e *geCurve) IsOnCurve(p Point) bool {
+	if _, isZero := p.(*infPoint); isZero {
+		return isZero
+	}
+	P := p.(*ptGe)
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Add(P.x, e.a2) // x+a2
+	t0 = F.Mul(t0, P.x)   // (x+a2)x
+	t0 = F.Add(t0, e.a4)  // (x+a2)x+a4
+	t0 = F.Mul(t0, P.x)   // ((x+a2)x+a4)x
+	t0 = F.Add(t0, e.a6)  // ((x+a2)x+a4)x+a6
+	t1 = F.Mul(e.a1, P.x) // a1x
+	t1 = F.Add(t1, e.a3)  // a1x+a3
+	t1 = F.Add(t1, P.y)   // y+a1x+a3
+	t1 = F.Mul(t1, P.y)   // (y+a1x+a3)y
+	return F.AreEqual(t0, t1)
+}
+func (e *geCurve) Identity() Point { return &infPoint{} }
+func (e *geCurve) Add(p, q Point) Point {
+	if p.IsIdentity() {
+		return q.Copy()
+	} else if q.IsIdentity() {
+		return p.Copy()
+	} else if p.IsEqual(e.Neg(q)) {
+		return e.Identity()
+	} else if p.IsEqual(q) {
+		return e.Double(p)
+	} else {
+		return e.add(p, q)
+	}
+}
+func (e *geCurve) Neg(p Point) Point {
+	if _, isZero := p.(*infPoint); isZero {
+		return e.Identity()
+	}
+	P := p.(*ptGe)
+	F := e.F
+	t0 := F.Mul(e.a1, P.x) // a1x
+	t0 = F.Add(t0, e.a3)   // a1x+a3
+	t0 = F.Add(t0, P.y)    // y+a1x+a3
+	return &ptGe{e, &afPoint{x: P.x.Copy(), y: F.Neg(t0)}}
+}
+
+// line returns the point P+Q, where l is the slope of the line passing
+// through P and Q.
+func (e *geCurve) line(P, Q *ptGe, ll GF.Elt) Point {
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Add(ll, e.a1) // l+a1
+	t1 = F.Mul(t0, ll)   // l^2+a1l
+	t1 = F.Sub(t1, e.a2) // l^2+a1l-a2
+	t1 = F.Sub(t1, P.x)  // l^2+a1l-a2-x1
+	x := F.Sub(t1, Q.x)  // x3 = l^2+a1l-a2-x1-x2
+
+	t1 = F.Mul(ll, P.x)  // lx1
+	t1 = F.Sub(P.y, t1)  // y1-lx1
+	t0 = F.Mul(t0, x)    // (l+a1)x3
+	t0 = F.Add(t0, t1)   // (l+a1)x3+(y1-lx1)
+	t0 = F.Add(t0, e.a3) // (l+a1)x3+(y1-lx1)+a3
+	y := F.Neg(t0)       // y3 = -(l+a1)x3-(y1-lx1)-a3
+
+	return &ptGe{e, &afPoint{x: x, y: y}}
+}
+func (e *geCurve) add(p, q Point) Point {
+	P := p.(*ptGe)
+	Q := q.(*ptGe)
+	F := e.F
+
+	if F.AreEqual(P.x, Q.x) {
+		panic("wrong inputs")
+	}
+
+	var t0, t1, ll GF.Elt
+	t0 = F.Sub(Q.y, P.y) // (y2-y1)
+	t1 = F.Sub(Q.x, P.x) // (x2-x1)
+	t1 = F.Inv(t1)       // 1/(x2-x1)
+	ll = F.Mul(t0, t1)   // l = (y2-y1)/(x2-x1)
+	return e.line(P, Q, ll)
+}
+func (e *geCurve) Double(p Point) Point {
+	if _, ok := p.(*infPoint); ok {
+		return e.Identity()
+	}
+	P := p.(*ptGe)
+	if P.IsTwoTorsion() {
+		return e.Identity()
+	}
+
+	F := e.F
+	var t0, t1, ll GF.Elt
+	t0 = F.Mul(F.Elt(3), P.x)  // 3x
+	t1 = F.Add(e.a2, e.a2)     // 2a2
+	t0 = F.Add(t0, t1)         // 3x+2a2
+	t0 = F.Mul(t0, P.x)        // (3x+2a2)x
+	t0 = F.Add(t0, e.a4)       // 3x^2+2a2x+a4
+	t1 = F.Mul(e.a1, P.y)      // a1y
+	t0 = F.Sub(t0, t1)         // 3x^2+2a2x+a4-a1y
+	t1 = P.twoYPlusA1XPlusA3() // 2y+a1x+a3
+	t1 = F.Inv(t1)             // 1/(2y+a1x+a3)
+	ll = F.Mul(t0, t1)         // l = (3x^2+2a2x+a4-a1y)/(2y+a1x+a3)
+	return e.line(P, P, ll)
+}
+func (e *geCurve) ClearCofactor(p Point) Point          { return e.ScalarMult(p, e.H) }
+func (e *geCurve) ScalarMult(p Point, k *big.Int) Point { return e.params.scalarMult(e, p, k) }
+
+// ToWeierstrass returns a map to the short Weierstrass curve
+// y^2=x^3-c4/48x-c6/864 given by (x,y) -> (x+b2/12, y+(a1x+a3)/2).
+func (e *geCurve) ToWeierstrass() RationalMap {
+	F := e.F
+	b2, _, _, _ := e.bInvariants()
+	c4, c6 := e.cInvariants()
+	A := F.Neg(F.Mul(c4, F.Inv(F.Elt(48))))  // A = -c4/48
+	B := F.Neg(F.Mul(c6, F.Inv(F.Elt(864)))) // B = -c6/864
+	e1 := Weierstrass.New("W from "+e.Name, F, A, B, e.params.R, e.params.H)
+	return &ge2we{E0: e, E1: e1.(*weCurve), b2div12: F.Mul(b2, F.Inv(F.Elt(12))), half: F.Inv(F.Elt(2))}
+}
+
+// ToWeierstrassGeneral returns a map to the curve in general form with
+// a1=a2=a3=0.
+func (e *weCurve) ToWeierstrassGeneral() RationalMap {
+	F := e.F
+	e0 := NewWeierstrassGeneral("WG from "+e.Name, F, F.Zero(), F.Zero(), F.Zero(), e.A, e.B, e.params.R, e.params.H)
+	return Invert(e0.(*geCurve).ToWeierstrass())
+}
+
+type ge2we struct {
+	E0      *geCurve
+	E1      *weCurve
+	b2div12 GF.Elt
+	half    GF.Elt
+}
+
+func (r *ge2we) Domain() EllCurve   { return r.E0 }
+func (r *ge2we) Codomain() EllCurve { return r.E1 }
+func (r *ge2we) Push(p Point) Point {
+	if p.IsIdentity() {
+		return r.E1.Identity()
+	}
+	P := p.(*ptGe)
+	F := r.E0.Field()
+	t0 := F.Mul(r.E0.a1, P.x)  // a1x
+	t0 = F.Add(t0, r.E0.a3)    // a1x+a3
+	t0 = F.Mul(t0, r.half)     // (a1x+a3)/2
+	y := F.Add(P.y, t0)        // y+(a1x+a3)/2
+	x := F.Add(P.x, r.b2div12) // x+b2/12
+	return r.E1.NewPoint(x, y)
+}
+func (r *ge2we) Pull(p Point) Point {
+	if p.IsIdentity() {
+		return r.E0.Identity()
+	}
+	P := p.(*ptWe)
+	F := r.E0.Field()
+	x := F.Sub(P.x, r.b2div12) // x-b2/12
+	t0 := F.Mul(r.E0.a1, x)    // a1x
+	t0 = F.Add(t0, r.E0.a3)    // a1x+a3
+	t0 = F.Mul(t0, r.half)     // (a1x+a3)/2
+	y := F.Sub(P.y, t0)        // y-(a1x+a3)/2
+	return r.E0.NewPoint(x, y)
+}
+
+// ptGe is an affine point on a geCurve curve.
+type ptGe struct {
+	*geCurve
+	*afPoint
+}
+
+func (p *ptGe) String() string { return p.afPoint.String() }
+func (p *ptGe) Copy() Point    { return &ptGe{p.geCurve, p.copy()} }
+func (p *ptGe) IsEqual(q Point) bool {
+	qq, ok := q.(*ptGe)
+	return ok && p.geCurve.IsEqual(qq.geCurve) && p.isEqual(p.F, qq.afPoint)
+}
+func (p *ptGe) IsIdentity() bool   { return false }
+func (p *ptGe) IsTwoTorsion() bool { return p.F.IsZero(p.twoYPlusA1XPlusA3()) }
+func (p *ptGe) twoYPlusA1XPlusA3() GF.Elt {
+	F := p.F
+	t0 := F.Mul(p.a1, p.x) // a1x
+	t0 = F.Add(t0, p.a3)   // a1x+a3
+	t0 = F.Add(t0, p.y)    // y+a1x+a3
+	return F.Add(t0, p.y)  // 2y+a1x+a3
+}
@@ -0,0 +1,198 @@
+// Package ecdh implements elliptic curve Diffie-Hellman key agreement over any
+// curve model, with SEC1 encodings, and the X25519 and X448 functions of
+// RFC 7748.
+package ecdh
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Scheme is ECDH over E, where G is a generator of prime order N. The shared
+// secret is the x-coordinate of [d]Q encoded as in SEC1.
+type Scheme struct {
+	E C.EllCurve
+	G C.Point
+	N *big.Int
+	// Cofactor selects cofactor Diffie-Hellman, where the shared point is
+	// [h][d]Q for the cofactor h of E. Otherwise, public keys must lie in the
+	// subgroup of order N.
+	Cofactor bool
+}
+
+// PublicKey is an ECDH public key Q=[d]G.
+type PublicKey struct{ Q C.Point }
+
+// PrivateKey is an ECDH private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// New returns ECDH over E with generator G of order E.Order().
+func New(E C.EllCurve, G C.Point) (*Scheme, error) {
+	N := E.Order()
+	if N == nil || !N.ProbablyPrime(20) {
+		return nil, errors.New("order of the generator must be prime")
+	}
+	if G.IsIdentity() || !E.IsOnCurve(G) || !E.ScalarMult(G, N).IsIdentity() {
+		return nil, errors.New("generator doesn't have order E.Order()")
+	}
+	return &Scheme{E: E, G: G, N: new(big.Int).Set(N)}, nil
+}
+
+// GenerateKey returns a private key with d sampled uniformly from [1, N-1].
+func (s *Scheme) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	nm1 := new(big.Int).Sub(s.N, big.NewInt(1))
+	d, err := rand.Int(rnd, nm1)
+	if err != nil {
+		return nil, err
+	}
+	return s.NewPrivateKey(d.Add(d, big.NewInt(1)))
+}
+
+// NewPrivateKey returns the private key with scalar d in [1, N-1].
+func (s *Scheme) NewPrivateKey(d *big.Int) (*PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(s.N) >= 0 {
+		return nil, errors.New("private scalar out of range")
+	}
+	return &PrivateKey{PublicKey{s.E.ScalarMult(s.G, d)}, new(big.Int).Set(d)}, nil
+}
+
+// ECDH returns the shared secret of key and the peer's public key. It returns
+// an error if the public key is invalid, or if the shared point is the
+// identity or has small order.
+func (s *Scheme) ECDH(key *PrivateKey, pub *PublicKey) ([]byte, error) {
+	if err := s.validate(pub.Q); err != nil {
+		return nil, err
+	}
+	P := s.E.ScalarMult(pub.Q, key.D)
+	if s.Cofactor {
+		P = s.E.ScalarMult(P, s.E.Cofactor())
+	}
+	if P.IsIdentity() || s.E.ClearCofactor(P).IsIdentity() {
+		return nil, errors.New("shared point has small order")
+	}
+	return s.encodeElt(P.X()), nil
+}
+
+// validate checks that Q is a point of E other than the identity and, unless
+// s.Cofactor is set, that Q has order N.
+func (s *Scheme) validate(Q C.Point) error {
+	if Q == nil || Q.IsIdentity() || !s.E.IsOnCurve(Q) {
+		return errors.New("invalid public key")
+	}
+	if !s.Cofactor && !s.E.ScalarMult(Q, s.N).IsIdentity() {
+		return errors.New("public key is not in the subgroup of order N")
+	}
+	return nil
+}
+
+// MarshalPublicKey returns the SEC1 encoding of a public key: 0x04||x||y, or
+// 0x02||x and 0x03||x if compressed, where the latter is used when y has sign
+// one.
+func (s *Scheme) MarshalPublicKey(pub *PublicKey, compressed bool) []byte {
+	x := s.encodeElt(pub.Q.X())
+	if compressed {
+		return append([]byte{byte(2 + s.E.Field().Sgn0(pub.Q.Y()))}, x...)
+	}
+	return append(append([]byte{4}, x...), s.encodeElt(pub.Q.Y())...)
+}
+
+// ParsePublicKey returns the public key of a SEC1 encoding, which is
+// validated as in ECDH.
+func (s *Scheme) ParsePublicKey(b []byte) (*PublicKey, error) {
+	n := s.eltLen()
+	var Q C.Point
+	var err error
+	switch {
+	case len(b) == 1+n && (b[0] == 2 || b[0] == 3):
+		var x GF.Elt
+		if x, err = s.decodeElt(b[1:]); err == nil {
+			Q, err = C.LiftX(s.E, x, int(b[0]-2))
+		}
+	case len(b) == 1+2*n && b[0] == 4:
+		var x, y GF.Elt
+		if x, err = s.decodeElt(b[1 : 1+n]); err != nil {
+			return nil, err
+		}
+		if y, err = s.decodeElt(b[1+n:]); err != nil {
+			return nil, err
+		}
+		Q, err = newPoint(s.E, x, y)
+	default:
+		err = errors.New("invalid length or prefix of public key")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validate(Q); err != nil {
+		return nil, err
+	}
+	return &PublicKey{Q}, nil
+}
+
+// newPoint returns the point (x,y), or an error if it is not on E.
+func newPoint(E C.EllCurve, x, y GF.Elt) (P C.Point, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			P, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return E.NewPoint(x, y), nil
+}
+
+// eltLen returns the length of encoded field elements, that is, the byte
+// length of the characteristic times the extension degree.
+func (s *Scheme) eltLen() int {
+	F := s.E.Field()
+	return int(F.Ext()) * ((F.P().BitLen() + 7) / 8)
+}
+
+// encodeElt returns the big-endian encoding of the coefficients of x, from
+// the most significant one.
+func (s *Scheme) encodeElt(x GF.Elt) []byte {
+	c := x.Polynomial()
+	n := s.eltLen() / len(c)
+	b := make([]byte, 0, s.eltLen())
+	for i := len(c) - 1; i >= 0; i-- {
+		b = append(b, fillBytes(c[i], n)...)
+	}
+	return b
+}
+
+// decodeElt returns the field element encoded in b, which must be canonical.
+func (s *Scheme) decodeElt(b []byte) (GF.Elt, error) {
+	F := s.E.Field()
+	m := int(F.Ext())
+	n := len(b) / m
+	c := make([]interface{}, m)
+	for i := range c {
+		v := new(big.Int).SetBytes(b[len(b)-(i+1)*n : len(b)-i*n])
+		if v.Cmp(F.P()) >= 0 {
+			return nil, errors.New("coordinate is not a field element")
+		}
+		c[i] = v
+	}
+	if m == 1 {
+		return F.Elt(c[0]), nil
+	}
+	return F.Elt(c), nil
+}
+
+// fillBytes returns x as a big-endian string of n bytes.
+func fillBytes(x *big.Int, n int) []byte {
+	b := make([]byte, n)
+	xb := x.Bytes()
+	copy(b[n-len(xb):], xb)
+	return b
+}
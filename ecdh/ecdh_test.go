@@ -0,0 +1,208 @@
+package ecdh_test
+
+import (
+	"bytes"
+	goecdh "crypto/ecdh"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	"github.com/armfazh/tozan-ecc/ecdh"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+func p256(t *testing.T) *ecdh.Scheme {
+	p := elliptic.P256().Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	s, err := ecdh.New(E, E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCrossP256(t *testing.T) {
+	s := p256(t)
+	goKey, err := goecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := s.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := s.ParsePublicKey(goKey.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.ECDH(key, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goPub, err := goecdh.P256().NewPublicKey(s.MarshalPublicKey(&key.PublicKey, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := goKey.ECDH(goPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got: %x\nwant: %x", got, want)
+	}
+	for _, compressed := range []bool{false, true} {
+		dec, err := s.ParsePublicKey(s.MarshalPublicKey(pub, compressed))
+		if err != nil || !dec.Q.IsEqual(pub.Q) {
+			t.Fatalf("compressed: %v, round trip failed: %v", compressed, err)
+		}
+	}
+	if _, err := s.ParsePublicKey([]byte{0}); err == nil {
+		t.Fatal("identity must be rejected")
+	}
+	bad := s.MarshalPublicKey(&key.PublicKey, false)
+	bad[len(bad)-1] ^= 1
+	if _, err := s.ParsePublicKey(bad); err == nil {
+		t.Fatal("point not on the curve must be rejected")
+	}
+}
+
+func TestCrossX25519(t *testing.T) {
+	x := ecdh.X25519()
+	for i := 0; i < 8; i++ {
+		goKey, err := goecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := x.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := x.PublicKey(goKey.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := goKey.PublicKey().Bytes(); !bytes.Equal(pub, want) {
+			t.Fatalf("got: %x\nwant: %x", pub, want)
+		}
+		got, err := x.ECDH(key, goKey.PublicKey().Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		goPub, err := x.PublicKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := goKey.ECDH(mustPublicKey(t, goPub))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("got: %x\nwant: %x", got, want)
+		}
+	}
+}
+
+func mustPublicKey(t *testing.T, b []byte) *goecdh.PublicKey {
+	pub, err := goecdh.X25519().NewPublicKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub
+}
+
+func TestRFC7748(t *testing.T) {
+	// Section 6 of RFC 7748.
+	for _, v := range []struct {
+		x                               *ecdh.XDH
+		alice, alicePub, bob, bobPub, k string
+	}{
+		{ecdh.X25519(),
+			"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+			"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+			"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+			"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+			"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"},
+		{ecdh.X448(),
+			"9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf5" +
+				"74a9419744897391006382a6f127ab1d9ac2d8c0a598726b",
+			"9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bb" +
+				"c836647241d953d40c5b12da88120d53177f80e532c41fa0",
+			"1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120" +
+				"bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d",
+			"3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972" +
+				"fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609",
+			"07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56" +
+				"fd2464c335543936521c24403085d59a449a5037514a879d"},
+	} {
+		alice, bob := mustHex(v.alice), mustHex(v.bob)
+		for _, w := range []struct{ key, pub string }{{v.alice, v.alicePub}, {v.bob, v.bobPub}} {
+			got, err := v.x.PublicKey(mustHex(w.key))
+			if err != nil || hex.EncodeToString(got) != w.pub {
+				t.Fatalf("%v: got: %x\nwant: %v", v.x, got, w.pub)
+			}
+		}
+		k0, err0 := v.x.ECDH(alice, mustHex(v.bobPub))
+		k1, err1 := v.x.ECDH(bob, mustHex(v.alicePub))
+		if err0 != nil || err1 != nil || hex.EncodeToString(k0) != v.k || !bytes.Equal(k0, k1) {
+			t.Fatalf("%v: got: %x, %x\nwant: %v", v.x, k0, k1, v.k)
+		}
+		// u=0 and u=1 have small order.
+		for _, u := range []byte{0, 1} {
+			pub := make([]byte, len(alice))
+			pub[0] = u
+			if _, err := v.x.ECDH(alice, pub); err == nil {
+				t.Fatalf("%v: small-order point u=%v must be rejected", v.x, u)
+			}
+		}
+	}
+}
+
+func TestSmallOrder(t *testing.T) {
+	for _, id := range []toy.ID{toy.M0, toy.E0} {
+		E, g, _ := id.New()
+		s, err := ecdh.New(E, E.ClearCofactor(g))
+		if err != nil {
+			t.Fatal(err)
+		}
+		T, err := C.PointOfOrder(E, E.Cofactor(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		alice, _ := s.GenerateKey(rand.Reader)
+		bob, _ := s.GenerateKey(rand.Reader)
+		for _, cofactor := range []bool{false, true} {
+			s.Cofactor = cofactor
+			k0, err0 := s.ECDH(alice, &bob.PublicKey)
+			k1, err1 := s.ECDH(bob, &alice.PublicKey)
+			if err0 != nil || err1 != nil || !bytes.Equal(k0, k1) {
+				t.Fatalf("%v: shared secrets differ: %x, %x", id, k0, k1)
+			}
+			if _, err := s.ECDH(alice, &ecdh.PublicKey{Q: T}); err == nil {
+				t.Fatalf("%v: small-order public key must be rejected", id)
+			}
+			// Q+T is only rejected without cofactor multiplication, and
+			// gives the same secret as Q with it.
+			mixed := &ecdh.PublicKey{Q: E.Add(bob.Q, T)}
+			k2, err := s.ECDH(alice, mixed)
+			if cofactor && (err != nil || !bytes.Equal(k0, k2)) {
+				t.Fatalf("%v: got: %x\nwant: %x", id, k2, k0)
+			}
+			if !cofactor && err == nil {
+				t.Fatalf("%v: public key outside the subgroup must be rejected", id)
+			}
+		}
+	}
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package ecdh
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Curve25519 returns the Montgomery curve y^2=x^3+486662x^2+x over
+// GF(2^255-19), and its base point of prime order.
+func Curve25519() (C.EllCurve, C.Point) {
+	F := GF.NewFp("2^255-19", "0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed")
+	N, _ := new(big.Int).SetString("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 0)
+	E := C.Montgomery.New("curve25519", F, F.Elt(486662), F.One(), N, big.NewInt(8))
+	G := E.NewPoint(
+		F.Elt(9),
+		F.Elt("0x20ae19a1b8a086b4e01edd2c7748d14c923d4d7e6d7c61b229e9c5a27eced3d9"))
+	return E, G
+}
+
+// Curve448 returns the Montgomery curve y^2=x^3+156326x^2+x over
+// GF(2^448-2^224-1), and its base point of prime order.
+func Curve448() (C.EllCurve, C.Point) {
+	F := GF.NewFp("2^448-2^224-1", "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	N, _ := new(big.Int).SetString("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3", 0)
+	E := C.Montgomery.New("curve448", F, F.Elt(156326), F.One(), N, big.NewInt(4))
+	G, err := C.LiftX(E, F.Elt(5), 0)
+	if err != nil {
+		panic(err)
+	}
+	return E, G
+}
+
+// XDH is a Diffie-Hellman function of RFC 7748, which uses only the
+// u-coordinate of points on a Montgomery curve. Keys and shared secrets are
+// little-endian strings.
+type XDH struct {
+	E     C.EllCurve
+	U     GF.Elt // u-coordinate of the base point
+	name  string
+	size  int
+	clamp func(k []byte)
+}
+
+// X25519 returns the X25519 function.
+func X25519() *XDH {
+	E, _ := Curve25519()
+	return &XDH{E: E, U: E.Field().Elt(9), name: "X25519", size: 32,
+		clamp: func(k []byte) {
+			k[0] &= 248
+			k[31] &= 127
+			k[31] |= 64
+		},
+	}
+}
+
+// X448 returns the X448 function.
+func X448() *XDH {
+	E, _ := Curve448()
+	return &XDH{E: E, U: E.Field().Elt(5), name: "X448", size: 56,
+		clamp: func(k []byte) {
+			k[0] &= 252
+			k[55] |= 128
+		},
+	}
+}
+
+func (x *XDH) String() string { return x.name }
+
+// GenerateKey returns a random private key.
+func (x *XDH) GenerateKey(rnd io.Reader) ([]byte, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	k := make([]byte, x.size)
+	if _, err := io.ReadFull(rnd, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// PublicKey returns the public key of a private key.
+func (x *XDH) PublicKey(key []byte) ([]byte, error) {
+	return x.ECDH(key, x.encode(x.U))
+}
+
+// ECDH returns the shared secret of a private key and the peer's public key.
+// It returns an error if the shared secret is all zeros, which happens when
+// the public key has small order.
+func (x *XDH) ECDH(key, pub []byte) ([]byte, error) {
+	if len(key) != x.size || len(pub) != x.size {
+		return nil, errors.New("wrong length of key")
+	}
+	k := append([]byte{}, key...)
+	x.clamp(k)
+	u := x.E.Field().Elt(leToInt(pub, x.E.Field().P().BitLen()))
+	out := x.encode(x.ladder(leToInt(k, 8*x.size), u))
+	zero := byte(0)
+	for _, b := range out {
+		zero |= b
+	}
+	if zero == 0 {
+		return nil, errors.New("shared secret is zero")
+	}
+	return out, nil
+}
+
+// ladder returns the u-coordinate of [k]P, where P has u-coordinate u, using
+// the Montgomery ladder of RFC 7748.
+func (x *XDH) ladder(k *big.Int, u GF.Elt) GF.Elt {
+	F := x.E.Field()
+	a24 := F.Mul(F.Sub(x.E.(C.M).A, F.Elt(2)), F.Inv(F.Elt(4))) // (A-2)/4
+	x2, z2 := F.One(), F.Zero()
+	x3, z3 := u, F.One()
+	swap := uint(0)
+	for t := k.BitLen() - 1; t >= 0; t-- {
+		kt := k.Bit(t)
+		swap ^= kt
+		x2, x3 = F.CMov(x2, x3, swap == 1), F.CMov(x3, x2, swap == 1)
+		z2, z3 = F.CMov(z2, z3, swap == 1), F.CMov(z3, z2, swap == 1)
+		swap = kt
+
+		A := F.Add(x2, z2)
+		AA := F.Sqr(A)
+		B := F.Sub(x2, z2)
+		BB := F.Sqr(B)
+		E := F.Sub(AA, BB)
+		D := F.Sub(x3, z3)
+		CC := F.Add(x3, z3)
+		DA := F.Mul(D, A)
+		CB := F.Mul(CC, B)
+		x3 = F.Sqr(F.Add(DA, CB))
+		z3 = F.Mul(u, F.Sqr(F.Sub(DA, CB)))
+		x2 = F.Mul(AA, BB)
+		z2 = F.Mul(E, F.Add(AA, F.Mul(a24, E)))
+	}
+	x2, z2 = F.CMov(x2, x3, swap == 1), F.CMov(z2, z3, swap == 1)
+	return F.Mul(x2, F.Inv0(z2))
+}
+
+// encode returns the little-endian encoding of u.
+func (x *XDH) encode(u GF.Elt) []byte {
+	b := fillBytes(u.Polynomial()[0], x.size)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+// leToInt returns the little-endian integer of b reduced modulo 2^bits.
+func leToInt(b []byte, bits int) *big.Int {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	n := new(big.Int).SetBytes(r)
+	return n.Mod(n, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+}
@@ -0,0 +1,177 @@
+// Package ecdsa implements the Elliptic Curve Digital Signature Algorithm
+// over short Weierstrass curves, with deterministic nonces as in RFC 6979.
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// Scheme is ECDSA over a short Weierstrass curve E defined over a prime field,
+// where G is a generator of prime order N.
+type Scheme struct {
+	E    C.EllCurve
+	G    C.Point
+	N    *big.Int
+	Hash crypto.Hash
+	// LowS makes Sign return s <= N/2, and Verify reject signatures with
+	// s > N/2.
+	LowS bool
+}
+
+// PublicKey is an ECDSA public key Q=[d]G.
+type PublicKey struct{ Q C.Point }
+
+// PrivateKey is an ECDSA private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// New returns ECDSA over E with generator G of order E.Order(). It returns an
+// error if E is not a short Weierstrass curve over a prime field, if its order
+// is not prime, or if G doesn't have that order.
+//
+// The scheme accepts both s and N-s; set LowS on the returned scheme to sign
+// and accept only s <= N/2, which prevents signature malleability.
+func New(E C.EllCurve, G C.Point, h crypto.Hash) (*Scheme, error) {
+	if _, ok := E.(C.W); !ok {
+		return nil, errors.New("ECDSA requires a short Weierstrass curve")
+	}
+	if E.Field().Ext() != 1 {
+		return nil, errors.New("ECDSA requires a curve over a prime field")
+	}
+	N := E.Order()
+	if N == nil || !N.ProbablyPrime(20) {
+		return nil, errors.New("order of the generator must be prime")
+	}
+	if G.IsIdentity() || !E.IsOnCurve(G) || !E.ScalarMult(G, N).IsIdentity() {
+		return nil, errors.New("generator doesn't have order E.Order()")
+	}
+	if !h.Available() {
+		return nil, errors.New("hash function is not available")
+	}
+	return &Scheme{E: E, G: G, N: new(big.Int).Set(N), Hash: h}, nil
+}
+
+// GenerateKey returns a private key with d sampled uniformly from [1, N-1].
+func (s *Scheme) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	nm1 := new(big.Int).Sub(s.N, big.NewInt(1))
+	d, err := rand.Int(rnd, nm1)
+	if err != nil {
+		return nil, err
+	}
+	return s.NewPrivateKey(d.Add(d, big.NewInt(1)))
+}
+
+// NewPrivateKey returns the private key with scalar d in [1, N-1].
+func (s *Scheme) NewPrivateKey(d *big.Int) (*PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(s.N) >= 0 {
+		return nil, errors.New("private scalar out of range")
+	}
+	return &PrivateKey{PublicKey{s.E.ScalarMult(s.G, d)}, new(big.Int).Set(d)}, nil
+}
+
+// Signature is an ECDSA signature (r,s).
+type Signature struct{ R, S *big.Int }
+
+// Sign returns a signature of msg, which is hashed with s.Hash. The nonce is
+// derived deterministically from the key and the hash as in RFC 6979.
+func (s *Scheme) Sign(key *PrivateKey, msg []byte) (*Signature, error) {
+	return s.SignDigest(key, s.digest(msg))
+}
+
+// SignDigest returns a signature of a digest computed with s.Hash.
+func (s *Scheme) SignDigest(key *PrivateKey, digest []byte) (*Signature, error) {
+	e := s.hashToInt(digest)
+	nonces := s.newNonces(key.D, digest)
+	for {
+		k := nonces.next()
+		R := s.E.ScalarMult(s.G, k)
+		r := s.toInt(R)
+		if r.Sign() == 0 {
+			continue
+		}
+		// sig = (e+rd)/k mod N
+		sig := new(big.Int).Mul(r, key.D)
+		sig.Add(sig, e)
+		sig.Mul(sig, new(big.Int).ModInverse(k, s.N))
+		sig.Mod(sig, s.N)
+		if sig.Sign() == 0 {
+			continue
+		}
+		if s.LowS && sig.Cmp(s.halfN()) > 0 {
+			sig.Sub(s.N, sig)
+		}
+		return &Signature{r, sig}, nil
+	}
+}
+
+// Nonce returns the first nonce of RFC 6979 for the key and a digest computed
+// with s.Hash, which is also used by other deterministic protocols.
+func (s *Scheme) Nonce(key *PrivateKey, digest []byte) *big.Int {
+	return s.newNonces(key.D, digest).next()
+}
+
+// Verify returns true if sig is a valid signature of msg under key.
+func (s *Scheme) Verify(key *PublicKey, msg []byte, sig *Signature) bool {
+	return s.VerifyDigest(key, s.digest(msg), sig)
+}
+
+// VerifyDigest returns true if sig is a valid signature of a digest computed
+// with s.Hash.
+func (s *Scheme) VerifyDigest(key *PublicKey, digest []byte, sig *Signature) bool {
+	if sig == nil || !s.inRange(sig.R) || !s.inRange(sig.S) {
+		return false
+	}
+	if s.LowS && sig.S.Cmp(s.halfN()) > 0 {
+		return false
+	}
+	if key.Q.IsIdentity() || !s.E.IsOnCurve(key.Q) {
+		return false
+	}
+	// R = [e/s]G+[r/s]Q
+	w := new(big.Int).ModInverse(sig.S, s.N)
+	u1 := new(big.Int).Mul(s.hashToInt(digest), w)
+	u2 := new(big.Int).Mul(sig.R, w)
+	u1.Mod(u1, s.N)
+	u2.Mod(u2, s.N)
+	R := s.E.Add(s.E.ScalarMult(s.G, u1), s.E.ScalarMult(key.Q, u2))
+	if R.IsIdentity() {
+		return false
+	}
+	return s.toInt(R).Cmp(sig.R) == 0
+}
+
+func (s *Scheme) digest(msg []byte) []byte {
+	h := s.Hash.New()
+	_, _ = h.Write(msg)
+	return h.Sum(nil)
+}
+
+// hashToInt returns the leftmost bits of the digest as an integer, as many
+// as the bit length of N, which is bits2int in RFC 6979.
+func (s *Scheme) hashToInt(digest []byte) *big.Int {
+	e := new(big.Int).SetBytes(digest)
+	if n := 8*len(digest) - s.N.BitLen(); n > 0 {
+		e.Rsh(e, uint(n))
+	}
+	return e
+}
+
+// toInt returns the x-coordinate of P reduced modulo N.
+func (s *Scheme) toInt(P C.Point) *big.Int {
+	x := P.X().Polynomial()[0]
+	return x.Mod(x, s.N)
+}
+
+func (s *Scheme) inRange(x *big.Int) bool { return x != nil && x.Sign() > 0 && x.Cmp(s.N) < 0 }
+func (s *Scheme) halfN() *big.Int         { return new(big.Int).Rsh(s.N, 1) }
@@ -0,0 +1,199 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	goecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	"github.com/armfazh/tozan-ecc/ecdsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// nist returns ECDSA over a NIST curve, which has A=-3.
+func nist(t *testing.T, c elliptic.Curve, h crypto.Hash) *ecdsa.Scheme {
+	p := c.Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	s, err := ecdsa.New(E, E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy)), h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func hexInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+func TestRFC6979(t *testing.T) {
+	// Appendix A.2.5 of RFC 6979.
+	s := nist(t, elliptic.P256(), crypto.SHA256)
+	key, err := s.NewPrivateKey(hexInt("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct{ msg, r, s string }{
+		{"sample",
+			"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+			"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8"},
+		{"test",
+			"F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+			"019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083"},
+	} {
+		sig, err := s.Sign(key, []byte(v.msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sig.R.Cmp(hexInt(v.r)) != 0 || sig.S.Cmp(hexInt(v.s)) != 0 {
+			t.Fatalf("got: (%x, %x)\nwant: (%v, %v)", sig.R, sig.S, v.r, v.s)
+		}
+		if !s.Verify(&key.PublicKey, []byte(v.msg), sig) {
+			t.Fatal("signature must be valid")
+		}
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	for _, v := range []struct {
+		c elliptic.Curve
+		h crypto.Hash
+	}{
+		{elliptic.P256(), crypto.SHA256},
+		{elliptic.P384(), crypto.SHA384},
+		{elliptic.P521(), crypto.SHA512},
+	} {
+		s := nist(t, v.c, v.h)
+		msg := []byte("cross-check with crypto/ecdsa")
+		digest := v.h.New()
+		_, _ = digest.Write(msg)
+		hash := digest.Sum(nil)
+
+		// Signed here, verified by crypto/ecdsa.
+		key, err := s.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := s.Sign(key, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := sig.MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub := &goecdsa.PublicKey{Curve: v.c,
+			X: key.Q.X().Polynomial()[0], Y: key.Q.Y().Polynomial()[0]}
+		if !goecdsa.VerifyASN1(pub, hash, der) {
+			t.Fatalf("%v: crypto/ecdsa rejected the signature", v.c.Params().Name)
+		}
+
+		// Signed by crypto/ecdsa, verified here.
+		goKey, err := goecdsa.GenerateKey(v.c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err = goecdsa.SignASN1(rand.Reader, goKey, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err = ecdsa.ParseDER(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err = s.NewPrivateKey(goKey.D)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.Verify(&key.PublicKey, msg, sig) {
+			t.Fatalf("%v: crypto/ecdsa signature rejected", v.c.Params().Name)
+		}
+		sig.S.Add(sig.S, big.NewInt(1))
+		if s.Verify(&key.PublicKey, msg, sig) {
+			t.Fatalf("%v: modified signature accepted", v.c.Params().Name)
+		}
+	}
+}
+
+func TestLowS(t *testing.T) {
+	s := nist(t, elliptic.P256(), crypto.SHA256)
+	key, err := s.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	half := new(big.Int).Rsh(s.N, 1)
+	for i := 0; i < 8; i++ {
+		msg := []byte{byte(i)}
+		s.LowS = false
+		sig, _ := s.Sign(key, msg)
+		s.LowS = true
+		low, _ := s.Sign(key, msg)
+		if low.S.Cmp(half) > 0 || !s.Verify(&key.PublicKey, msg, low) {
+			t.Fatal("wrong low-S signature")
+		}
+		// Both (r,s) and (r,N-s) are valid, but only one is low.
+		if high := sig.S.Cmp(half) > 0; high == s.Verify(&key.PublicKey, msg, sig) {
+			t.Fatalf("high-S signature: %v, accepted: %v", high, !high)
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	E, g, _ := toy.W0.New()
+	s, err := ecdsa.New(E, E.ClearCofactor(g), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := s.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 16; i++ {
+		msg := []byte{byte(i)}
+		sig, err := s.Sign(key, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.Verify(&key.PublicKey, msg, sig) {
+			t.Fatal("signature must be valid")
+		}
+		b, err := s.MarshalRaw(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := s.ParseRaw(b)
+		if err != nil || raw.R.Cmp(sig.R) != 0 || raw.S.Cmp(sig.S) != 0 {
+			t.Fatalf("got: %v\nwant: %v", raw, sig)
+		}
+		der, err := sig.MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ecdsa.ParseDER(append(der, 0)); err == nil {
+			t.Fatal("trailing data must be rejected")
+		}
+		got, err := ecdsa.ParseDER(der)
+		if err != nil || got.R.Cmp(sig.R) != 0 || got.S.Cmp(sig.S) != 0 {
+			t.Fatalf("got: %v\nwant: %v", got, sig)
+		}
+	}
+	for _, sig := range []*ecdsa.Signature{
+		{R: big.NewInt(0), S: big.NewInt(1)},
+		{R: big.NewInt(1), S: s.N},
+		{R: new(big.Int).Lsh(s.N, 8), S: big.NewInt(1)},
+	} {
+		if _, err := s.MarshalRaw(sig); err == nil {
+			t.Fatalf("%v must be rejected", sig)
+		}
+	}
+	if _, err := ecdsa.New(E, g, crypto.SHA256); err == nil {
+		t.Fatal("generator of composite order must be rejected")
+	}
+}
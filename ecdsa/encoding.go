@@ -0,0 +1,57 @@
+package ecdsa
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+// MarshalDER returns the DER encoding of the ASN.1 sequence of r and s.
+func (sig *Signature) MarshalDER() ([]byte, error) {
+	return asn1.Marshal(*sig)
+}
+
+// ParseDER parses a DER-encoded signature; non-canonical encodings and
+// trailing data are rejected.
+func ParseDER(b []byte) (*Signature, error) {
+	sig := new(Signature)
+	rest, err := asn1.Unmarshal(b, sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after signature")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, errors.New("signature values must be positive")
+	}
+	if der, err := sig.MarshalDER(); err != nil || !bytes.Equal(der, b) {
+		return nil, errors.New("signature is not DER-encoded")
+	}
+	return sig, nil
+}
+
+// MarshalRaw returns r||s, each as a big-endian string of the byte length of
+// N. It returns an error if r or s is not in [1, N-1].
+func (s *Scheme) MarshalRaw(sig *Signature) ([]byte, error) {
+	if !s.inRange(sig.R) || !s.inRange(sig.S) {
+		return nil, errors.New("signature values out of range")
+	}
+	n := s.scalarLen()
+	b := make([]byte, 2*n)
+	fillBytes(sig.R, b[:n])
+	fillBytes(sig.S, b[n:])
+	return b, nil
+}
+
+// ParseRaw parses a signature encoded as r||s.
+func (s *Scheme) ParseRaw(b []byte) (*Signature, error) {
+	n := s.scalarLen()
+	if len(b) != 2*n {
+		return nil, errors.New("wrong length of signature")
+	}
+	return &Signature{new(big.Int).SetBytes(b[:n]), new(big.Int).SetBytes(b[n:])}, nil
+}
+
+func (s *Scheme) scalarLen() int { return (s.N.BitLen() + 7) / 8 }
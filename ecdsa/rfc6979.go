@@ -0,0 +1,77 @@
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+// nonces generates the sequence of candidate nonces of Section 3.2 of
+// RFC 6979.
+type nonces struct {
+	s    *Scheme
+	k, v []byte
+	mac  func(key []byte) hash.Hash
+	more bool
+}
+
+// newNonces initializes the HMAC_DRBG with the private scalar x and the hash
+// h1 of the message.
+func (s *Scheme) newNonces(x *big.Int, h1 []byte) *nonces {
+	n := &nonces{s: s, mac: func(key []byte) hash.Hash { return hmac.New(s.Hash.New, key) }}
+	hlen := s.Hash.Size()
+	n.v = make([]byte, hlen)
+	n.k = make([]byte, hlen)
+	for i := range n.v {
+		n.v[i] = 0x01
+	}
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q)
+	z := s.hashToInt(h1)
+	z.Mod(z, s.N)
+	seed := append(s.int2octets(x), s.int2octets(z)...)
+	for _, b := range []byte{0x00, 0x01} {
+		n.k = n.hmac(n.k, n.v, []byte{b}, seed)
+		n.v = n.hmac(n.k, n.v)
+	}
+	return n
+}
+
+// next returns the next nonce in [1, N-1].
+func (n *nonces) next() *big.Int {
+	s := n.s
+	for {
+		if n.more {
+			n.k = n.hmac(n.k, n.v, []byte{0x00})
+			n.v = n.hmac(n.k, n.v)
+		}
+		n.more = true
+		var t []byte
+		for 8*len(t) < s.N.BitLen() {
+			n.v = n.hmac(n.k, n.v)
+			t = append(t, n.v...)
+		}
+		if k := s.hashToInt(t); k.Sign() > 0 && k.Cmp(s.N) < 0 {
+			return k
+		}
+	}
+}
+
+func (n *nonces) hmac(key []byte, data ...[]byte) []byte {
+	h := n.mac(key)
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// int2octets returns x as a big-endian string of ceil(qlen/8) bytes.
+func (s *Scheme) int2octets(x *big.Int) []byte {
+	return fillBytes(x, make([]byte, s.scalarLen()))
+}
+
+// fillBytes writes x into b as a big-endian number padded with zeros.
+func fillBytes(x *big.Int, b []byte) []byte {
+	xb := x.Bytes()
+	copy(b[len(b)-len(xb):], xb)
+	return b
+}
@@ -0,0 +1,197 @@
+// Package ecvrf implements the elliptic curve verifiable random functions of
+// RFC 9381.
+package ecvrf
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// Suite is an ECVRF ciphersuite.
+type Suite struct {
+	E C.EllCurve
+	B C.Point
+	Q *big.Int // prime order of B
+
+	name              string
+	id                byte // suite_string
+	hash              crypto.Hash
+	cLen, qLen, ptLen int
+	littleEndian      bool
+
+	encodeToCurve func(salt, alpha []byte) C.Point
+	pointToString func(P C.Point) []byte
+	stringToPoint func(b []byte) (C.Point, error)
+	// secret expands a private key string into the secret scalar and the
+	// state used by nonce.
+	secret func(sk []byte) (x *big.Int, state []byte, err error)
+	nonce  func(key *PrivateKey, h []byte) *big.Int
+}
+
+// PublicKey is the encoding of Y=[x]B.
+type PublicKey []byte
+
+// PrivateKey is an ECVRF private key.
+type PrivateKey struct {
+	x      *big.Int
+	state  []byte
+	public PublicKey
+}
+
+// Public returns the public key.
+func (k *PrivateKey) Public() PublicKey { return append(PublicKey{}, k.public...) }
+
+func (s *Suite) String() string { return s.name }
+
+// GenerateKey returns a random private key.
+func (s *Suite) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	sk := make([]byte, s.qLen)
+	for {
+		if _, err := io.ReadFull(rnd, sk); err != nil {
+			return nil, err
+		}
+		if key, err := s.NewPrivateKey(sk); err == nil {
+			return key, nil
+		}
+	}
+}
+
+// NewPrivateKey returns the private key of a secret key string, which is a
+// big-endian scalar for the NIST suites, and a seed as in RFC 8032 for the
+// Edwards suites.
+func (s *Suite) NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != s.qLen {
+		return nil, errors.New("wrong length of private key")
+	}
+	x, state, err := s.secret(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{
+		x:      x,
+		state:  state,
+		public: s.pointToString(s.E.ScalarMult(s.B, x)),
+	}, nil
+}
+
+// ProofLen returns the length of proofs.
+func (s *Suite) ProofLen() int { return s.ptLen + s.cLen + s.qLen }
+
+// Prove returns the proof pi of the input alpha, from which the output beta
+// is obtained with ProofToHash.
+func (s *Suite) Prove(key *PrivateKey, alpha []byte) []byte {
+	E := s.E
+	H := s.encodeToCurve(key.public, alpha)
+	Gamma := E.ScalarMult(H, key.x)
+	k := s.nonce(key, s.pointToString(H))
+	c := s.challenge(E.ScalarMult(s.B, key.x), H, Gamma, E.ScalarMult(s.B, k), E.ScalarMult(H, k))
+	// s = k+cx mod q
+	sc := new(big.Int).Mul(c, key.x)
+	sc.Add(sc, k).Mod(sc, s.Q)
+	pi := s.pointToString(Gamma)
+	pi = append(pi, s.intToString(c, s.cLen)...)
+	return append(pi, s.intToString(sc, s.qLen)...)
+}
+
+// ProofToHash returns the output beta of a proof. It doesn't verify the
+// proof, so beta must not be used before Verify accepts pi.
+func (s *Suite) ProofToHash(pi []byte) ([]byte, error) {
+	Gamma, _, _, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return s.proofToHash(Gamma), nil
+}
+
+func (s *Suite) proofToHash(Gamma C.Point) []byte {
+	P := s.E.ScalarMult(Gamma, s.E.Cofactor())
+	return s.digest([]byte{s.id, 0x03}, s.pointToString(P), []byte{0x00})
+}
+
+// Verify checks that pi is a valid proof of alpha under the public key, and
+// returns the output beta. The public key is validated, as required for full
+// uniqueness and collision resistance.
+func (s *Suite) Verify(pub PublicKey, alpha, pi []byte) ([]byte, bool) {
+	E := s.E
+	Y, err := s.stringToPoint(pub)
+	if err != nil || E.ScalarMult(Y, E.Cofactor()).IsIdentity() {
+		return nil, false
+	}
+	Gamma, c, sc, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, false
+	}
+	H := s.encodeToCurve(pub, alpha)
+	// U = [s]B-[c]Y and V = [s]H-[c]Gamma
+	U := E.Add(E.ScalarMult(s.B, sc), E.Neg(E.ScalarMult(Y, c)))
+	V := E.Add(E.ScalarMult(H, sc), E.Neg(E.ScalarMult(Gamma, c)))
+	if s.challenge(Y, H, Gamma, U, V).Cmp(c) != 0 {
+		return nil, false
+	}
+	return s.proofToHash(Gamma), true
+}
+
+func (s *Suite) decodeProof(pi []byte) (Gamma C.Point, c, sc *big.Int, err error) {
+	if len(pi) != s.ProofLen() {
+		return nil, nil, nil, errors.New("wrong length of proof")
+	}
+	if Gamma, err = s.stringToPoint(pi[:s.ptLen]); err != nil {
+		return nil, nil, nil, err
+	}
+	c = s.stringToInt(pi[s.ptLen : s.ptLen+s.cLen])
+	sc = s.stringToInt(pi[s.ptLen+s.cLen:])
+	if sc.Cmp(s.Q) >= 0 {
+		return nil, nil, nil, errors.New("scalar out of range")
+	}
+	return Gamma, c, sc, nil
+}
+
+// challenge returns the truncated hash of the points.
+func (s *Suite) challenge(points ...C.Point) *big.Int {
+	data := [][]byte{{s.id, 0x02}}
+	for _, P := range points {
+		data = append(data, s.pointToString(P))
+	}
+	data = append(data, []byte{0x00})
+	return s.stringToInt(s.digest(data...)[:s.cLen])
+}
+
+func (s *Suite) digest(data ...[]byte) []byte {
+	h := s.hash.New()
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func (s *Suite) intToString(x *big.Int, n int) []byte {
+	b := make([]byte, n)
+	xb := x.Bytes()
+	copy(b[n-len(xb):], xb)
+	if s.littleEndian {
+		reverse(b)
+	}
+	return b
+}
+
+func (s *Suite) stringToInt(b []byte) *big.Int {
+	if s.littleEndian {
+		b = append([]byte{}, b...)
+		reverse(b)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,134 @@
+package ecvrf_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/ecvrf"
+)
+
+func TestVectors(t *testing.T) {
+	// Appendix B of RFC 9381. The P-256 keys are those of Appendix A.2.5 of
+	// RFC 6979 and of Appendix L.4.2 of ANSI X9.62-2005.
+	p256SK := "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"
+	p256PK := "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6"
+	ansiSK := "2ca1411a41b17b24cc8c3b089cfd033f1920202a6c0de8abb97df1498d50d2c8"
+	ansiPK := "03596375e6ce57e0f20294fc46bdfcfd19a39f8161b58695b3ec5b3d16427c274d"
+	ansiAlpha := "4578616d706c65207573696e67204543445341206b65792066726f6d20417070" +
+		"656e646978204c2e342e32206f6620414e53492e58392d36322d32303035"
+	for _, v := range []struct {
+		s                       *ecvrf.Suite
+		sk, pk, alpha, pi, beta string
+	}{
+		{ecvrf.P256SHA256TAI(), p256SK, p256PK, "73616d706c65",
+			"035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f",
+			"a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e"},
+		{ecvrf.P256SHA256TAI(), p256SK, p256PK, "74657374",
+			"034dac60aba508ba0c01aa9be80377ebd7562c4a52d74722e0abae7dc3080ddb56c19e067b15a8a8174905b13617804534214f935b94c2287f797e393eb0816969d864f37625b443f30f1a5a33f2b3c854",
+			"a284f94ceec2ff4b3794629da7cbafa49121972671b466cab4ce170aa365f26d"},
+		{ecvrf.P256SHA256TAI(), ansiSK, ansiPK, ansiAlpha,
+			"03d03398bf53aa23831d7d1b2937e005fb0062cbefa06796579f2a1fc7e7b8c667d091c00b0f5c3619d10ecea44363b5a599cadc5b2957e223fec62e81f7b4825fc799a771a3d7334b9186bdbee87316b1",
+			"90871e06da5caa39a3c61578ebb844de8635e27ac0b13e829997d0d95dd98c19"},
+		{ecvrf.P256SHA256SSWU(), p256SK, p256PK, "73616d706c65",
+			"0331d984ca8fece9cbb9a144c0d53df3c4c7a33080c1e02ddb1a96a365394c7888782fffde7b842c38c20c08de6ec6c2e7027a97000f2c9fa4425d5c03e639fb48fde58114d755985498d7eb234cf4aed9",
+			"21e66dc9747430f17ed9efeda054cf4a264b097b9e8956a1787526ed00dc664b"},
+		{ecvrf.P256SHA256SSWU(), p256SK, p256PK, "74657374",
+			"03f814c0455d32dbc75ad3aea08c7e2db31748e12802db23640203aebf1fa8db2743aad348a3006dc1caad7da28687320740bf7dd78fe13c298867321ce3b36b79ec3093b7083ac5e4daf3465f9f43c627",
+			"8e7185d2b420e4f4681f44ce313a26d05613323837da09a69f00491a83ad25dd"},
+		{ecvrf.P256SHA256SSWU(), ansiSK, ansiPK, ansiAlpha,
+			"039f8d9cdc162c89be2871cbcb1435144739431db7fab437ab7bc4e2651a9e99d5488405a11a6c7fc8defddd9e1573a563b7333aab4effe73ae9803274174c659269fd39b53e133dcd9e0d24f01288de9a",
+			"4fbadf33b42a5f42f23a6f89952d2e634a6e3810f15878b46ef1bb85a04fe95a"},
+		{ecvrf.Edwards25519SHA512ELL2(),
+			"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", "",
+			"7d9c633ffeee27349264cf5c667579fc583b4bda63ab71d001f89c10003ab46f14adf9a3cd8b8412d9038531e865c341cafa73589b023d14311c331a9ad15ff2fb37831e00f0acaa6d73bc9997b06501",
+			"9d574bf9b8302ec0fc1e21c3ec5368269527b87b462ce36dab2d14ccf80c53cccf6758f058c5b1c856b116388152bbe509ee3b9ecfe63d93c3b4346c1fbc6c54"},
+		{ecvrf.Edwards25519SHA512ELL2(),
+			"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c", "72",
+			"47b327393ff2dd81336f8a2ef10339112401253b3c714eeda879f12c509072ef055b48372bb82efbdce8e10c8cb9a2f9d60e93908f93df1623ad78a86a028d6bc064dbfc75a6a57379ef855dc6733801",
+			"38561d6b77b71d30eb97a062168ae12b667ce5c28caccdf76bc88e093e4635987cd96814ce55b4689b3dd2947f80e59aac7b7675f8083865b46c89b2ce9cc735"},
+		{ecvrf.Edwards25519SHA512ELL2(),
+			"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025", "af82",
+			"926e895d308f5e328e7aa159c06eddbe56d06846abf5d98c2512235eaa57fdce35b46edfc655bc828d44ad09d1150f31374e7ef73027e14760d42e77341fe05467bb286cc2c9d7fde29120a0b2320d04",
+			"121b7f9b9aaaa29099fc04a94ba52784d44eac976dd1a3cca458733be5cd090a7b5fbd148444f17f8daf1fb55cb04b1ae85a626e30a54b4b0f8abf4a43314a58"},
+	} {
+		sk, _ := hex.DecodeString(v.sk)
+		alpha, _ := hex.DecodeString(v.alpha)
+		key, err := v.s.NewPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(key.Public()); got != v.pk {
+			t.Fatalf("%v: got pk: %v\nwant: %v", v.s, got, v.pk)
+		}
+		pi := v.s.Prove(key, alpha)
+		if got := hex.EncodeToString(pi); got != v.pi {
+			t.Fatalf("%v: got pi: %v\nwant: %v", v.s, got, v.pi)
+		}
+		beta, ok := v.s.Verify(key.Public(), alpha, pi)
+		if !ok {
+			t.Fatalf("%v: proof rejected", v.s)
+		}
+		if hex.EncodeToString(beta) != v.beta {
+			t.Fatalf("%v: got beta: %x\nwant: %v", v.s, beta, v.beta)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	alpha := []byte("alpha")
+	for _, s := range []*ecvrf.Suite{
+		ecvrf.P256SHA256TAI(),
+		ecvrf.P256SHA256SSWU(),
+		ecvrf.Edwards25519SHA512ELL2(),
+	} {
+		key, err := s.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub := key.Public()
+		pi := s.Prove(key, alpha)
+		if !bytes.Equal(pi, s.Prove(key, alpha)) {
+			t.Fatalf("%v: proofs must be deterministic", s)
+		}
+		beta, ok := s.Verify(pub, alpha, pi)
+		if !ok {
+			t.Fatalf("%v: proof rejected", s)
+		}
+		if b, err := s.ProofToHash(pi); err != nil || !bytes.Equal(b, beta) {
+			t.Fatalf("%v: ProofToHash differs from Verify: %v", s, err)
+		}
+
+		other, _ := s.GenerateKey(rand.Reader)
+		if _, ok := s.Verify(other.Public(), alpha, pi); ok {
+			t.Fatalf("%v: proof accepted under another key", s)
+		}
+		if _, ok := s.Verify(pub, []byte("beta"), pi); ok {
+			t.Fatalf("%v: proof accepted for another input", s)
+		}
+		for _, i := range []int{0, len(pi) / 2, len(pi) - 2} {
+			bad := append([]byte{}, pi...)
+			bad[i] ^= 1
+			if _, ok := s.Verify(pub, alpha, bad); ok {
+				t.Fatalf("%v: modified proof accepted", s)
+			}
+		}
+		if _, ok := s.Verify(pub, alpha, pi[1:]); ok {
+			t.Fatalf("%v: short proof accepted", s)
+		}
+	}
+
+	// Public keys of low order are rejected, such as the identity of
+	// edwards25519.
+	s := ecvrf.Edwards25519SHA512ELL2()
+	key, _ := s.GenerateKey(rand.Reader)
+	id := make([]byte, 32)
+	id[0] = 1
+	if _, ok := s.Verify(id, alpha, s.Prove(key, alpha)); ok {
+		t.Fatal("low-order public key accepted")
+	}
+}
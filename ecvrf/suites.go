@@ -0,0 +1,181 @@
+package ecvrf
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha256" // for the P-256 suites
+	_ "crypto/sha512" // for the Edwards suite
+	"errors"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/ecdsa"
+	"github.com/armfazh/tozan-ecc/eddsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+// P256SHA256TAI returns ECVRF-P256-SHA256-TAI, which encodes to the curve
+// with try-and-increment.
+func P256SHA256TAI() *Suite {
+	s := p256("ECVRF-P256-SHA256-TAI", 0x01)
+	s.encodeToCurve = s.tryAndIncrement
+	return s
+}
+
+// P256SHA256SSWU returns ECVRF-P256-SHA256-SSWU, which encodes to the curve
+// with P256_XMD:SHA-256_SSWU_NU_ of RFC 9380.
+func P256SHA256SSWU() *Suite {
+	s := p256("ECVRF-P256-SHA256-SSWU", 0x02)
+	encode, err := group.Encoder(s.E, crypto.SHA256)
+	if err != nil {
+		panic(err)
+	}
+	dst := append([]byte("ECVRF_P256_XMD:SHA-256_SSWU_NU_"), s.id)
+	s.encodeToCurve = func(salt, alpha []byte) C.Point {
+		return encode(append(append([]byte{}, salt...), alpha...), dst)
+	}
+	return s
+}
+
+// Edwards25519SHA512ELL2 returns ECVRF-EDWARDS25519-SHA512-ELL2, which encodes
+// to the curve with edwards25519_XMD:SHA-512_ELL2_NU_ of RFC 9380.
+func Edwards25519SHA512ELL2() *Suite {
+	E, B := eddsa.Edwards25519()
+	ed := eddsa.Ed25519()
+	s := &Suite{E: E, B: B, Q: E.Order(),
+		name: "ECVRF-EDWARDS25519-SHA512-ELL2", id: 0x04, hash: crypto.SHA512,
+		cLen: 16, qLen: 32, ptLen: 32, littleEndian: true,
+		pointToString: ed.EncodePoint,
+		stringToPoint: ed.DecodePoint,
+	}
+	encode := ell2Edwards25519(E)
+	dst := append([]byte("ECVRF_edwards25519_XMD:SHA-512_ELL2_NU_"), s.id)
+	s.encodeToCurve = func(salt, alpha []byte) C.Point {
+		return encode(append(append([]byte{}, salt...), alpha...), dst)
+	}
+	// The secret scalar and the nonce prefix are derived as in RFC 8032.
+	s.secret = func(sk []byte) (*big.Int, []byte, error) {
+		h := s.digest(sk)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		return s.stringToInt(h[:32]), h[32:], nil
+	}
+	s.nonce = func(key *PrivateKey, h []byte) *big.Int {
+		k := s.stringToInt(s.digest(key.state, h))
+		return k.Mod(k, s.Q)
+	}
+	return s
+}
+
+// p256 returns a suite over P-256 with SEC1 compressed encodings and nonces
+// of RFC 6979.
+func p256(name string, id byte) *Suite {
+	p := elliptic.P256().Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	B := E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy))
+	ec, err := ecdsa.New(E, B, crypto.SHA256)
+	if err != nil {
+		panic(err)
+	}
+	s := &Suite{E: E, B: B, Q: E.Order(),
+		name: name, id: id, hash: crypto.SHA256,
+		cLen: 16, qLen: 32, ptLen: 33,
+	}
+	s.pointToString = s.sec1Encode
+	s.stringToPoint = s.sec1Decode
+	s.secret = func(sk []byte) (*big.Int, []byte, error) {
+		x := s.stringToInt(sk)
+		if x.Sign() == 0 || x.Cmp(s.Q) >= 0 {
+			return nil, nil, errors.New("private scalar out of range")
+		}
+		return x, nil, nil
+	}
+	s.nonce = func(key *PrivateKey, h []byte) *big.Int {
+		return ec.Nonce(&ecdsa.PrivateKey{D: key.x}, s.digest(h))
+	}
+	return s
+}
+
+// tryAndIncrement hashes salt||alpha with a counter until the hash is the
+// x-coordinate of a point with even y.
+func (s *Suite) tryAndIncrement(salt, alpha []byte) C.Point {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := s.digest([]byte{s.id, 0x01}, salt, alpha, []byte{byte(ctr), 0x00})
+		if P, err := s.stringToPoint(append([]byte{0x02}, h...)); err == nil {
+			return s.E.ClearCofactor(P)
+		}
+	}
+	panic("no point found")
+}
+
+// sec1Encode returns the compressed SEC1 encoding of P.
+func (s *Suite) sec1Encode(P C.Point) []byte {
+	if P.IsIdentity() {
+		return []byte{0x00}
+	}
+	F := s.E.Field()
+	b := make([]byte, s.ptLen)
+	b[0] = byte(2 + F.Sgn0(P.Y()))
+	x := P.X().Polynomial()[0].Bytes()
+	copy(b[s.ptLen-len(x):], x)
+	return b
+}
+
+// sec1Decode parses a compressed SEC1 encoding.
+func (s *Suite) sec1Decode(b []byte) (C.Point, error) {
+	if len(b) != s.ptLen || (b[0] != 2 && b[0] != 3) {
+		return nil, errors.New("invalid length or prefix of point")
+	}
+	F := s.E.Field()
+	x := new(big.Int).SetBytes(b[1:])
+	if x.Cmp(F.P()) >= 0 {
+		return nil, errors.New("coordinate is not a field element")
+	}
+	return C.LiftX(s.E, F.Elt(x), int(b[0]-2))
+}
+
+// ell2Edwards25519 returns encode_to_curve of RFC 9380 for edwards25519, which
+// maps with Elligator 2 onto curve25519 and then to edwards25519.
+func ell2Edwards25519(E C.EllCurve) func(msg, dst []byte) C.Point {
+	F := E.Field()
+	J, Z := F.Elt(486662), F.Elt(2)
+	c1 := F.Sqrt(F.Elt(-486664))
+	if F.Sgn0(c1) == 1 {
+		c1 = F.Neg(c1)
+	}
+	mont := func(x GF.Elt) GF.Elt { // x^3+Jx^2+x
+		return F.Mul(F.Add(F.Mul(F.Add(x, J), x), F.One()), x)
+	}
+	return func(msg, dst []byte) C.Point {
+		b, err := group.ExpandMessageXMD(crypto.SHA512, msg, dst, 48)
+		if err != nil {
+			panic(err)
+		}
+		u := F.Elt(new(big.Int).SetBytes(b))
+		x1 := F.Neg(F.Mul(J, F.Inv0(F.Add(F.One(), F.Mul(Z, F.Sqr(u))))))
+		if F.IsZero(x1) {
+			x1 = F.Neg(J)
+		}
+		x, y2, sign := x1, mont(x1), 1
+		if !F.IsZero(y2) && !F.IsSquare(y2) {
+			x = F.Sub(F.Neg(x1), J)
+			y2, sign = mont(x), 0
+		}
+		y := F.Sqrt(y2)
+		if F.Sgn0(y) != sign {
+			y = F.Neg(y)
+		}
+		// (v,w) = (c1*x/y, (x-1)/(x+1)), where the exceptional cases map to
+		// the identity.
+		xp1 := F.Add(x, F.One())
+		if F.IsZero(y) || F.IsZero(xp1) {
+			return E.Identity()
+		}
+		v := F.Mul(F.Mul(c1, x), F.Inv(y))
+		w := F.Mul(F.Sub(x, F.One()), F.Inv(xp1))
+		return E.ClearCofactor(E.NewPoint(v, w))
+	}
+}
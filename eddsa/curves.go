@@ -0,0 +1,33 @@
+package eddsa
+
+import (
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Edwards25519 returns the twisted Edwards curve -x^2+y^2=1+dx^2y^2 with
+// d=-121665/121666 over GF(2^255-19), and its base point of prime order.
+func Edwards25519() (C.EllCurve, C.Point) {
+	F := GF.NewFp("2^255-19", "0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed")
+	d := F.Mul(F.Elt(-121665), F.Inv(F.Elt(121666)))
+	L, _ := new(big.Int).SetString("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 0)
+	E := C.TwistedEdwards.New("edwards25519", F, F.Elt(-1), d, L, big.NewInt(8))
+	B := E.NewPoint(
+		F.Elt("0x216936d3cd6e53fec0a4e231fdd6dc5c692cc7609525a7b2c9562d608f25d51a"),
+		F.Elt("0x6666666666666666666666666666666666666666666666666666666666666658"))
+	return E, B
+}
+
+// Edwards448 returns the Edwards curve x^2+y^2=1-39081x^2y^2 over
+// GF(2^448-2^224-1), and its base point of prime order.
+func Edwards448() (C.EllCurve, C.Point) {
+	F := GF.NewFp("2^448-2^224-1", "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	L, _ := new(big.Int).SetString("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3", 0)
+	E := C.TwistedEdwards.New("edwards448", F, F.One(), F.Elt(-39081), L, big.NewInt(4))
+	B := E.NewPoint(
+		F.Elt("0x4f1970c66bed0ded221d15a622bf36da9e146570470f1767ea6de324a3d3a46412ae1af72ab66511433b80e18b00938e2626a82bc70cc05e"),
+		F.Elt("0x693f46716eb6bc248876203756c9c7624bea73736ca3984087789c1e05a0c2d73ad3ff1ce67c39c4fdbd132c4ed7c8ad9808795bf230fa14"))
+	return E, B
+}
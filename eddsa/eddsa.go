@@ -0,0 +1,306 @@
+// Package eddsa implements the Edwards-curve Digital Signature Algorithm of
+// RFC 8032: Ed25519, Ed25519ctx, Ed25519ph, Ed448 and Ed448ph.
+package eddsa
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"golang.org/x/crypto/sha3"
+)
+
+// Scheme is an instance of EdDSA on a twisted Edwards curve E with base point
+// B of prime order L.
+type Scheme struct {
+	E C.EllCurve
+	B C.Point
+	L *big.Int
+	// Cofactored selects the verification equation [h][S]B=[h]R+[h][k]A,
+	// where h is the cofactor, instead of the strict equation [S]B=R+[k]A.
+	Cofactored bool
+
+	name    string
+	size    int    // length of encoded points and scalars
+	dom     []byte // dom2 or dom4, prepended to every hash
+	hash    func(data ...[]byte) []byte
+	prehash func(msg []byte) []byte
+	clamp   func(h []byte)
+}
+
+// PublicKey is the encoding of a public point A=[s]B.
+type PublicKey []byte
+
+// PrivateKey is an EdDSA private key derived from a seed.
+type PrivateKey struct {
+	seed   []byte
+	public PublicKey
+	s      *big.Int
+	prefix []byte
+}
+
+// Seed returns the seed from which the key was derived.
+func (k *PrivateKey) Seed() []byte { return append([]byte{}, k.seed...) }
+
+// Public returns the public key.
+func (k *PrivateKey) Public() PublicKey { return append(PublicKey{}, k.public...) }
+
+const ed25519Dom = "SigEd25519 no Ed25519 collisions"
+
+// Ed25519 returns pure Ed25519, without context.
+func Ed25519() *Scheme {
+	s := ed25519()
+	s.name = "Ed25519"
+	return s
+}
+
+// Ed25519ctx returns Ed25519 with a non-empty context of at most 255 bytes.
+func Ed25519ctx(ctx []byte) (*Scheme, error) {
+	if len(ctx) == 0 {
+		return nil, errors.New("Ed25519ctx requires a non-empty context")
+	}
+	return ed25519().withDom(ed25519Dom, 0, ctx, "Ed25519ctx")
+}
+
+// Ed25519ph returns Ed25519 with SHA-512 prehashing and a context of at most
+// 255 bytes.
+func Ed25519ph(ctx []byte) (*Scheme, error) {
+	s, err := ed25519().withDom(ed25519Dom, 1, ctx, "Ed25519ph")
+	if err != nil {
+		return nil, err
+	}
+	s.prehash = func(msg []byte) []byte { h := sha512.Sum512(msg); return h[:] }
+	return s, nil
+}
+
+// Ed448 returns Ed448 with a context of at most 255 bytes.
+func Ed448(ctx []byte) (*Scheme, error) {
+	return ed448().withDom("SigEd448", 0, ctx, "Ed448")
+}
+
+// Ed448ph returns Ed448 with SHAKE256 prehashing and a context of at most 255
+// bytes.
+func Ed448ph(ctx []byte) (*Scheme, error) {
+	s, err := ed448().withDom("SigEd448", 1, ctx, "Ed448ph")
+	if err != nil {
+		return nil, err
+	}
+	s.prehash = func(msg []byte) []byte { return shake256(64, msg) }
+	return s, nil
+}
+
+func ed25519() *Scheme {
+	E, B := Edwards25519()
+	return &Scheme{E: E, B: B, L: E.Order(), size: 32,
+		hash: func(data ...[]byte) []byte {
+			h := sha512.New()
+			for _, d := range data {
+				_, _ = h.Write(d)
+			}
+			return h.Sum(nil)
+		},
+		clamp: func(h []byte) {
+			h[0] &= 248
+			h[31] &= 127
+			h[31] |= 64
+		},
+	}
+}
+
+func ed448() *Scheme {
+	E, B := Edwards448()
+	return &Scheme{E: E, B: B, L: E.Order(), size: 57,
+		hash: func(data ...[]byte) []byte { return shake256(114, data...) },
+		clamp: func(h []byte) {
+			h[0] &= 252
+			h[55] |= 128
+			h[56] = 0
+		},
+	}
+}
+
+func shake256(n int, data ...[]byte) []byte {
+	h := sha3.NewShake256()
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	out := make([]byte, n)
+	_, _ = h.Read(out)
+	return out
+}
+
+// withDom sets the domain separator prefix||flag||len(ctx)||ctx.
+func (s *Scheme) withDom(prefix string, flag byte, ctx []byte, name string) (*Scheme, error) {
+	if len(ctx) > 255 {
+		return nil, errors.New("context is longer than 255 bytes")
+	}
+	s.dom = append(append([]byte(prefix), flag, byte(len(ctx))), ctx...)
+	s.name = name
+	return s, nil
+}
+
+func (s *Scheme) String() string { return s.name }
+
+// GenerateKey returns a private key from a random seed.
+func (s *Scheme) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	seed := make([]byte, s.size)
+	if _, err := io.ReadFull(rnd, seed); err != nil {
+		return nil, err
+	}
+	return s.NewKeyFromSeed(seed)
+}
+
+// NewKeyFromSeed derives a private key from a seed of the length of encoded
+// points.
+func (s *Scheme) NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != s.size {
+		return nil, errors.New("wrong length of seed")
+	}
+	h := s.hash(seed)
+	s.clamp(h[:s.size])
+	k := &PrivateKey{
+		seed:   append([]byte{}, seed...),
+		s:      leToInt(h[:s.size]),
+		prefix: h[s.size:],
+	}
+	k.public = s.encode(s.E.ScalarMult(s.B, k.s))
+	return k, nil
+}
+
+// Sign returns the signature R||S of msg.
+func (s *Scheme) Sign(key *PrivateKey, msg []byte) []byte {
+	m := s.message(msg)
+	r := s.hashToScalar(key.prefix, m)
+	R := s.encode(s.E.ScalarMult(s.B, r))
+	k := s.hashToScalar(R, key.public, m)
+	// S = r+ks mod L
+	S := new(big.Int).Mul(k, key.s)
+	S.Add(S, r).Mod(S, s.L)
+	return append(R, intToLE(S, s.size)...)
+}
+
+// Verify returns true if sig is a valid signature of msg under pub.
+func (s *Scheme) Verify(pub PublicKey, msg, sig []byte) bool {
+	if len(sig) != 2*s.size {
+		return false
+	}
+	A, err := s.decode(pub)
+	if err != nil {
+		return false
+	}
+	R, err := s.decode(sig[:s.size])
+	if err != nil {
+		return false
+	}
+	S := leToInt(sig[s.size:])
+	if S.Cmp(s.L) >= 0 {
+		return false
+	}
+	k := s.hashToScalar(sig[:s.size], pub, s.message(msg))
+	// [S]B = R+[k]A
+	lhs := s.E.ScalarMult(s.B, S)
+	rhs := s.E.Add(R, s.E.ScalarMult(A, k))
+	if s.Cofactored {
+		lhs, rhs = s.E.ClearCofactor(lhs), s.E.ClearCofactor(rhs)
+	}
+	return lhs.IsEqual(rhs)
+}
+
+// message returns PH(msg).
+func (s *Scheme) message(msg []byte) []byte {
+	if s.prehash != nil {
+		return s.prehash(msg)
+	}
+	return msg
+}
+
+// hashToScalar returns H(dom||data) as a little-endian integer modulo L.
+func (s *Scheme) hashToScalar(data ...[]byte) *big.Int {
+	h := leToInt(s.hash(append([][]byte{s.dom}, data...)...))
+	return h.Mod(h, s.L)
+}
+
+// encode returns the little-endian encoding of y, with the sign of x in the
+// most significant bit.
+func (s *Scheme) encode(P C.Point) []byte {
+	F := s.E.Field()
+	b := intToLE(P.Y().Polynomial()[0], s.size)
+	b[s.size-1] |= byte(F.Sgn0(P.X()) << 7)
+	return b
+}
+
+// EncodePoint returns the encoding of P of Section 5.1.2 of RFC 8032.
+func (s *Scheme) EncodePoint(P C.Point) []byte { return s.encode(P) }
+
+// DecodePoint returns the point encoded in b as in Section 5.1.3 of RFC 8032.
+// The point is not required to be in the prime-order subgroup.
+func (s *Scheme) DecodePoint(b []byte) (C.Point, error) { return s.decode(b) }
+
+var errDecoding = errors.New("invalid point encoding")
+
+// decode returns the point encoded in b. As y < p is required, encodings are
+// canonical except for x=0 with the sign bit set, which is rejected.
+func (s *Scheme) decode(b []byte) (C.Point, error) {
+	if len(b) != s.size {
+		return nil, errDecoding
+	}
+	F := s.E.Field()
+	b = append([]byte{}, b...)
+	sign := int(b[s.size-1] >> 7)
+	b[s.size-1] &= 0x7f
+	yy := leToInt(b)
+	if yy.Cmp(F.P()) >= 0 {
+		return nil, errDecoding
+	}
+	// x^2 = (y^2-1)/(dy^2-a)
+	a, d := s.coefficients()
+	y := F.Elt(yy)
+	t0 := F.Sqr(y)              // y^2
+	t1 := F.Sub(t0, F.One())    // y^2-1
+	t0 = F.Sub(F.Mul(d, t0), a) // dy^2-a
+	xx := F.Mul(t1, F.Inv(t0))  // x^2
+	x := F.Zero()
+	if !F.IsZero(xx) {
+		if !F.IsSquare(xx) {
+			return nil, errDecoding
+		}
+		x = F.Sqrt(xx)
+	}
+	if F.IsZero(x) && sign == 1 {
+		return nil, errDecoding
+	}
+	if F.Sgn0(x) != sign {
+		x = F.Neg(x)
+	}
+	return s.E.NewPoint(x, y), nil
+}
+
+// coefficients returns a and d of the curve ax^2+y^2=1+dx^2y^2.
+func (s *Scheme) coefficients() (a, d GF.Elt) {
+	E := s.E.(C.T)
+	return E.A, E.D
+}
+
+func leToInt(b []byte) *big.Int {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(r)
+}
+
+func intToLE(x *big.Int, n int) []byte {
+	b := x.Bytes()
+	r := make([]byte, n)
+	for i := range b {
+		r[i] = b[len(b)-1-i]
+	}
+	return r
+}
@@ -0,0 +1,275 @@
+package eddsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/eddsa"
+)
+
+type vector struct {
+	name, scheme          string
+	sk, pk, msg, ctx, sig string
+}
+
+// Test vectors from Section 7 of RFC 8032.
+var vectors = []vector{
+	{"TEST 1", "Ed25519Pure",
+		"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		"",
+		"",
+		"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"},
+	{"TEST 2", "Ed25519Pure",
+		"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		"72",
+		"",
+		"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00"},
+	{"TEST 3", "Ed25519Pure",
+		"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		"af82",
+		"",
+		"6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a"},
+	{"TEST sha(abc)", "Ed25519Pure",
+		"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		"ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		"",
+		"dc2a4459e7369633a52b1bf277839a00201009a3efbf3ecb69bea2186c26b58909351fc9ac90b3ecfdfbc7c66431e0303dca179c138ac17ad9bef1177331a704"},
+	{"TEST abc", "Ed25519Ph",
+		"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		"ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		"616263",
+		"",
+		"98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406"},
+	{"foo", "Ed25519Ctx",
+		"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"f726936d19c800494e3fdaff20b276a8",
+		"666f6f",
+		"55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d"},
+	{"bar", "Ed25519Ctx",
+		"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"f726936d19c800494e3fdaff20b276a8",
+		"626172",
+		"fc60d5872fc46b3aa69f8b5b4351d5808f92bcc044606db097abab6dbcb1aee3216c48e8b3b66431b5b186d1d28f8ee15a5ca2df6668346291c2043d4eb3e90d"},
+	{"foo2", "Ed25519Ctx",
+		"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"508e9e6882b979fea900f62adceaca35",
+		"666f6f",
+		"8b70c1cc8310e1de20ac53ce28ae6e7207f33c3295e03bb5c0732a1d20dc64908922a8b052cf99b7c4fe107a5abb5b2c4085ae75890d02df26269d8945f84b0b"},
+	{"foo3", "Ed25519Ctx",
+		"ab9c2853ce297ddab85c993b3ae14bcad39b2c682beabc27d6d4eb20711d6560",
+		"0f1d1274943b91415889152e893d80e93275a1fc0b65fd71b4b0dda10ad7d772",
+		"f726936d19c800494e3fdaff20b276a8",
+		"666f6f",
+		"21655b5f1aa965996b3f97b3c849eafba922a0a62992f73b3d1b73106a84ad85e9b86a7b6005ea868337ff2d20a7f5fbd4cd10b0be49a68da2b2e0dc0ad8960f"},
+
+	{"Blank", "Ed448Pure",
+		"6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b",
+		"5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+		"",
+		"",
+		"533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f4dbb61149f05a7363268c71d95808ff2e652600"},
+	{"1 octet", "Ed448Pure",
+		"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		"03",
+		"",
+		"26b8f91727bd62897af15e41eb43c377efb9c610d48f2335cb0bd0087810f4352541b143c4b981b7e18f62de8ccdf633fc1bf037ab7cd779805e0dbcc0aae1cbcee1afb2e027df36bc04dcecbf154336c19f0af7e0a6472905e799f1953d2a0ff3348ab21aa4adafd1d234441cf807c03a00"},
+	{"1 octet (with context)", "Ed448Pure",
+		"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		"03",
+		"666f6f",
+		"d4f8f6131770dd46f40867d6fd5d5055de43541f8c5e35abbcd001b32a89f7d2151f7647f11d8ca2ae279fb842d607217fce6e042f6815ea000c85741de5c8da1144a6a1aba7f96de42505d7a7298524fda538fccbbb754f578c1cad10d54d0d5428407e85dcbc98a49155c13764e66c3c00"},
+	{"11 octets", "Ed448Pure",
+		"cd23d24f714274e744343237b93290f511f6425f98e64459ff203e8985083ffdf60500553abc0e05cd02184bdb89c4ccd67e187951267eb328",
+		"dcea9e78f35a1bf3499a831b10b86c90aac01cd84b67a0109b55a36e9328b1e365fce161d71ce7131a543ea4cb5f7e9f1d8b00696447001400",
+		"0c3e544074ec63b0265e0c",
+		"",
+		"1f0a8888ce25e8d458a21130879b840a9089d999aaba039eaf3e3afa090a09d389dba82c4ff2ae8ac5cdfb7c55e94d5d961a29fe0109941e00b8dbdeea6d3b051068df7254c0cdc129cbe62db2dc957dbb47b51fd3f213fb8698f064774250a5028961c9bf8ffd973fe5d5c206492b140e00"},
+	{"12 octets", "Ed448Pure",
+		"258cdd4ada32ed9c9ff54e63756ae582fb8fab2ac721f2c8e676a72768513d939f63dddb55609133f29adf86ec9929dccb52c1c5fd2ff7e21b",
+		"3ba16da0c6f2cc1f30187740756f5e798d6bc5fc015d7c63cc9510ee3fd44adc24d8e968b6e46e6f94d19b945361726bd75e149ef09817f580",
+		"64a65f3cdedcdd66811e2915",
+		"",
+		"7eeeab7c4e50fb799b418ee5e3197ff6bf15d43a14c34389b59dd1a7b1b85b4ae90438aca634bea45e3a2695f1270f07fdcdf7c62b8efeaf00b45c2c96ba457eb1a8bf075a3db28e5c24f6b923ed4ad747c3c9e03c7079efb87cb110d3a99861e72003cbae6d6b8b827e4e6c143064ff3c00"},
+	{"13 octets", "Ed448Pure",
+		"7ef4e84544236752fbb56b8f31a23a10e42814f5f55ca037cdcc11c64c9a3b2949c1bb60700314611732a6c2fea98eebc0266a11a93970100e",
+		"b3da079b0aa493a5772029f0467baebee5a8112d9d3a22532361da294f7bb3815c5dc59e176b4d9f381ca0938e13c6c07b174be65dfa578e80",
+		"64a65f3cdedcdd66811e2915e7",
+		"",
+		"6a12066f55331b6c22acd5d5bfc5d71228fbda80ae8dec26bdd306743c5027cb4890810c162c027468675ecf645a83176c0d7323a2ccde2d80efe5a1268e8aca1d6fbc194d3f77c44986eb4ab4177919ad8bec33eb47bbb5fc6e28196fd1caf56b4e7e0ba5519234d047155ac727a1053100"},
+	{"64 octets", "Ed448Pure",
+		"d65df341ad13e008567688baedda8e9dcdc17dc024974ea5b4227b6530e339bff21f99e68ca6968f3cca6dfe0fb9f4fab4fa135d5542ea3f01",
+		"df9705f58edbab802c7f8363cfe5560ab1c6132c20a9f1dd163483a26f8ac53a39d6808bf4a1dfbd261b099bb03b3fb50906cb28bd8a081f00",
+		"bd0f6a3747cd561bdddf4640a332461a4a30a12a434cd0bf40d766d9c6d458e5512204a30c17d1f50b5079631f64eb3112182da3005835461113718d1a5ef944",
+		"",
+		"554bc2480860b49eab8532d2a533b7d578ef473eeb58c98bb2d0e1ce488a98b18dfde9b9b90775e67f47d4a1c3482058efc9f40d2ca033a0801b63d45b3b722ef552bad3b4ccb667da350192b61c508cf7b6b5adadc2c8d9a446ef003fb05cba5f30e88e36ec2703b349ca229c2670833900"},
+	{"256 octets", "Ed448Pure",
+		"2ec5fe3c17045abdb136a5e6a913e32ab75ae68b53d2fc149b77e504132d37569b7e766ba74a19bd6162343a21c8590aa9cebca9014c636df5",
+		"79756f014dcfe2079f5dd9e718be4171e2ef2486a08f25186f6bff43a9936b9bfe12402b08ae65798a3d81e22e9ec80e7690862ef3d4ed3a00",
+		"15777532b0bdd0d1389f636c5f6b9ba734c90af572877e2d272dd078aa1e567cfa80e12928bb542330e8409f3174504107ecd5efac61ae7504dabe2a602ede89e5cca6257a7c77e27a702b3ae39fc769fc54f2395ae6a1178cab4738e543072fc1c177fe71e92e25bf03e4ecb72f47b64d0465aaea4c7fad372536c8ba516a6039c3c2a39f0e4d832be432dfa9a706a6e5c7e19f397964ca4258002f7c0541b590316dbc5622b6b2a6fe7a4abffd96105eca76ea7b98816af0748c10df048ce012d901015a51f189f3888145c03650aa23ce894c3bd889e030d565071c59f409a9981b51878fd6fc110624dcbcde0bf7a69ccce38fabdf86f3bef6044819de11",
+		"",
+		"c650ddbb0601c19ca11439e1640dd931f43c518ea5bea70d3dcde5f4191fe53f00cf966546b72bcc7d58be2b9badef28743954e3a44a23f880e8d4f1cfce2d7a61452d26da05896f0a50da66a239a8a188b6d825b3305ad77b73fbac0836ecc60987fd08527c1a8e80d5823e65cafe2a3d00"},
+	{"TEST abc", "Ed448Ph",
+		"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42ef7822e0d5104127dc05d6dbefde69e3ab2cec7c867c6e2c49",
+		"259b71c19f83ef77a7abd26524cbdb3161b590a48f7d17de3ee0ba9c52beb743c09428a131d6b1b57303d90d8132c276d5ed3d5d01c0f53880",
+		"616263",
+		"",
+		"822f6901f7480f3d5f562c592994d9693602875614483256505600bbc281ae381f54d6bce2ea911574932f52a4e6cadd78769375ec3ffd1b801a0d9b3f4030cd433964b6457ea39476511214f97469b57dd32dbc560a9a94d00bff07620464a3ad203df7dc7ce360c3cd3696d9d9fab90f00"},
+	{"TEST abc (with context)", "Ed448Ph",
+		"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42ef7822e0d5104127dc05d6dbefde69e3ab2cec7c867c6e2c49",
+		"259b71c19f83ef77a7abd26524cbdb3161b590a48f7d17de3ee0ba9c52beb743c09428a131d6b1b57303d90d8132c276d5ed3d5d01c0f53880",
+		"616263",
+		"666f6f",
+		"c32299d46ec8ff02b54540982814dce9a05812f81962b649d528095916a2aa481065b1580423ef927ecf0af5888f90da0f6a9a85ad5dc3f280d91224ba9911a3653d00e484e2ce232521481c8658df304bb7745a73514cdb9bf3e15784ab71284f8d0704a608c54a6b62d97beb511d132100"},
+}
+
+func scheme(t *testing.T, name string, ctx []byte) *eddsa.Scheme {
+	var s *eddsa.Scheme
+	var err error
+	switch name {
+	case "Ed25519Pure":
+		s = eddsa.Ed25519()
+	case "Ed25519Ctx":
+		s, err = eddsa.Ed25519ctx(ctx)
+	case "Ed25519Ph":
+		s, err = eddsa.Ed25519ph(ctx)
+	case "Ed448Pure":
+		s, err = eddsa.Ed448(ctx)
+	case "Ed448Ph":
+		s, err = eddsa.Ed448ph(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestRFC8032(t *testing.T) {
+	for _, v := range vectors {
+		s := scheme(t, v.scheme, unhex(v.ctx))
+		key, err := s.NewKeyFromSeed(unhex(v.sk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := []byte(key.Public()), unhex(v.pk); !bytes.Equal(got, want) {
+			t.Fatalf("%v %v: public key\ngot:  %x\nwant: %x", s, v.name, got, want)
+		}
+		msg := unhex(v.msg)
+		sig := s.Sign(key, msg)
+		if want := unhex(v.sig); !bytes.Equal(sig, want) {
+			t.Fatalf("%v %v: signature\ngot:  %x\nwant: %x", s, v.name, sig, want)
+		}
+		for _, cofactored := range []bool{false, true} {
+			s.Cofactored = cofactored
+			if !s.Verify(key.Public(), msg, sig) {
+				t.Fatalf("%v %v: valid signature rejected", s, v.name)
+			}
+			sig[len(sig)/2] ^= 1
+			if s.Verify(key.Public(), msg, sig) {
+				t.Fatalf("%v %v: invalid signature accepted", s, v.name)
+			}
+			sig[len(sig)/2] ^= 1
+		}
+	}
+}
+
+func TestCrossEd25519(t *testing.T) {
+	ctx := []byte("context")
+	ctxScheme, _ := eddsa.Ed25519ctx(ctx)
+	phScheme, _ := eddsa.Ed25519ph(ctx)
+	for i := 0; i < 4; i++ {
+		goPub, goKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := []byte{byte(i), 1, 2, 3}
+		for _, v := range []struct {
+			s    *eddsa.Scheme
+			opts *ed25519.Options
+		}{
+			{eddsa.Ed25519(), &ed25519.Options{}},
+			{ctxScheme, &ed25519.Options{Context: string(ctx)}},
+			{phScheme, &ed25519.Options{Hash: crypto.SHA512, Context: string(ctx)}},
+		} {
+			key, err := v.s.NewKeyFromSeed(goKey.Seed())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(key.Public(), goPub) {
+				t.Fatalf("%v: public keys differ", v.s)
+			}
+			m := msg
+			if v.opts.Hash != 0 {
+				h := sha512.Sum512(msg)
+				m = h[:]
+			}
+			want, err := goKey.Sign(nil, m, v.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig := v.s.Sign(key, msg)
+			if !bytes.Equal(sig, want) {
+				t.Fatalf("%v:\ngot:  %x\nwant: %x", v.s, sig, want)
+			}
+			if err := ed25519.VerifyWithOptions(goPub, m, sig, v.opts); err != nil {
+				t.Fatalf("%v: %v", v.s, err)
+			}
+		}
+	}
+}
+
+// TestCofactored checks a signature by the public key of order two, which is
+// only valid under the cofactored equation: with R the identity and S=0, the
+// strict equation O=R+[k]A holds only if k is even.
+func TestCofactored(t *testing.T) {
+	s := eddsa.Ed25519()
+	R := make([]byte, 32)
+	R[0] = 1 // y=1
+	A := make([]byte, 32)
+	for i := range A {
+		A[i] = 0xff
+	}
+	A[0], A[31] = 0xec, 0x7f // y=p-1
+	sig := append(R, make([]byte, 32)...)
+	for i := 0; ; i++ {
+		msg := []byte{byte(i)}
+		h := sha512.Sum512(append(append(append([]byte{}, R...), A...), msg...))
+		for j := 0; j < 32; j++ {
+			h[j], h[63-j] = h[63-j], h[j]
+		}
+		k := new(big.Int).SetBytes(h[:])
+		if k.Mod(k, s.L).Bit(0) == 0 {
+			continue
+		}
+		if s.Verify(A, msg, sig) {
+			t.Fatal("strict verification must reject")
+		}
+		s.Cofactored = true
+		if !s.Verify(A, msg, sig) {
+			t.Fatal("cofactored verification must accept")
+		}
+		return
+	}
+}
@@ -0,0 +1,263 @@
+package group
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+// curveGroup is the subgroup of prime order N of an elliptic curve E,
+// generated by G.
+type curveGroup struct {
+	E    C.EllCurve
+	G    C.Point
+	N    *big.Int
+	name string
+	exp  expander
+	k    int                    // security level in bits
+	z    GF.Elt                 // Z of the simplified SWU map, if any
+	sswu func(u GF.Elt) C.Point // nil for try-and-increment
+}
+
+// FromCurve returns the group generated by G of prime order E.Order(), where
+// hashing uses expand_message_xmd with h and a security level of half the
+// bit length of the order.
+//
+// For short Weierstrass curves with AB!=0 over prime fields, HashToElement is
+// hash_to_curve of RFC 9380 with the simplified SWU map; for other curves, it
+// uses try-and-increment, which is not constant time.
+func FromCurve(E C.EllCurve, G C.Point, h crypto.Hash) (Group, error) {
+	N := E.Order()
+	if N == nil || !N.ProbablyPrime(20) {
+		return nil, errors.New("order of the generator must be prime")
+	}
+	if G.IsIdentity() || !E.IsOnCurve(G) || !E.ScalarMult(G, N).IsIdentity() {
+		return nil, errors.New("generator doesn't have order E.Order()")
+	}
+	if !h.Available() {
+		return nil, errors.New("hash function is not available")
+	}
+	g := &curveGroup{E: E, G: G, N: new(big.Int).Set(N), exp: xmd(h), k: (N.BitLen() + 1) / 2}
+	g.name = fmt.Sprintf("group of order %v", N)
+	if W, ok := E.(C.W); ok && E.Field().Ext() == 1 {
+		F := E.Field()
+		if !F.IsZero(W.A) && !F.IsZero(W.B) {
+			g.z = findZ(F, W.A, W.B)
+			g.sswu = func(u GF.Elt) C.Point { return g.mapSSWU(W.A, W.B, u) }
+		}
+	}
+	return g, nil
+}
+
+// P256 returns the group of NIST P-256, which hashes with the suite
+// P256_XMD:SHA-256_SSWU_RO_ of RFC 9380.
+func P256() Group { return nist(elliptic.P256(), crypto.SHA256, "P256_XMD:SHA-256_SSWU_RO_") }
+
+// P384 returns the group of NIST P-384, which hashes with the suite
+// P384_XMD:SHA-384_SSWU_RO_ of RFC 9380.
+func P384() Group { return nist(elliptic.P384(), crypto.SHA384, "P384_XMD:SHA-384_SSWU_RO_") }
+
+// P521 returns the group of NIST P-521, which hashes with the suite
+// P521_XMD:SHA-512_SSWU_RO_ of RFC 9380.
+func P521() Group { return nist(elliptic.P521(), crypto.SHA512, "P521_XMD:SHA-512_SSWU_RO_") }
+
+func nist(c elliptic.Curve, h crypto.Hash, name string) Group {
+	p := c.Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	g, err := FromCurve(E, E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy)), h)
+	if err != nil {
+		panic(err)
+	}
+	g.(*curveGroup).name = name
+	return g
+}
+
+func (g *curveGroup) String() string      { return g.name }
+func (g *curveGroup) Order() *big.Int     { return new(big.Int).Set(g.N) }
+func (g *curveGroup) Identity() *Element  { return &Element{g.E.Identity()} }
+func (g *curveGroup) Generator() *Element { return &Element{g.G.Copy()} }
+func (g *curveGroup) Add(a, b *Element) *Element {
+	return &Element{g.E.Add(a.p, b.p)}
+}
+func (g *curveGroup) Neg(a *Element) *Element { return &Element{g.E.Neg(a.p)} }
+func (g *curveGroup) ScalarMult(a *Element, k *big.Int) *Element {
+	return &Element{g.E.ScalarMult(a.p, mod(k, g.N))}
+}
+func (g *curveGroup) IsEqual(a, b *Element) bool { return a.p.IsEqual(b.p) }
+func (g *curveGroup) IsIdentity(a *Element) bool { return a.p.IsIdentity() }
+
+func (g *curveGroup) HashToElement(msg, dst []byte) *Element {
+	if g.sswu == nil {
+		// The group has prime order, so a point is found with overwhelming
+		// probability.
+		P, err := C.HashToPoint(g.E, msg, dst, true)
+		if err != nil {
+			panic(err)
+		}
+		return &Element{P}
+	}
+	F := g.E.Field()
+	u := hashToField(F, g.exp, msg, dst, 2, uniformLen(F.P(), g.k))
+	P := g.E.Add(g.sswu(u[0]), g.sswu(u[1]))
+	return &Element{g.E.ClearCofactor(P)}
+}
+
+// Encoder returns encode_to_curve of RFC 9380 for a short Weierstrass curve E
+// with AB!=0 over a prime field, which uses the simplified SWU map and
+// expand_message_xmd with h, at a security level of half the bit length of
+// E.Order(). Unlike hash_to_curve, its outputs are not uniformly distributed.
+func Encoder(E C.EllCurve, h crypto.Hash) (func(msg, dst []byte) C.Point, error) {
+	W, ok := E.(C.W)
+	if !ok || E.Field().Ext() != 1 {
+		return nil, errors.New("curve must be short Weierstrass over a prime field")
+	}
+	F := E.Field()
+	if F.IsZero(W.A) || F.IsZero(W.B) {
+		return nil, errors.New("simplified SWU requires AB!=0")
+	}
+	if !h.Available() {
+		return nil, errors.New("hash function is not available")
+	}
+	g := &curveGroup{E: E, exp: xmd(h), k: (E.Order().BitLen() + 1) / 2, z: findZ(F, W.A, W.B)}
+	return func(msg, dst []byte) C.Point {
+		u := hashToField(F, g.exp, msg, dst, 1, uniformLen(F.P(), g.k))
+		return E.ClearCofactor(g.mapSSWU(W.A, W.B, u[0]))
+	}, nil
+}
+
+func (g *curveGroup) HashToScalar(msg, dst []byte) *big.Int {
+	L := uniformLen(g.N, g.k)
+	k := new(big.Int).SetBytes(g.exp(msg, dst, L))
+	return k.Mod(k, g.N)
+}
+
+func (g *curveGroup) RandomScalar(rnd io.Reader) (*big.Int, error) {
+	return randomScalar(rnd, g.N)
+}
+
+func (g *curveGroup) fieldLen() int { return (g.E.Field().P().BitLen() + 7) / 8 }
+
+// ElementLength is the length of compressed SEC1 encodings.
+func (g *curveGroup) ElementLength() int { return 1 + g.fieldLen() }
+
+// SerializeElement returns the compressed SEC1 encoding of a, which is
+// 0x02||x or 0x03||x, where the latter is used when y has sign one.
+func (g *curveGroup) SerializeElement(a *Element) ([]byte, error) {
+	if a.p.IsIdentity() {
+		return nil, errors.New("the identity can't be serialized")
+	}
+	x := fillBytes(a.p.X().Polynomial()[0], g.fieldLen())
+	return append([]byte{byte(2 + g.E.Field().Sgn0(a.p.Y()))}, x...), nil
+}
+
+func (g *curveGroup) DeserializeElement(b []byte) (*Element, error) {
+	if len(b) != g.ElementLength() || (b[0] != 2 && b[0] != 3) {
+		return nil, errors.New("invalid length or prefix of element")
+	}
+	F := g.E.Field()
+	x := new(big.Int).SetBytes(b[1:])
+	if x.Cmp(F.P()) >= 0 {
+		return nil, errors.New("coordinate is not a field element")
+	}
+	P, err := C.LiftX(g.E, F.Elt(x), int(b[0]-2))
+	if err != nil {
+		return nil, err
+	}
+	if P.IsIdentity() || !g.E.ScalarMult(P, g.N).IsIdentity() {
+		return nil, errors.New("element is not in the group")
+	}
+	return &Element{P}, nil
+}
+
+func (g *curveGroup) ScalarLength() int { return (g.N.BitLen() + 7) / 8 }
+
+// SerializeScalar returns the big-endian encoding of k.
+func (g *curveGroup) SerializeScalar(k *big.Int) []byte {
+	return fillBytes(mod(k, g.N), g.ScalarLength())
+}
+
+func (g *curveGroup) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != g.ScalarLength() {
+		return nil, errors.New("wrong length of scalar")
+	}
+	k := new(big.Int).SetBytes(b)
+	if k.Cmp(g.N) >= 0 {
+		return nil, errors.New("scalar out of range")
+	}
+	return k, nil
+}
+
+// mapSSWU is the simplified SWU map of RFC 9380 onto y^2=x^3+Ax+B.
+func (g *curveGroup) mapSSWU(A, B, u GF.Elt) C.Point {
+	F := g.E.Field()
+	Z := g.z
+	zu2 := F.Mul(Z, F.Sqr(u))                                   // Zu^2
+	tv1 := F.Inv0(F.Add(F.Sqr(zu2), zu2))                       // 1/(Z^2u^4+Zu^2)
+	x1 := F.Mul(F.Neg(F.Mul(B, F.Inv(A))), F.Add(F.One(), tv1)) // (-B/A)(1+tv1)
+	if F.IsZero(tv1) {
+		x1 = F.Mul(B, F.Inv(F.Mul(Z, A))) // B/(ZA)
+	}
+	x, y2 := x1, rhs(F, A, B, x1)
+	if !isSquare(F, y2) {
+		x = F.Mul(zu2, x1)
+		y2 = rhs(F, A, B, x)
+	}
+	y := F.Sqrt(y2)
+	if F.Sgn0(u) != F.Sgn0(y) {
+		y = F.Neg(y)
+	}
+	return g.E.NewPoint(x, y)
+}
+
+// findZ returns Z for the simplified SWU map, as find_z_sswu of RFC 9380.
+func findZ(F GF.Field, A, B GF.Elt) GF.Elt {
+	R := poly.NewRing(F)
+	for ctr := int64(1); ; ctr++ {
+		for _, c := range []int64{ctr, -ctr} {
+			Z := F.Elt(c)
+			if isSquare(F, Z) || F.AreEqual(Z, F.Elt(-1)) {
+				continue
+			}
+			// g(x)-Z must be irreducible, that is, have no roots as it is a cubic.
+			g := R.New(F.Sub(B, Z), A, F.Zero(), F.One())
+			if len(R.Roots(g)) != 0 {
+				continue
+			}
+			if isSquare(F, rhs(F, A, B, F.Mul(B, F.Inv(F.Mul(Z, A))))) {
+				return Z
+			}
+		}
+	}
+}
+
+// rhs returns x^3+Ax+B.
+func rhs(F GF.Field, A, B, x GF.Elt) GF.Elt {
+	return F.Add(F.Mul(F.Add(F.Sqr(x), A), x), B)
+}
+
+func isSquare(F GF.Field, x GF.Elt) bool { return F.IsZero(x) || F.IsSquare(x) }
+
+func randomScalar(rnd io.Reader, n *big.Int) (*big.Int, error) {
+	k, err := rand.Int(rnd, new(big.Int).Sub(n, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// fillBytes returns x as a big-endian string of n bytes.
+func fillBytes(x *big.Int, n int) []byte {
+	b := make([]byte, n)
+	xb := x.Bytes()
+	copy(b[n-len(xb):], xb)
+	return b
+}
@@ -0,0 +1,114 @@
+package group
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+	"golang.org/x/crypto/sha3"
+)
+
+const oversizeDST = "H2C-OVERSIZE-DST-"
+
+// ExpandMessageXMD returns n pseudo-random bytes derived from msg and the
+// domain separation tag dst using the hash function h, as expand_message_xmd
+// of RFC 9380.
+func ExpandMessageXMD(h crypto.Hash, msg, dst []byte, n int) ([]byte, error) {
+	H := h.New()
+	bLen := H.Size()
+	ell := (n + bLen - 1) / bLen
+	if ell > 255 || n > 65535 {
+		return nil, errors.New("requested too many bytes")
+	}
+	if len(dst) > 255 {
+		H.Reset()
+		_, _ = H.Write([]byte(oversizeDST))
+		_, _ = H.Write(dst)
+		dst = H.Sum(nil)
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	// b0 = H(Z_pad || msg || I2OSP(n, 2) || 0 || DST_prime)
+	H.Reset()
+	_, _ = H.Write(make([]byte, H.BlockSize()))
+	_, _ = H.Write(msg)
+	_, _ = H.Write([]byte{byte(n >> 8), byte(n), 0})
+	_, _ = H.Write(dstPrime)
+	b0 := H.Sum(nil)
+
+	out := make([]byte, 0, ell*bLen)
+	bi := make([]byte, bLen)
+	for i := 1; i <= ell; i++ {
+		// bi = H(strxor(b0, b(i-1)) || I2OSP(i, 1) || DST_prime)
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		H.Reset()
+		_, _ = H.Write(bi)
+		_, _ = H.Write([]byte{byte(i)})
+		_, _ = H.Write(dstPrime)
+		bi = H.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:n], nil
+}
+
+// ExpandMessageXOF returns n pseudo-random bytes derived from msg and the
+// domain separation tag dst using SHAKE256, as expand_message_xof of RFC 9380.
+func ExpandMessageXOF(msg, dst []byte, n int) ([]byte, error) {
+	if n > 65535 {
+		return nil, errors.New("requested too many bytes")
+	}
+	if len(dst) > 255 {
+		H := sha3.NewShake256()
+		_, _ = H.Write([]byte(oversizeDST))
+		_, _ = H.Write(dst)
+		dst = make([]byte, 64)
+		_, _ = H.Read(dst)
+	}
+	H := sha3.NewShake256()
+	_, _ = H.Write(msg)
+	_, _ = H.Write([]byte{byte(n >> 8), byte(n)})
+	_, _ = H.Write(dst)
+	_, _ = H.Write([]byte{byte(len(dst))})
+	out := make([]byte, n)
+	_, _ = H.Read(out)
+	return out, nil
+}
+
+// expander returns n bytes derived from msg and dst.
+type expander func(msg, dst []byte, n int) []byte
+
+func xmd(h crypto.Hash) expander {
+	return func(msg, dst []byte, n int) []byte {
+		b, err := ExpandMessageXMD(h, msg, dst, n)
+		if err != nil {
+			panic(err)
+		}
+		return b
+	}
+}
+
+func xof(msg, dst []byte, n int) []byte {
+	b, err := ExpandMessageXOF(msg, dst, n)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// hashToField returns count elements of the prime field F, derived from L
+// bytes each, as hash_to_field of RFC 9380.
+func hashToField(F GF.Field, exp expander, msg, dst []byte, count, L int) []GF.Elt {
+	b := exp(msg, dst, count*L)
+	u := make([]GF.Elt, count)
+	for i := range u {
+		u[i] = F.Elt(new(big.Int).SetBytes(b[i*L : (i+1)*L]))
+	}
+	return u
+}
+
+// uniformLen returns the number of bytes needed to derive an integer modulo
+// m with a bias of 2^-k, which is L of RFC 9380.
+func uniformLen(m *big.Int, k int) int { return (m.BitLen() + k + 7) / 8 }
@@ -0,0 +1,61 @@
+// Package group provides a prime-order group interface for protocol code,
+// with implementations backed by elliptic curves and by the ristretto255 and
+// decaf448 groups.
+//
+// Scalars are integers modulo the order of the group. Elements are opaque,
+// and must only be used with the group that created them.
+package group
+
+import (
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// Group is a cyclic group of prime order.
+type Group interface {
+	String() string
+	// Order returns the prime order of the group.
+	Order() *big.Int
+	Identity() *Element
+	Generator() *Element
+	Add(a, b *Element) *Element
+	Neg(a *Element) *Element
+	// ScalarMult returns [k]a; k can be negative.
+	ScalarMult(a *Element, k *big.Int) *Element
+	IsEqual(a, b *Element) bool
+	IsIdentity(a *Element) bool
+	// HashToElement deterministically maps msg to an element, with domain
+	// separation tag dst.
+	HashToElement(msg, dst []byte) *Element
+	// HashToScalar deterministically maps msg to a scalar, with domain
+	// separation tag dst.
+	HashToScalar(msg, dst []byte) *big.Int
+	// RandomScalar returns a scalar sampled uniformly from [1, Order()-1].
+	RandomScalar(rnd io.Reader) (*big.Int, error)
+	// ElementLength returns the length of serialized elements.
+	ElementLength() int
+	// SerializeElement returns the canonical encoding of a, or an error if a
+	// is the identity.
+	SerializeElement(a *Element) ([]byte, error)
+	// DeserializeElement returns the element encoded in b, or an error if b
+	// is not a canonical encoding, or if it is the identity.
+	DeserializeElement(b []byte) (*Element, error)
+	// ScalarLength returns the length of serialized scalars.
+	ScalarLength() int
+	SerializeScalar(k *big.Int) []byte
+	// DeserializeScalar returns the scalar encoded in b, or an error if b is
+	// not the encoding of an integer in [0, Order()-1].
+	DeserializeScalar(b []byte) (*big.Int, error)
+}
+
+// Element is an element of a Group.
+type Element struct{ p C.Point }
+
+// Point returns the point of a. For groups of curves it is a itself, and for
+// ristretto255 and decaf448 it is one of the representatives of a.
+func (a *Element) Point() C.Point { return a.p.Copy() }
+
+// mod returns k modulo n, which is non-negative.
+func mod(k, n *big.Int) *big.Int { return new(big.Int).Mod(k, n) }
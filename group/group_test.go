@@ -0,0 +1,137 @@
+package group_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	_ "crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+func groups(t *testing.T) []group.Group {
+	gs := []group.Group{
+		group.P256(),
+		group.P384(),
+		group.P521(),
+		group.Ristretto255(),
+		group.Decaf448(),
+	}
+	// W0 hashes with simplified SWU, and E0 with try-and-increment.
+	for _, id := range []toy.ID{toy.W0, toy.E0} {
+		E, g, _ := id.New()
+		G, err := group.FromCurve(E, E.ClearCofactor(g), crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gs = append(gs, G)
+	}
+	return gs
+}
+
+func TestGroup(t *testing.T) {
+	dst := []byte("group test")
+	for _, g := range groups(t) {
+		G := g.Generator()
+		k, err := g.RandomScalar(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := g.HashToScalar([]byte("scalar"), dst)
+		if l.Sign() < 0 || l.Cmp(g.Order()) >= 0 {
+			t.Fatalf("%v: scalar out of range", g)
+		}
+		// [k]G+[l]G = [k+l]G
+		got := g.Add(g.ScalarMult(G, k), g.ScalarMult(G, l))
+		want := g.ScalarMult(G, new(big.Int).Add(k, l))
+		if !g.IsEqual(got, want) {
+			t.Fatalf("%v: [k]G+[l]G != [k+l]G", g)
+		}
+		if !g.IsIdentity(g.Add(G, g.Neg(G))) || !g.IsIdentity(g.ScalarMult(G, g.Order())) {
+			t.Fatalf("%v: G-G and [N]G must be the identity", g)
+		}
+		if !g.IsEqual(g.ScalarMult(G, big.NewInt(-1)), g.Neg(G)) {
+			t.Fatalf("%v: [-1]G != -G", g)
+		}
+
+		P := g.HashToElement([]byte("element"), dst)
+		if !g.IsEqual(P, g.HashToElement([]byte("element"), dst)) ||
+			g.IsEqual(P, g.HashToElement([]byte("element"), []byte("other"))) {
+			t.Fatalf("%v: HashToElement must be deterministic and separated by dst", g)
+		}
+		for _, Q := range []*group.Element{G, P, g.ScalarMult(G, k)} {
+			b, err := g.SerializeElement(Q)
+			if err != nil || len(b) != g.ElementLength() {
+				t.Fatalf("%v: serialization failed: %v", g, err)
+			}
+			R, err := g.DeserializeElement(b)
+			if err != nil || !g.IsEqual(Q, R) {
+				t.Fatalf("%v: round trip failed: %v", g, err)
+			}
+		}
+		if _, err := g.SerializeElement(g.Identity()); err == nil {
+			t.Fatalf("%v: the identity must not be serialized", g)
+		}
+		if _, err := g.DeserializeElement(make([]byte, g.ElementLength())); err == nil {
+			t.Fatalf("%v: the identity must not be deserialized", g)
+		}
+
+		b := g.SerializeScalar(k)
+		if k2, err := g.DeserializeScalar(b); err != nil || len(b) != g.ScalarLength() || k2.Cmp(k) != 0 {
+			t.Fatalf("%v: scalar round trip failed: %v", g, err)
+		}
+		if _, err := g.DeserializeScalar(bytes.Repeat([]byte{0xff}, g.ScalarLength())); err == nil {
+			t.Fatalf("%v: scalar out of range accepted", g)
+		}
+	}
+}
+
+func TestExpandMessage(t *testing.T) {
+	// Appendix K.1 and K.6 of RFC 9380.
+	for _, v := range []struct {
+		xof      bool
+		dst, msg string
+		n        int
+		uniform  string
+	}{
+		{false, "QUUX-V01-CS02-with-expander-SHA256-128", "", 0x20,
+			"68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{false, "QUUX-V01-CS02-with-expander-SHA256-128", "abc", 0x20,
+			"d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{true, "QUUX-V01-CS02-with-expander-SHAKE256", "", 0x20,
+			"2ffc05c48ed32b95d72e807f6eab9f7530dd1c2f013914c8fed38c5ccc15ad76"},
+		{true, "QUUX-V01-CS02-with-expander-SHAKE256", "abc", 0x20,
+			"b39e493867e2767216792abce1f2676c197c0692aed061560ead251821808e07"},
+	} {
+		var got []byte
+		var err error
+		if v.xof {
+			got, err = group.ExpandMessageXOF([]byte(v.msg), []byte(v.dst), v.n)
+		} else {
+			got, err = group.ExpandMessageXMD(crypto.SHA256, []byte(v.msg), []byte(v.dst), v.n)
+		}
+		if err != nil || hex.EncodeToString(got) != v.uniform {
+			t.Fatalf("%q: got: %x\nwant: %v", v.msg, got, v.uniform)
+		}
+	}
+}
+
+func TestHashToCurve(t *testing.T) {
+	// P256_XMD:SHA-256_SSWU_RO_ from Appendix J.1.1 of RFC 9380, given as
+	// compressed encodings.
+	g := group.P256()
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	for _, v := range []struct{ msg, P string }{
+		{"", "032c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4"},
+		{"abc", "020bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f"},
+	} {
+		b, err := g.SerializeElement(g.HashToElement([]byte(v.msg), dst))
+		if err != nil || hex.EncodeToString(b) != v.P {
+			t.Fatalf("%q: got: %x\nwant: %v", v.msg, b, v.P)
+		}
+	}
+}
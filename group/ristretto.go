@@ -0,0 +1,112 @@
+package group
+
+import (
+	"crypto"
+	_ "crypto/sha512" // for hashing to ristretto255
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/armfazh/tozan-ecc/ristretto"
+)
+
+// rGroup is a ristretto-style group, whose elements are represented by points
+// of a twisted Edwards curve.
+type rGroup struct {
+	*ristretto.Group
+	exp expander
+}
+
+// Ristretto255 returns the ristretto255 group, which hashes with
+// expand_message_xmd and SHA-512, as ristretto255_XMD:SHA-512_R255MAP_RO_ of
+// RFC 9380.
+func Ristretto255() Group { return &rGroup{ristretto.Ristretto255(), xmd(crypto.SHA512)} }
+
+// Decaf448 returns the decaf448 group, which hashes with expand_message_xof
+// and SHAKE256, as decaf448_XOF:SHAKE256_D448MAP_RO_ of RFC 9380.
+func Decaf448() Group { return &rGroup{ristretto.Decaf448(), xof} }
+
+func (g *rGroup) Identity() *Element  { return &Element{g.Group.Identity()} }
+func (g *rGroup) Generator() *Element { return &Element{g.Group.Generator()} }
+func (g *rGroup) Add(a, b *Element) *Element {
+	return &Element{g.E.Add(a.p, b.p)}
+}
+func (g *rGroup) Neg(a *Element) *Element { return &Element{g.E.Neg(a.p)} }
+func (g *rGroup) ScalarMult(a *Element, k *big.Int) *Element {
+	return &Element{g.E.ScalarMult(a.p, mod(k, g.Order()))}
+}
+func (g *rGroup) IsEqual(a, b *Element) bool { return g.Group.IsEqual(a.p, b.p) }
+func (g *rGroup) IsIdentity(a *Element) bool {
+	return g.Group.IsEqual(a.p, g.Group.Identity())
+}
+
+func (g *rGroup) HashToElement(msg, dst []byte) *Element {
+	P, err := g.FromUniformBytes(g.exp(msg, dst, g.UniformLen()))
+	if err != nil {
+		panic(err)
+	}
+	return &Element{P}
+}
+
+// HashToScalar derives 64 bytes, which are reduced modulo the order as a
+// little-endian integer.
+func (g *rGroup) HashToScalar(msg, dst []byte) *big.Int {
+	k := leToInt(g.exp(msg, dst, 64))
+	return k.Mod(k, g.Order())
+}
+
+func (g *rGroup) RandomScalar(rnd io.Reader) (*big.Int, error) {
+	return randomScalar(rnd, g.Order())
+}
+
+func (g *rGroup) ElementLength() int { return g.EncodingLen() }
+
+func (g *rGroup) SerializeElement(a *Element) ([]byte, error) {
+	if g.IsIdentity(a) {
+		return nil, errors.New("the identity can't be serialized")
+	}
+	return g.Encode(a.p), nil
+}
+
+func (g *rGroup) DeserializeElement(b []byte) (*Element, error) {
+	P, err := g.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	if g.Group.IsEqual(P, g.Group.Identity()) {
+		return nil, errors.New("element is the identity")
+	}
+	return &Element{P}, nil
+}
+
+func (g *rGroup) ScalarLength() int { return g.EncodingLen() }
+
+// SerializeScalar returns the little-endian encoding of k.
+func (g *rGroup) SerializeScalar(k *big.Int) []byte {
+	b := fillBytes(mod(k, g.Order()), g.ScalarLength())
+	reverse(b)
+	return b
+}
+
+func (g *rGroup) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != g.ScalarLength() {
+		return nil, errors.New("wrong length of scalar")
+	}
+	k := leToInt(b)
+	if k.Cmp(g.Order()) >= 0 {
+		return nil, errors.New("scalar out of range")
+	}
+	return k, nil
+}
+
+func leToInt(b []byte) *big.Int {
+	r := append([]byte{}, b...)
+	reverse(r)
+	return new(big.Int).SetBytes(r)
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,290 @@
+// Package oprf implements the oblivious pseudorandom functions of RFC 9497 in
+// its three modes: OPRF, VOPRF and POPRF.
+package oprf
+
+import (
+	"crypto"
+	_ "crypto/sha256" // for the P256 and P384 suites
+	_ "crypto/sha512" // for the ristretto255 and P521 suites
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/armfazh/tozan-ecc/group"
+	"golang.org/x/crypto/sha3"
+)
+
+// Mode is a protocol variant.
+type Mode byte
+
+const (
+	ModeOPRF  Mode = 0x00 // Oblivious PRF.
+	ModeVOPRF Mode = 0x01 // Verifiable OPRF, whose evaluations carry a proof.
+	ModePOPRF Mode = 0x02 // Partially-oblivious PRF, with a public input.
+)
+
+// Identifiers of the ciphersuites.
+const (
+	Ristretto255SHA512 = "ristretto255-SHA512"
+	Decaf448SHAKE256   = "decaf448-SHAKE256"
+	P256SHA256         = "P256-SHA256"
+	P384SHA384         = "P384-SHA384"
+	P521SHA512         = "P521-SHA512"
+)
+
+var (
+	// ErrInvalidInput is returned when an input hashes to the identity.
+	ErrInvalidInput = errors.New("input maps to the identity")
+	// ErrVerify is returned when a proof is invalid.
+	ErrVerify = errors.New("proof verification failed")
+	// ErrInverse is returned when the tweaked POPRF key is not invertible.
+	ErrInverse = errors.New("tweaked key is not invertible")
+)
+
+// Protocol is a mode of RFC 9497 instantiated with a ciphersuite.
+type Protocol struct {
+	Group group.Group
+	Mode  Mode
+
+	ctx  []byte // contextString
+	hash func(data ...[]byte) []byte
+}
+
+// New returns the protocol for a ciphersuite identifier and a mode.
+func New(id string, mode Mode) (*Protocol, error) {
+	if mode > ModePOPRF {
+		return nil, fmt.Errorf("unknown mode %v", mode)
+	}
+	p := &Protocol{Mode: mode}
+	switch id {
+	case Ristretto255SHA512:
+		p.Group, p.hash = group.Ristretto255(), hashWith(crypto.SHA512)
+	case Decaf448SHAKE256:
+		p.Group, p.hash = group.Decaf448(), shake256
+	case P256SHA256:
+		p.Group, p.hash = group.P256(), hashWith(crypto.SHA256)
+	case P384SHA384:
+		p.Group, p.hash = group.P384(), hashWith(crypto.SHA384)
+	case P521SHA512:
+		p.Group, p.hash = group.P521(), hashWith(crypto.SHA512)
+	default:
+		return nil, fmt.Errorf("unknown ciphersuite %q", id)
+	}
+	p.ctx = append([]byte("OPRFV1-"), byte(mode), '-')
+	p.ctx = append(p.ctx, id...)
+	return p, nil
+}
+
+func hashWith(h crypto.Hash) func(data ...[]byte) []byte {
+	return func(data ...[]byte) []byte {
+		H := h.New()
+		for _, d := range data {
+			_, _ = H.Write(d)
+		}
+		return H.Sum(nil)
+	}
+}
+
+func shake256(data ...[]byte) []byte {
+	H := sha3.NewShake256()
+	for _, d := range data {
+		_, _ = H.Write(d)
+	}
+	out := make([]byte, 64)
+	_, _ = H.Read(out)
+	return out
+}
+
+func (p *Protocol) hashToGroup(msg []byte) *group.Element {
+	return p.Group.HashToElement(msg, p.dst("HashToGroup-"))
+}
+
+func (p *Protocol) hashToScalar(msg []byte) *big.Int {
+	return p.Group.HashToScalar(msg, p.dst("HashToScalar-"))
+}
+
+func (p *Protocol) dst(prefix string) []byte { return append([]byte(prefix), p.ctx...) }
+
+// GenerateKeyPair returns a random private key and its public key.
+func (p *Protocol) GenerateKeyPair(rnd io.Reader) (*big.Int, *group.Element, error) {
+	sk, err := p.Group.RandomScalar(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sk, p.Group.ScalarMult(p.Group.Generator(), sk), nil
+}
+
+// DeriveKeyPair deterministically derives a key pair from a seed and public
+// information.
+func (p *Protocol) DeriveKeyPair(seed, info []byte) (*big.Int, *group.Element, error) {
+	input := append(append([]byte{}, seed...), lengthPrefix(info)...)
+	dst := p.dst("DeriveKeyPair")
+	for counter := 0; counter < 256; counter++ {
+		sk := p.Group.HashToScalar(append(input, byte(counter)), dst)
+		if sk.Sign() != 0 {
+			return sk, p.Group.ScalarMult(p.Group.Generator(), sk), nil
+		}
+	}
+	return nil, nil, errors.New("key pair derivation failed")
+}
+
+// Blind returns a random blind and the blinded element of an input.
+func (p *Protocol) Blind(input []byte, rnd io.Reader) (*big.Int, *group.Element, error) {
+	blind, err := p.Group.RandomScalar(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	blinded, err := p.DeterministicBlind(input, blind)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blind, blinded, nil
+}
+
+// DeterministicBlind returns the blinded element of an input for a given
+// blind.
+func (p *Protocol) DeterministicBlind(input []byte, blind *big.Int) (*group.Element, error) {
+	P := p.hashToGroup(input)
+	if p.Group.IsIdentity(P) {
+		return nil, ErrInvalidInput
+	}
+	return p.Group.ScalarMult(P, blind), nil
+}
+
+// BlindEvaluate evaluates the blinded elements under the private key sk. In
+// the VOPRF and POPRF modes, it also returns a proof of correct evaluation
+// for the batch using randomness from rnd; info is the public input of the
+// POPRF mode, and is ignored by the other modes.
+func (p *Protocol) BlindEvaluate(sk *big.Int, blinded []*group.Element, info []byte, rnd io.Reader) ([]*group.Element, *Proof, error) {
+	var r *big.Int
+	if p.Mode != ModeOPRF {
+		var err error
+		if r, err = p.Group.RandomScalar(rnd); err != nil {
+			return nil, nil, err
+		}
+	}
+	return p.DeterministicBlindEvaluate(sk, blinded, info, r)
+}
+
+// DeterministicBlindEvaluate is BlindEvaluate with the randomness r of the
+// proof given, which is ignored in the OPRF mode.
+func (p *Protocol) DeterministicBlindEvaluate(sk *big.Int, blinded []*group.Element, info []byte, r *big.Int) ([]*group.Element, *Proof, error) {
+	G := p.Group
+	// In the POPRF mode, evaluations are [1/t]B with t = sk+m, and the proof
+	// shows that B = [t]([1/t]B) for the tweaked key [t]G.
+	k, A := sk, G.Generator()
+	if p.Mode == ModePOPRF {
+		t, err := p.tweak(sk, info)
+		if err != nil {
+			return nil, nil, err
+		}
+		k = t
+	}
+	pk := G.ScalarMult(A, k)
+	evaluated := make([]*group.Element, len(blinded))
+	for i := range blinded {
+		if p.Mode == ModePOPRF {
+			evaluated[i] = G.ScalarMult(blinded[i], new(big.Int).ModInverse(k, G.Order()))
+		} else {
+			evaluated[i] = G.ScalarMult(blinded[i], k)
+		}
+	}
+	if p.Mode == ModeOPRF {
+		return evaluated, nil, nil
+	}
+	var proof *Proof
+	if p.Mode == ModeVOPRF {
+		proof = p.GenerateProof(k, A, pk, blinded, evaluated, r)
+	} else {
+		proof = p.GenerateProof(k, A, pk, evaluated, blinded, r)
+	}
+	return evaluated, proof, nil
+}
+
+// Finalize returns the outputs of the inputs, from their blinds, blinded
+// elements and the evaluated elements returned by the server. In the VOPRF
+// and POPRF modes, the proof is verified against the public key pk; info is
+// the public input of the POPRF mode.
+func (p *Protocol) Finalize(pk *group.Element, inputs [][]byte, blinds []*big.Int, blinded, evaluated []*group.Element, proof *Proof, info []byte) ([][]byte, error) {
+	n := len(inputs)
+	if len(blinds) != n || len(blinded) != n || len(evaluated) != n {
+		return nil, errors.New("mismatched batch sizes")
+	}
+	G := p.Group
+	switch p.Mode {
+	case ModeVOPRF:
+		if !p.VerifyProof(G.Generator(), pk, blinded, evaluated, proof) {
+			return nil, ErrVerify
+		}
+	case ModePOPRF:
+		m := p.hashToScalar(framedInfo(info))
+		tweaked := G.Add(G.ScalarMult(G.Generator(), m), pk)
+		if G.IsIdentity(tweaked) {
+			return nil, ErrInvalidInput
+		}
+		if !p.VerifyProof(G.Generator(), tweaked, evaluated, blinded, proof) {
+			return nil, ErrVerify
+		}
+	}
+	outputs := make([][]byte, n)
+	for i := range inputs {
+		inv := new(big.Int).ModInverse(blinds[i], G.Order())
+		out, err := p.output(inputs[i], G.ScalarMult(evaluated[i], inv), info)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = out
+	}
+	return outputs, nil
+}
+
+// Evaluate returns the output of an input, computed directly with the private
+// key sk; info is the public input of the POPRF mode.
+func (p *Protocol) Evaluate(sk *big.Int, input, info []byte) ([]byte, error) {
+	G := p.Group
+	P := p.hashToGroup(input)
+	if G.IsIdentity(P) {
+		return nil, ErrInvalidInput
+	}
+	k := sk
+	if p.Mode == ModePOPRF {
+		t, err := p.tweak(sk, info)
+		if err != nil {
+			return nil, err
+		}
+		k = new(big.Int).ModInverse(t, G.Order())
+	}
+	return p.output(input, G.ScalarMult(P, k), info)
+}
+
+// tweak returns the POPRF key skS+HashToScalar(framedInfo).
+func (p *Protocol) tweak(sk *big.Int, info []byte) (*big.Int, error) {
+	t := new(big.Int).Add(sk, p.hashToScalar(framedInfo(info)))
+	t.Mod(t, p.Group.Order())
+	if t.Sign() == 0 {
+		return nil, ErrInverse
+	}
+	return t, nil
+}
+
+// output returns Hash(len||input||[len||info]||len||element||"Finalize").
+func (p *Protocol) output(input []byte, P *group.Element, info []byte) ([]byte, error) {
+	elt, err := p.Group.SerializeElement(P)
+	if err != nil {
+		return nil, err
+	}
+	data := [][]byte{lengthPrefix(input)}
+	if p.Mode == ModePOPRF {
+		data = append(data, lengthPrefix(info))
+	}
+	data = append(data, lengthPrefix(elt), []byte("Finalize"))
+	return p.hash(data...), nil
+}
+
+func framedInfo(info []byte) []byte { return append([]byte("Info"), lengthPrefix(info)...) }
+
+// lengthPrefix returns I2OSP(len(b), 2)||b.
+func lengthPrefix(b []byte) []byte {
+	return append([]byte{byte(len(b) >> 8), byte(len(b))}, b...)
+}
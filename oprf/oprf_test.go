@@ -0,0 +1,211 @@
+package oprf_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/group"
+	"github.com/armfazh/tozan-ecc/oprf"
+)
+
+var suites = []string{
+	oprf.Ristretto255SHA512,
+	oprf.Decaf448SHAKE256,
+	oprf.P256SHA256,
+	oprf.P384SHA384,
+	oprf.P521SHA512,
+}
+
+var modes = []oprf.Mode{oprf.ModeOPRF, oprf.ModeVOPRF, oprf.ModePOPRF}
+
+func TestVectors(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+	keyInfo := []byte("test key")
+	for _, v := range vectors {
+		p, err := oprf.New(v.id, v.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		G := p.Group
+		sk, pk, err := p.DeriveKeyPair(seed, keyInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(G.SerializeScalar(sk)); got != v.sk {
+			t.Fatalf("%v/%v: got sk: %v\nwant: %v", v.id, v.mode, got, v.sk)
+		}
+		if v.mode != oprf.ModeOPRF {
+			b, err := G.SerializeElement(pk)
+			if got := hex.EncodeToString(b); err != nil || got != v.pk {
+				t.Fatalf("%v/%v: got pk: %v\nwant: %v", v.id, v.mode, got, v.pk)
+			}
+		}
+		for i, w := range v.tests {
+			inputs := splitHex(t, w.input)
+			info := mustHex(t, w.info)
+			blinds := make([]*big.Int, len(inputs))
+			blinded := make([]*group.Element, len(inputs))
+			for j, b := range splitHex(t, w.blind) {
+				if blinds[j], err = G.DeserializeScalar(b); err != nil {
+					t.Fatal(err)
+				}
+				if blinded[j], err = p.DeterministicBlind(inputs[j], blinds[j]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			checkElements(t, G, blinded, w.blinded)
+
+			var r *big.Int
+			if w.r != "" {
+				if r, err = G.DeserializeScalar(mustHex(t, w.r)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			evaluated, proof, err := p.DeterministicBlindEvaluate(sk, blinded, info, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkElements(t, G, evaluated, w.evaluated)
+			if proof != nil {
+				if got := hex.EncodeToString(p.SerializeProof(proof)); got != w.proof {
+					t.Fatalf("%v/%v/%v: got proof: %v\nwant: %v", v.id, v.mode, i, got, w.proof)
+				}
+			}
+
+			outputs, err := p.Finalize(pk, inputs, blinds, blinded, evaluated, proof, info)
+			if err != nil {
+				t.Fatalf("%v/%v/%v: %v", v.id, v.mode, i, err)
+			}
+			want := splitHex(t, w.output)
+			for j := range inputs {
+				direct, err := p.Evaluate(sk, inputs[j], info)
+				if err != nil || !bytes.Equal(outputs[j], want[j]) || !bytes.Equal(direct, want[j]) {
+					t.Fatalf("%v/%v/%v: got output: %x\nwant: %x", v.id, v.mode, i, outputs[j], want[j])
+				}
+			}
+		}
+	}
+}
+
+// checkElements compares the serialization of elements with the
+// comma-separated hex encodings in want.
+func checkElements(t *testing.T, G group.Group, elements []*group.Element, want string) {
+	t.Helper()
+	got := make([]string, len(elements))
+	for i := range elements {
+		b, err := G.SerializeElement(elements[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[i] = hex.EncodeToString(b)
+	}
+	if g := strings.Join(got, ","); g != want {
+		t.Fatalf("%v: got: %v\nwant: %v", G, g, want)
+	}
+}
+
+func splitHex(t *testing.T, s string) [][]byte {
+	parts := strings.Split(s, ",")
+	out := make([][]byte, len(parts))
+	for i := range parts {
+		out[i] = mustHex(t, parts[i])
+	}
+	return out
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestProtocol(t *testing.T) {
+	inputs := [][]byte{{0x00}, bytes.Repeat([]byte{0x5a}, 17)}
+	info := []byte("test info")
+	for _, id := range suites {
+		for _, mode := range modes {
+			p, err := oprf.New(id, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sk, pk, err := p.GenerateKeyPair(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blinds := make([]*big.Int, len(inputs))
+			blinded := make([]*group.Element, len(inputs))
+			for i, in := range inputs {
+				if blinds[i], blinded[i], err = p.Blind(in, rand.Reader); err != nil {
+					t.Fatal(err)
+				}
+			}
+			evaluated, proof, err := p.BlindEvaluate(sk, blinded, info, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (proof == nil) != (mode == oprf.ModeOPRF) {
+				t.Fatalf("%v/%v: unexpected proof", id, mode)
+			}
+			if proof != nil {
+				if proof, err = p.DeserializeProof(p.SerializeProof(proof)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			outputs, err := p.Finalize(pk, inputs, blinds, blinded, evaluated, proof, info)
+			if err != nil {
+				t.Fatalf("%v/%v: %v", id, mode, err)
+			}
+			for i, in := range inputs {
+				want, err := p.Evaluate(sk, in, info)
+				if err != nil || !bytes.Equal(outputs[i], want) {
+					t.Fatalf("%v/%v: Finalize and Evaluate differ: %v", id, mode, err)
+				}
+			}
+			if mode == oprf.ModeOPRF {
+				continue
+			}
+
+			// Swapping evaluations or changing the public input must be detected.
+			evaluated[0], evaluated[1] = evaluated[1], evaluated[0]
+			if _, err := p.Finalize(pk, inputs, blinds, blinded, evaluated, proof, info); err != oprf.ErrVerify {
+				t.Fatalf("%v/%v: swapped evaluations accepted", id, mode)
+			}
+			evaluated[0], evaluated[1] = evaluated[1], evaluated[0]
+			if mode == oprf.ModePOPRF {
+				if _, err := p.Finalize(pk, inputs, blinds, blinded, evaluated, proof, []byte("other")); err != oprf.ErrVerify {
+					t.Fatalf("%v/%v: wrong info accepted", id, mode)
+				}
+			}
+		}
+	}
+}
+
+func TestProof(t *testing.T) {
+	p, err := oprf.New(oprf.P256SHA256, oprf.ModeVOPRF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	G := p.Group
+	A := G.Generator()
+	k, B, _ := p.GenerateKeyPair(rand.Reader)
+	C := []*group.Element{G.HashToElement([]byte("C0"), nil), G.HashToElement([]byte("C1"), nil)}
+	D := []*group.Element{G.ScalarMult(C[0], k), G.ScalarMult(C[1], k)}
+	r, _ := G.RandomScalar(rand.Reader)
+	proof := p.GenerateProof(k, A, B, C, D, r)
+	if !p.VerifyProof(A, B, C, D, proof) {
+		t.Fatal("valid proof rejected")
+	}
+	D[1] = G.Add(D[1], A)
+	if p.VerifyProof(A, B, C, D, proof) || p.VerifyProof(A, B, C, D, p.GenerateProof(k, A, B, C, D, r)) {
+		t.Fatal("proof of a false statement accepted")
+	}
+	if p.VerifyProof(A, B, C[:1], D, proof) || p.VerifyProof(A, B, C, D, nil) {
+		t.Fatal("malformed proof accepted")
+	}
+}
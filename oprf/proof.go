@@ -0,0 +1,113 @@
+package oprf
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+// Proof is a batched proof of discrete-log equality, showing that B = [k]A
+// and D[i] = [k]C[i] for all i, without revealing k.
+type Proof struct{ C, S *big.Int }
+
+// SerializeProof returns the encoding of the challenge followed by the
+// response.
+func (p *Protocol) SerializeProof(proof *Proof) []byte {
+	return append(p.Group.SerializeScalar(proof.C), p.Group.SerializeScalar(proof.S)...)
+}
+
+// DeserializeProof parses the encoding of a proof.
+func (p *Protocol) DeserializeProof(b []byte) (*Proof, error) {
+	n := p.Group.ScalarLength()
+	if len(b) != 2*n {
+		return nil, errors.New("wrong length of proof")
+	}
+	c, err := p.Group.DeserializeScalar(b[:n])
+	if err != nil {
+		return nil, err
+	}
+	s, err := p.Group.DeserializeScalar(b[n:])
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{c, s}, nil
+}
+
+// GenerateProof returns a proof that B = [k]A and D[i] = [k]C[i], using r as
+// the randomness, which must be a fresh random scalar.
+func (p *Protocol) GenerateProof(k *big.Int, A, B *group.Element, C, D []*group.Element, r *big.Int) *Proof {
+	G := p.Group
+	M, Z := p.composites(k, B, C, D)
+	c := p.challenge(B, M, Z, G.ScalarMult(A, r), G.ScalarMult(M, r))
+	s := new(big.Int).Mul(c, k)
+	s.Sub(r, s).Mod(s, G.Order())
+	return &Proof{c, s}
+}
+
+// VerifyProof reports whether proof shows that B = [k]A and D[i] = [k]C[i]
+// for some k.
+func (p *Protocol) VerifyProof(A, B *group.Element, C, D []*group.Element, proof *Proof) bool {
+	G := p.Group
+	if proof == nil || len(C) != len(D) {
+		return false
+	}
+	M, Z := p.composites(nil, B, C, D)
+	t2 := G.Add(G.ScalarMult(A, proof.S), G.ScalarMult(B, proof.C))
+	t3 := G.Add(G.ScalarMult(M, proof.S), G.ScalarMult(Z, proof.C))
+	return p.challenge(B, M, Z, t2, t3).Cmp(proof.C) == 0
+}
+
+// composites returns the random linear combinations M of C and Z of D, as
+// ComputeComposites of RFC 9497. If the prover's k is given, Z is computed
+// as [k]M instead.
+func (p *Protocol) composites(k *big.Int, B *group.Element, C, D []*group.Element) (M, Z *group.Element) {
+	G := p.Group
+	seed := p.hash(lengthPrefix(p.serialize(B)), lengthPrefix(p.dst("Seed-")))
+	M, Z = G.Identity(), G.Identity()
+	for i := range C {
+		di := p.hashToScalar(concat(
+			lengthPrefix(seed),
+			[]byte{byte(i >> 8), byte(i)},
+			lengthPrefix(p.serialize(C[i])),
+			lengthPrefix(p.serialize(D[i])),
+			[]byte("Composite"),
+		))
+		M = G.Add(G.ScalarMult(C[i], di), M)
+		if k == nil {
+			Z = G.Add(G.ScalarMult(D[i], di), Z)
+		}
+	}
+	if k != nil {
+		Z = G.ScalarMult(M, k)
+	}
+	return M, Z
+}
+
+func (p *Protocol) challenge(elts ...*group.Element) *big.Int {
+	var transcript [][]byte
+	for _, e := range elts {
+		transcript = append(transcript, lengthPrefix(p.serialize(e)))
+	}
+	transcript = append(transcript, []byte("Challenge"))
+	return p.hashToScalar(concat(transcript...))
+}
+
+// serialize encodes an element, where the identity is encoded as the empty
+// string; it only appears in transcripts of malformed batches, which then
+// fail to verify.
+func (p *Protocol) serialize(e *group.Element) []byte {
+	b, err := p.Group.SerializeElement(e)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func concat(data ...[]byte) []byte {
+	var out []byte
+	for _, d := range data {
+		out = append(out, d...)
+	}
+	return out
+}
@@ -0,0 +1,823 @@
+package oprf_test
+
+import "github.com/armfazh/tozan-ecc/oprf"
+
+// vector is a test vector of Appendix A of RFC 9497. Batched inputs, blinds
+// and elements are separated by commas.
+type vector struct {
+	input, info, blind, blinded, evaluated, proof, r, output string
+}
+
+// vectors are the test vectors of Appendix A of RFC 9497, whose key pairs are
+// derived from Seed = 0xa3...a3 and KeyInfo = "test key". The public key is
+// empty in the OPRF mode.
+var vectors = []struct {
+	id     string
+	mode   oprf.Mode
+	sk, pk string
+	tests  []vector
+}{
+	{oprf.Ristretto255SHA512, oprf.ModeOPRF,
+		"5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e",
+		"",
+		[]vector{
+			{
+				input:     "00",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "609a0ae68c15a3cf6903766461307e5c8bb2f95e7e6550e1ffa2dc99e412803c",
+				evaluated: "7ec6578ae5120958eb2db1745758ff379e77cb64fe77b0b2d8cc917ea0869c7e",
+				output: "527759c3d9366f277d8c6020418d96bb393ba2afb20ff90df23fb7708264e2f3" +
+					"ab9135e3bd69955851de4b1f9fe8a0973396719b7912ba9ee8aa7d0b5e24bcf6",
+			},
+			{
+				input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "da27ef466870f5f15296299850aa088629945a17d1f5b7f5ff043f76b3c06418",
+				evaluated: "b4cbf5a4f1eeda5a63ce7b77c7d23f461db3fcab0dd28e4e17cecb5c90d02c25",
+				output: "f4a74c9c592497375e796aa837e907b1a045d34306a749db9f34221f7e750cb4" +
+					"f2a6413a6bf6fa5e19ba6348eb673934a722a7ede2e7621306d18951e7cf2c73",
+			},
+		},
+	},
+	{oprf.Ristretto255SHA512, oprf.ModeVOPRF,
+		"e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909",
+		"c803e2cc6b05fc15064549b5920659ca4a77b2cca6f04f6b357009335476ad4e",
+		[]vector{
+			{
+				input:     "00",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "863f330cc1a1259ed5a5998a23acfd37fb4351a793a5b3c090b642ddc439b945",
+				evaluated: "aa8fa048764d5623868679402ff6108d2521884fa138cd7f9c7669a9a014267e",
+				proof: "ddef93772692e535d1a53903db24367355cc2cc78de93b3be5a8ffcc6985dd06" +
+					"6d4346421d17bf5117a2a1ff0fcb2a759f58a539dfbe857a40bce4cf49ec600d",
+				r: "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				output: "b58cfbe118e0cb94d79b5fd6a6dafb98764dff49c14e1770b566e42402da1a7d" +
+					"a4d8527693914139caee5bd03903af43a491351d23b430948dd50cde10d32b3c",
+			},
+			{
+				input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "cc0b2a350101881d8a4cba4c80241d74fb7dcbfde4a61fde2f91443c2bf9ef0c",
+				evaluated: "60a59a57208d48aca71e9e850d22674b611f752bed48b36f7a91b372bd7ad468",
+				proof: "401a0da6264f8cf45bb2f5264bc31e109155600babb3cd4e5af7d181a2c9dc0a" +
+					"67154fabf031fd936051dec80b0b6ae29c9503493dde7393b722eafdf5a50b02",
+				r: "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				output: "8a9a2f3c7f085b65933594309041fc1898d42d0858e59f90814ae90571a6df60" +
+					"356f4610bf816f27afdd84f47719e480906d27ecd994985890e5f539e7ea74b6",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706" +
+					"," +
+					"222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				blinded: "863f330cc1a1259ed5a5998a23acfd37fb4351a793a5b3c090b642ddc439b945" +
+					"," +
+					"90a0145ea9da29254c3a56be4fe185465ebb3bf2a1801f7124bbbadac751e654",
+				evaluated: "aa8fa048764d5623868679402ff6108d2521884fa138cd7f9c7669a9a014267e" +
+					"," +
+					"cc5ac221950a49ceaa73c8db41b82c20372a4c8d63e5dded2db920b7eee36a2a",
+				proof: "cc203910175d786927eeb44ea847328047892ddf8590e723c37205cb74600b0a" +
+					"5ab5337c8eb4ceae0494c2cf89529dcf94572ed267473d567aeed6ab873dee08",
+				r: "419c4f4f5052c53c45f3da494d2b67b220d02118e0857cdbcf037f9ea84bbe0c",
+				output: "b58cfbe118e0cb94d79b5fd6a6dafb98764dff49c14e1770b566e42402da1a7d" +
+					"a4d8527693914139caee5bd03903af43a491351d23b430948dd50cde10d32b3c" +
+					"," +
+					"8a9a2f3c7f085b65933594309041fc1898d42d0858e59f90814ae90571a6df60" +
+					"356f4610bf816f27afdd84f47719e480906d27ecd994985890e5f539e7ea74b6",
+			},
+		},
+	},
+	{oprf.Ristretto255SHA512, oprf.ModePOPRF,
+		"145c79c108538421ac164ecbe131942136d5570b16d8bf41a24d4337da981e07",
+		"c647bef38497bc6ec077c22af65b696efa43bff3b4a1975a3e8e0a1c5a79d631",
+		[]vector{
+			{
+				input:     "00",
+				info:      "7465737420696e666f",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "c8713aa89241d6989ac142f22dba30596db635c772cbf25021fdd8f3d461f715",
+				evaluated: "1a4b860d808ff19624731e67b5eff20ceb2df3c3c03b906f5693e2078450d874",
+				proof: "41ad1a291aa02c80b0915fbfbb0c0afa15a57e2970067a602ddb9e8fd6b7100d" +
+					"e32e1ecff943a36f0b10e3dae6bd266cdeb8adf825d86ef27dbc6c0e30c52206",
+				r: "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				output: "ca688351e88afb1d841fde4401c79efebb2eb75e7998fa9737bd5a82a152406d" +
+					"38bd29f680504e54fd4587eddcf2f37a2617ac2fbd2993f7bdf45442ace7d221",
+			},
+			{
+				input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info:      "7465737420696e666f",
+				blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+				blinded:   "f0f0b209dd4d5f1844dac679acc7761b91a2e704879656cb7c201e82a99ab07d",
+				evaluated: "8c3c9d064c334c6991e99f286ea2301d1bde170b54003fb9c44c6d7bd6fc1540",
+				proof: "4c39992d55ffba38232cdac88fe583af8a85441fefd7d1d4a8d0394cd1de7701" +
+					"8bf135c174f20281b3341ab1f453fe72b0293a7398703384bed822bfdeec8908",
+				r: "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				output: "7c6557b276a137922a0bcfc2aa2b35dd78322bd500235eb6d6b6f91bc5b56a52" +
+					"de2d65612d503236b321f5d0bebcbc52b64b92e426f29c9b8b69f52de98ae507",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info: "7465737420696e666f",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706" +
+					"," +
+					"222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+				blinded: "c8713aa89241d6989ac142f22dba30596db635c772cbf25021fdd8f3d461f715" +
+					"," +
+					"423a01c072e06eb1cce96d23acce06e1ea64a609d7ec9e9023f3049f2d64e50c",
+				evaluated: "1a4b860d808ff19624731e67b5eff20ceb2df3c3c03b906f5693e2078450d874" +
+					"," +
+					"aa1f16e903841036e38075da8a46655c94fc92341887eb5819f46312adfc0504",
+				proof: "43fdb53be399cbd3561186ae480320caa2b9f36cca0e5b160c4a677b8bbf4301" +
+					"b28f12c36aa8e11e5a7ef551da0781e863a6dc8c0b2bf5a149c9e00621f02006",
+				r: "419c4f4f5052c53c45f3da494d2b67b220d02118e0857cdbcf037f9ea84bbe0c",
+				output: "ca688351e88afb1d841fde4401c79efebb2eb75e7998fa9737bd5a82a152406d" +
+					"38bd29f680504e54fd4587eddcf2f37a2617ac2fbd2993f7bdf45442ace7d221" +
+					"," +
+					"7c6557b276a137922a0bcfc2aa2b35dd78322bd500235eb6d6b6f91bc5b56a52" +
+					"de2d65612d503236b321f5d0bebcbc52b64b92e426f29c9b8b69f52de98ae507",
+			},
+		},
+	},
+	{oprf.Decaf448SHAKE256, oprf.ModeOPRF,
+		"e8b1375371fd11ebeb224f832dcc16d371b4188951c438f751425699ed29ecc8" +
+			"0c6c13e558ccd67634fd82eac94aa8d1f0d7fee990695d1e",
+		"",
+		[]vector{
+			{
+				input: "00",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "e0ae01c4095f08e03b19baf47ffdc19cb7d98e583160522a3c7d6a0b2111cd93" +
+					"a126a46b7b41b730cd7fc943d4e28e590ed33ae475885f6c",
+				evaluated: "50ce4e60eed006e22e7027454b5a4b8319eb2bc8ced609eb19eb3ad42fb19e06" +
+					"ba12d382cbe7ae342a0cad6ead0ef8f91f00bb7f0cd9c0a2",
+				output: "37d3f7922d9388a15b561de5829bbf654c4089ede89c0ce0f3f85bcdba09e382" +
+					"ce0ab3507e021f9e79706a1798ffeac68ebd5cf62e5eb9838c7068351d97ae37",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "86a88dc5c6331ecfcb1d9aacb50a68213803c462e377577cacc00af28e15f0dd" +
+					"bc2e3d716f2f39ef95f3ec1314a2c64d940a9f295d8f13bb",
+				evaluated: "162e9fa6e9d527c3cd734a31bf122a34dbd5bcb7bb23651f1768a7a9274cc116" +
+					"c03b58afa6f0dede3994a60066c76370e7328e7062fd5819",
+				output: "a2a652290055cb0f6f8637a249ee45e32ef4667db0b4c80c0a70d2a64164d015" +
+					"25cfdad5d870a694ec77972b9b6ec5d2596a5223e5336913f945101f0137f55e",
+			},
+		},
+	},
+	{oprf.Decaf448SHAKE256, oprf.ModeVOPRF,
+		"e3c01519a076a326a0eb566343e9b21c115fa18e6e85577ddbe890b33104fcc2" +
+			"835ddfb14a928dc3f5d79b936e17c76b99e0bf6a1680930e",
+		"945fc518c47695cf65217ace04b86ac5e4cbe26ca649d52854bb16c494ce0906" +
+			"9d6add96b20d4b0ae311a87c9a73e3a146b525763ab2f955",
+		[]vector{
+			{
+				input: "00",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "7261bbc335c664ba788f1b1a1a4cd5190cc30e787ef277665ac1d314f8861e3e" +
+					"c11854ce3ddd42035d9e0f5cddde324c332d8c880abc00eb",
+				evaluated: "ca1491a526c28d880806cf0fb0122222392cf495657be6e4c9d203bceffa46c8" +
+					"6406caf8217859d3fb259077af68e5d41b3699410781f467",
+				proof: "f84bbeee47aedf43558dae4b95b3853635a9fc1a9ea7eac9b454c64c66c4f49c" +
+					"d1c72711c7ac2e06c681e16ea693d5500bbd7b56455df52f69e00b76b4126961" +
+					"e1562fdbaaac40b7701065cbeece3febbfe09e00160f81775d36daed99d8a2a1" +
+					"0be0759e01b7ee81217203416c9db208",
+				r: "b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				output: "e2ac40b634f36cccd8262b285adff7c9dcc19cd308564a5f4e581d1a8535773b" +
+					"86fa4fc9f2203c370763695c5093aea4a7aedec4488b1340ba3bf663a23098c1",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "88287e553939090b888ddc15913e1807dc4757215555e1c3a79488ef31159472" +
+					"9c7fa74c772a732b78440b7d66d0aa35f3bb316f1d93e1b2",
+				evaluated: "c00978c73e8e4ee1d447ab0d3ad1754055e72cc85c08e3a0db170909a9c61cbf" +
+					"f1f1e7015f289e3038b0f341faea5d7780c130106065c231",
+				proof: "7a2831a6b237e11ac1657d440df93bc5ce00f552e6020a99d5c956ffc4d07b5a" +
+					"de3e82ecdc257fd53d76239e733e0a1313e84ce16cc0d82734806092a693d7e8" +
+					"d3c420c2cb6ccd5d0ca32514fb78e9ad0973ebdcb52eba438fc73948d76339ee" +
+					"710121d83e2fe6f001cfdf551aff9f36",
+				r: "b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				output: "862952380e07ec840d9f6e6f909c5a25d16c3dacb586d89a181b4aa7380c959b" +
+					"aa8c480fe8e6c64e089d68ea7aeeb5817bd524d7577905b5bab487690048c941",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112," +
+					"b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				blinded: "7261bbc335c664ba788f1b1a1a4cd5190cc30e787ef277665ac1d314f8861e3e" +
+					"c11854ce3ddd42035d9e0f5cddde324c332d8c880abc00eb," +
+					"2e15f393c035492a1573627a3606e528c6294c767c8d43b8c691ef70a52cc7dc" +
+					"7d1b53fe458350a270abb7c231b87ba58266f89164f714d9",
+				evaluated: "ca1491a526c28d880806cf0fb0122222392cf495657be6e4c9d203bceffa46c8" +
+					"6406caf8217859d3fb259077af68e5d41b3699410781f467," +
+					"8ec68e9871b296e81c55647ce64a04fe75d19932f1400544cd601468c60f9984" +
+					"08bbb546601d4a636e8be279e558d70b95c8d4a4f61892be",
+				proof: "167d922f0a6ffa845eed07f8aa97b6ac746d902ecbeb18f49c009adc0521eab1" +
+					"e4d275b74a2dc266b7a194c854e85e7eb54a9a36376dfc04ec7f3bd55fc9618c" +
+					"3970cb548e064f8a2f06183a5702933dbc3e4c25a73438f2108ee1981c306181" +
+					"003c7ea92fce963ec7b4ba4f270e6d38",
+				r: "63798726803c9451ba405f00ef3acb633ddf0c420574a2ec6cbf28f840800e35" +
+					"5c9fbaac10699686de2724ed22e797a00f3bd93d105a7f23",
+				output: "e2ac40b634f36cccd8262b285adff7c9dcc19cd308564a5f4e581d1a8535773b" +
+					"86fa4fc9f2203c370763695c5093aea4a7aedec4488b1340ba3bf663a23098c1" +
+					"," +
+					"862952380e07ec840d9f6e6f909c5a25d16c3dacb586d89a181b4aa7380c959b" +
+					"aa8c480fe8e6c64e089d68ea7aeeb5817bd524d7577905b5bab487690048c941",
+			},
+		},
+	},
+	{oprf.Decaf448SHAKE256, oprf.ModePOPRF,
+		"792a10dcbd3ba4a52a054f6f39186623208695301e7adb9634b74709ab22de40" +
+			"2990eb143fd7c67ac66be75e0609705ecea800992aac8e19",
+		"6c9d12723a5bbcf305522cc04b4a34d9ced2e12831826018ea7b5dcf5452647a" +
+			"d262113059bf0f6e4354319951b9d513c74f29cb0eec38c1",
+		[]vector{
+			{
+				input: "00",
+				info:  "7465737420696e666f",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "161183c13c6cb33b0e4f9b7365f8c5c12d13c72f8b62d276ca09368d093dce9b" +
+					"42198276b9e9d870ac392dda53efd28d1b7e6e8c060cdc42",
+				evaluated: "06ec89dfde25bb2a6f0145ac84b91ac277b35de39ad1d6f402a8e46414952ce0" +
+					"d9ea1311a4ece283e2b01558c7078b040cfaa40dd63b3e6c",
+				proof: "66caee75bf2460429f620f6ad3e811d524cb8ddd848a435fc5d89af48877abf6" +
+					"506ee341a0b6f67c2d76cd021e5f3d1c9abe5aa9f0dce016da746135fedba2af" +
+					"41ed1d01659bfd6180d96bc1b7f320c0cb6926011ce392ecca748662564892ba" +
+					"e66516acaac6ca39aadf6fcca95af406",
+				r: "b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				output: "4423f6dcc1740688ea201de57d76824d59cd6b859e1f9884b7eebc49b0b97135" +
+					"8cf9cb075df1536a8ea31bcf55c3e31c2ba9cfa8efe54448d17091daeb9924ed",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info:  "7465737420696e666f",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112",
+				blinded: "12082b6a381c6c51e85d00f2a3d828cdeab3f5cb19a10b9c014c33826764ab7e" +
+					"7cfb8b4ff6f411bddb2d64e62a472af1cd816e5b712790c6",
+				evaluated: "f2919b7eedc05ab807c221fce2b12c4ae9e19e6909c4784564b690d1972d2994" +
+					"ca623f273afc67444d84ea40cbc58fcdab7945f321a52848",
+				proof: "a295677c54d1bc4286330907fc2490a7de163da26f9ce03a462a452fea422b19" +
+					"ade296ba031359b3b6841e48455d20519ad01b4ac4f0b92e76d3cf16fbef0a3f" +
+					"72791a8401ef2d7081d361e502e96b2c60608b9fa566f43d4611c2f161d83aab" +
+					"ef7f8017332b26ed1daaf80440772022",
+				r: "b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				output: "8691905500510843902c44bdd9730ab9dc3925aa58ff9dd42765a2baf633126d" +
+					"e0c3adb93bef5652f38e5827b6396e87643960163a560fc4ac9738c8de4e4a8d",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info: "7465737420696e666f",
+				blind: "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833" +
+					"a26e9388336361686ff1f83df55046504dfecad8549ba112," +
+					"b1b748135d405ce48c6973401d9455bb8ccd18b01d0295c0627f67661200dbf9" +
+					"569f73fbb3925daa043a070e5f953d80bb464ea369e5522b",
+				blinded: "161183c13c6cb33b0e4f9b7365f8c5c12d13c72f8b62d276ca09368d093dce9b" +
+					"42198276b9e9d870ac392dda53efd28d1b7e6e8c060cdc42," +
+					"fc8847d43fb4cea4e408f585661a8f2867533fa91d22155d3127a22f18d3b007" +
+					"add480f7d300bca93fa47fe87ae06a57b7d0f0d4c30b12f0",
+				evaluated: "06ec89dfde25bb2a6f0145ac84b91ac277b35de39ad1d6f402a8e46414952ce0" +
+					"d9ea1311a4ece283e2b01558c7078b040cfaa40dd63b3e6c," +
+					"2e74c626d07de49b1c8c21d87120fd78105f485e36816af9bde3e3efbeef7681" +
+					"5326062fd333925b66c5ce5a20f100bf01770c16609f990a",
+				proof: "fd94db736f97ea4efe9d0d4ad2933072697a6bbeb32834057b23edf7c7009f01" +
+					"1dfa72157f05d2a507c2bbf0b54cad99ab99de05921c021fda7d70e65bcecdb0" +
+					"5f9a30154127ace983c74d10fd910b554c5e95f6bd1565fd1f3dbbe3c523ece5" +
+					"c72d57a559b7be1368c4786db4a3c910",
+				r: "63798726803c9451ba405f00ef3acb633ddf0c420574a2ec6cbf28f840800e35" +
+					"5c9fbaac10699686de2724ed22e797a00f3bd93d105a7f23",
+				output: "4423f6dcc1740688ea201de57d76824d59cd6b859e1f9884b7eebc49b0b97135" +
+					"8cf9cb075df1536a8ea31bcf55c3e31c2ba9cfa8efe54448d17091daeb9924ed" +
+					"," +
+					"8691905500510843902c44bdd9730ab9dc3925aa58ff9dd42765a2baf633126d" +
+					"e0c3adb93bef5652f38e5827b6396e87643960163a560fc4ac9738c8de4e4a8d",
+			},
+		},
+	},
+	{oprf.P256SHA256, oprf.ModeOPRF,
+		"159749d750713afe245d2d39ccfaae8381c53ce92d098a9375ee70739c7ac0bf",
+		"",
+		[]vector{
+			{
+				input: "00",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "03723a1e5c09b8b9c18d1dcbca29e8007e95f14f4732d9346d490ffc19511036" +
+					"8d",
+				evaluated: "030de02ffec47a1fd53efcdd1c6faf5bdc270912b8749e783c7ca75bb4129588" +
+					"32",
+				output: "a0b34de5fa4c5b6da07e72af73cc507cceeb48981b97b7285fc375345fe495dd",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "03cc1df781f1c2240a64d1c297b3f3d16262ef5d4cf102734882675c26231b08" +
+					"38",
+				evaluated: "03a0395fe3828f2476ffcd1f4fe540e5a8489322d398be3c4e5a869db7fcb7c5" +
+					"2c",
+				output: "c748ca6dd327f0ce85f4ae3a8cd6d4d5390bbb804c9e12dcf94f853fece3dcce",
+			},
+		},
+	},
+	{oprf.P256SHA256, oprf.ModeVOPRF,
+		"ca5d94c8807817669a51b196c34c1b7f8442fde4334a7121ae4736364312fca6",
+		"03e17e70604bcabe198882c0a1f27a92441e774224ed9c702e51dd17038b1024" +
+			"62",
+		[]vector{
+			{
+				input: "00",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "02dd05901038bb31a6fae01828fd8d0e49e35a486b5c5d4b4994013648c01277" +
+					"da",
+				evaluated: "0209f33cab60cf8fe69239b0afbcfcd261af4c1c5632624f2e9ba29b90ae83e4" +
+					"a2",
+				proof: "e7c2b3c5c954c035949f1f74e6bce2ed539a3be267d1481e9ddb178533df4c26" +
+					"64f69d065c604a4fd953e100b856ad83804eb3845189babfa5a702090d6fc5fa",
+				r:      "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				output: "0412e8f78b02c415ab3a288e228978376f99927767ff37c5718d420010a645a1",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "03cd0f033e791c4d79dfa9c6ed750f2ac009ec46cd4195ca6fd3800d1e9b887d" +
+					"bd",
+				evaluated: "030d2985865c693bf7af47ba4d3a3813176576383d19aff003ef7b0784a0d83c" +
+					"f1",
+				proof: "2787d729c57e3d9512d3aa9e8708ad226bc48e0f1750b0767aaff73482c44b8d" +
+					"2873d74ec88aebd3504961acea16790a05c542d9fbff4fe269a77510db00abab",
+				r:      "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				output: "771e10dcd6bcd3664e23b8f2a710cfaaa8357747c4a8cbba03133967b5c24f18",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364" +
+					"," +
+					"f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				blinded: "02dd05901038bb31a6fae01828fd8d0e49e35a486b5c5d4b4994013648c01277" +
+					"da," +
+					"03462e9ae64cae5b83ba98a6b360d942266389ac369b923eb3d557213b1922f8" +
+					"ab",
+				evaluated: "0209f33cab60cf8fe69239b0afbcfcd261af4c1c5632624f2e9ba29b90ae83e4" +
+					"a2," +
+					"02bb24f4d838414aef052a8f044a6771230ca69c0a5677540fff738dd31bb697" +
+					"71",
+				proof: "bdcc351707d02a72ce49511c7db990566d29d6153ad6f8982fad2b435d6ce4d6" +
+					"0da1e6b3fa740811bde34dd4fe0aa1b5fe6600d0440c9ddee95ea7fad7a60cf2",
+				r: "350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c8026877963",
+				output: "0412e8f78b02c415ab3a288e228978376f99927767ff37c5718d420010a645a1" +
+					"," +
+					"771e10dcd6bcd3664e23b8f2a710cfaaa8357747c4a8cbba03133967b5c24f18",
+			},
+		},
+	},
+	{oprf.P256SHA256, oprf.ModePOPRF,
+		"6ad2173efa689ef2c27772566ad7ff6e2d59b3b196f00219451fb2c89ee4dae2",
+		"030d7ff077fddeec965db14b794f0cc1ba9019b04a2f4fcc1fa525dedf72e2a3" +
+			"e3",
+		[]vector{
+			{
+				input: "00",
+				info:  "7465737420696e666f",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "031563e127099a8f61ed51eeede05d747a8da2be329b40ba1f0db0b2bd9dd4e2" +
+					"c0",
+				evaluated: "02c5e5300c2d9e6ba7f3f4ad60500ad93a0157e6288eb04b67e125db024a2c74" +
+					"d2",
+				proof: "f8a33690b87736c854eadfcaab58a59b8d9c03b569110b6f31f8bf7577f3fbb8" +
+					"5a8a0c38468ccde1ba942be501654adb106167c8eb178703ccb42bccffb9231a",
+				r:      "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				output: "193a92520bd8fd1f37accb918040a57108daa110dc4f659abe212636d245c592",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info:  "7465737420696e666f",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "021a440ace8ca667f261c10ac7686adc66a12be31e3520fca317643a1eee9dcd" +
+					"4d",
+				evaluated: "0208ca109cbae44f4774fc0bdd2783efdcb868cb4523d52196f700210e777c5d" +
+					"e3",
+				proof: "043a8fb7fc7fd31e35770cabda4753c5bf0ecc1e88c68d7d35a62bf2631e875a" +
+					"f4613641be2d1875c31d1319d191c4bbc0d04875f4fd03c31d3d17dd8e069b69",
+				r:      "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				output: "1e6d164cfd835d88a31401623549bf6b9b306628ef03a7962921d62bc5ffce8c",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info: "7465737420696e666f",
+				blind: "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364" +
+					"," +
+					"f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				blinded: "031563e127099a8f61ed51eeede05d747a8da2be329b40ba1f0db0b2bd9dd4e2" +
+					"c0," +
+					"03ca4ff41c12fadd7a0bc92cf856732b21df652e01a3abdf0fa8847da053db21" +
+					"3c",
+				evaluated: "02c5e5300c2d9e6ba7f3f4ad60500ad93a0157e6288eb04b67e125db024a2c74" +
+					"d2," +
+					"02f0b6bcd467343a8d8555a99dc2eed0215c71898c5edb77a3d97ddd0dbad478" +
+					"e8",
+				proof: "8fbd85a32c13aba79db4b42e762c00687d6dbf9c8cb97b2a225645ccb00d9d75" +
+					"80b383c885cdfd07df448d55e06f50f6173405eee5506c0ed0851ff718d13e68",
+				r: "350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c8026877963",
+				output: "193a92520bd8fd1f37accb918040a57108daa110dc4f659abe212636d245c592" +
+					"," +
+					"1e6d164cfd835d88a31401623549bf6b9b306628ef03a7962921d62bc5ffce8c",
+			},
+		},
+	},
+	{oprf.P384SHA384, oprf.ModeOPRF,
+		"dfe7ddc41a4646901184f2b432616c8ba6d452f9bcd0c4f75a5150ef2b2ed02e" +
+			"f40b8b92f60ae591bcabd72a6518f188",
+		"",
+		[]vector{
+			{
+				input: "00",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "02a36bc90e6db34096346eaf8b7bc40ee1113582155ad3797003ce614c835a87" +
+					"4343701d3f2debbd80d97cbe45de6e5f1f",
+				evaluated: "03af2a4fc94770d7a7bf3187ca9cc4faf3732049eded2442ee50fbddda58b70a" +
+					"e2999366f72498cdbc43e6f2fc184afe30",
+				output: "ed84ad3f31a552f0456e58935fcc0a3039db42e7f356dcb32aa6d487b6b815a0" +
+					"7d5813641fb1398c03ddab5763874357",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "02def6f418e3484f67a124a2ce1bfb19de7a4af568ede6a1ebb2733882510ddd" +
+					"43d05f2b1ab5187936a55e50a847a8b900",
+				evaluated: "034e9b9a2960b536f2ef47d8608b21597ba400d5abfa1825fd21c36b75f927f3" +
+					"96bf3716c96129d1fa4a77fa1d479c8d7b",
+				output: "dd4f29da869ab9355d60617b60da0991e22aaab243a3460601e48b075859d1c5" +
+					"26d36597326f1b985778f781a1682e75",
+			},
+		},
+	},
+	{oprf.P384SHA384, oprf.ModeVOPRF,
+		"051646b9e6e7a71ae27c1e1d0b87b4381db6d3595eeeb1adb41579adbf992f42" +
+			"78f9016eafc944edaa2b43183581779d",
+		"031d689686c611991b55f1a1d8f4305ccd6cb719446f660a30db61b7aa87b46a" +
+			"cf59b7c0d4a9077b3da21c25dd482229a0",
+		[]vector{
+			{
+				input: "00",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "02d338c05cbecb82de13d6700f09cb61190543a7b7e2c6cd4fca56887e564ea8" +
+					"2653b27fdad383995ea6d02cf26d0e24d9",
+				evaluated: "02a7bba589b3e8672aa19e8fd258de2e6aae20101c8d761246de97a6b5ee9cf1" +
+					"05febce4327a326255a3c604f63f600ef6",
+				proof: "bfc6cf3859127f5fe25548859856d6b7fa1c7459f0ba5712a806fc091a3000c4" +
+					"2d8ba34ff45f32a52e40533efd2a03bc87f3bf4f9f58028297ccb9ccb18ae718" +
+					"2bcd1ef239df77e3be65ef147f3acf8bc9cbfc5524b702263414f043e3b7ca2e",
+				r: "803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				output: "3333230886b562ffb8329a8be08fea8025755372817ec969d114d1203d026b4a" +
+					"622beab60220bf19078bca35a529b35c",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "02f27469e059886f221be5f2cca03d2bdc61e55221721c3b3e56fc012e36d31a" +
+					"e5f8dc058109591556a6dbd3a8c69c433b",
+				evaluated: "03f16f903947035400e96b7f531a38d4a07ac89a80f89d86a1bf089c525a92c7" +
+					"f4733729ca30c56ce78b1ab4f7d92db8b4",
+				proof: "d005d6daaad7571414c1e0c75f7e57f2113ca9f4604e84bc90f9be52da896fff" +
+					"3bee496dcde2a578ae9df315032585f801fb21c6080ac05672b291e575a40295" +
+					"b306d967717b28e08fcc8ad1cab47845d16af73b3e643ddcc191208e71c64630",
+				r: "803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				output: "b91c70ea3d4d62ba922eb8a7d03809a441e1c3c7af915cbc2226f485213e8959" +
+					"42cd0f8580e6d99f82221e66c40d274f",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364," +
+					"803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				blinded: "02d338c05cbecb82de13d6700f09cb61190543a7b7e2c6cd4fca56887e564ea8" +
+					"2653b27fdad383995ea6d02cf26d0e24d9," +
+					"02fa02470d7f151018b41e82223c32fad824de6ad4b5ce9f8e9f98083c9a726d" +
+					"e9a1fc39d7a0cb6f4f188dd9cea01474cd",
+				evaluated: "02a7bba589b3e8672aa19e8fd258de2e6aae20101c8d761246de97a6b5ee9cf1" +
+					"05febce4327a326255a3c604f63f600ef6," +
+					"028e9e115625ff4c2f07bf87ce3fd73fc77994a7a0c1df03d2a630a3d845930e" +
+					"2e63a165b114d98fe34e61b68d23c0b50a",
+				proof: "6d8dcbd2fc95550a02211fb78afd013933f307d21e7d855b0b1ed0af78076d81" +
+					"37ad8b0a1bfa05676d325249c1dbb9a52bd81b1c2b7b0efc77cf7b278e1c947f" +
+					"6283f1d4c513053fc0ad19e026fb0c30654b53d9cea4b87b037271b5d2e2d0ea",
+				r: "a097e722ed2427de86966910acba9f5c350e8040f828bf6ceca27405420cdf3d" +
+					"63cb3aef005f40ba51943c8026877963",
+				output: "3333230886b562ffb8329a8be08fea8025755372817ec969d114d1203d026b4a" +
+					"622beab60220bf19078bca35a529b35c," +
+					"b91c70ea3d4d62ba922eb8a7d03809a441e1c3c7af915cbc2226f485213e8959" +
+					"42cd0f8580e6d99f82221e66c40d274f",
+			},
+		},
+	},
+	{oprf.P384SHA384, oprf.ModePOPRF,
+		"5b2690d6954b8fbb159f19935d64133f12770c00b68422559c65431942d721ff" +
+			"79d47d7a75906c30b7818ec0f38b7fb2",
+		"02f00f0f1de81e5d6cf18140d4926ffdc9b1898c48dc49657ae36eb1e45deb8b" +
+			"951aaf1f10c82d2eaa6d02aafa3f10d2b6",
+		[]vector{
+			{
+				input: "00",
+				info:  "7465737420696e666f",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "03859b36b95e6564faa85cd3801175eda2949707f6aa0640ad093cbf8ad2f58e" +
+					"762f08b56b2a1b42a64953aaf49cbf1ae3",
+				evaluated: "0220710e2e00306453f5b4f574cb6a512453f35c45080d09373e190c19ce5b18" +
+					"5914fbf36582d7e0754bb7c8b683205b91",
+				proof: "82a17ef41c8b57f1e3122311b4d5cd39a63df0f67443ef18d961f9b659c1601c" +
+					"ed8d3c64b294f604319ca80230380d437a49c7af0d620e22116669c008ebb767" +
+					"d90283d573b49cdb49e3725889620924c2c4b047a2a6225a3ba27e640ebddd33",
+				r: "803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				output: "0188653cfec38119a6c7dd7948b0f0720460b4310e40824e048bf82a16527303" +
+					"ed449a08caf84272c3bbc972ede797df",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info:  "7465737420696e666f",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364",
+				blinded: "03f7efcb4aaf000263369d8a0621cb96b81b3206e99876de2a00699ed4c45acf" +
+					"3969cd6e2319215395955d3f8d8cc1c712",
+				evaluated: "034993c818369927e74b77c400376fd1ae29b6ac6c6ddb776cf10e4fbc487826" +
+					"531b3cf0b7c8ca4d92c7af90c9def85ce6",
+				proof: "693471b5dff0cd6a5c00ea34d7bf127b2795164e3bdb5f39a1e5edfbd13e443b" +
+					"c516061cd5b8449a473c2ceeccada9f3e5b57302e3d7bc5e28d38d6e3a3056e1" +
+					"e73b6cc030f5180f8a1ffa45aa923ee66d2ad0a07b500f2acc7fb99b5506465c",
+				r: "803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				output: "ff2a527a21cc43b251a567382677f078c6e356336aec069dea8ba36995343ca3" +
+					"b33bb5d6cf15be4d31a7e6d75b30d3f5",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info: "7465737420696e666f",
+				blind: "504650f53df8f16f6861633388936ea23338fa65ec36e0290022b48eb562889d" +
+					"89dbfa691d1cde91517fa222ed7ad364," +
+					"803d955f0e073a04aa5d92b3fb739f56f9db001266677f62c095021db018cd8c" +
+					"bb55941d4073698ce45c405d1348b7b1",
+				blinded: "03859b36b95e6564faa85cd3801175eda2949707f6aa0640ad093cbf8ad2f58e" +
+					"762f08b56b2a1b42a64953aaf49cbf1ae3," +
+					"021a65d618d645f1a20bc33b06deaa7e73d6d634c8a56a3d02b53a732b69a5c5" +
+					"3c5a207ea33d5afdcde9a22d59726bce51",
+				evaluated: "0220710e2e00306453f5b4f574cb6a512453f35c45080d09373e190c19ce5b18" +
+					"5914fbf36582d7e0754bb7c8b683205b91," +
+					"02017657b315ec65ef861505e596c8645d94685dd7602cdd092a8f1c1c0194a5" +
+					"d0485fe47d071d972ab514370174cc23f5",
+				proof: "4a0b2fe96d5b2a046a0447fe079b77859ef11a39a3520d6ff7c626aad9b473b7" +
+					"24fb0cf188974ec961710a62162a83e97e0baa9eeada73397032d928b3e97b1e" +
+					"a92ad9458208302be3681b8ba78bcc17745bac00f84e0fdc98a6a8cba009c080",
+				r: "a097e722ed2427de86966910acba9f5c350e8040f828bf6ceca27405420cdf3d" +
+					"63cb3aef005f40ba51943c8026877963",
+				output: "0188653cfec38119a6c7dd7948b0f0720460b4310e40824e048bf82a16527303" +
+					"ed449a08caf84272c3bbc972ede797df," +
+					"ff2a527a21cc43b251a567382677f078c6e356336aec069dea8ba36995343ca3" +
+					"b33bb5d6cf15be4d31a7e6d75b30d3f5",
+			},
+		},
+	},
+	{oprf.P521SHA512, oprf.ModeOPRF,
+		"0153441b8faedb0340439036d6aed06d1217b34c42f17f8db4c5cc610a4a955d" +
+			"698a688831b16d0dc7713a1aa3611ec60703bffc7dc9c84e3ed673b3dbe1d5fc" +
+			"cea6",
+		"",
+		[]vector{
+			{
+				input: "00",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "0300e78bf846b0e1e1a3c320e353d758583cd876df56100a3a1e62bacba470fa" +
+					"6e0991be1be80b721c50c5fd0c672ba764457acc18c6200704e9294fbf28859d" +
+					"916351",
+				evaluated: "030166371cf827cb2fb9b581f97907121a16e2dc5d8b10ce9f0ede7f7d76a0d0" +
+					"47657735e8ad07bcda824907b3e5479bd72cdef6b839b967ba5c58b118b84d26" +
+					"f2ba07",
+				output: "26232de6fff83f812adadadb6cc05d7bbeee5dca043dbb16b03488abb9981d0a" +
+					"1ef4351fad52dbd7e759649af393348f7b9717566c19a6b8856284d69375c809",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "0300c28e57e74361d87e0c1874e5f7cc1cc796d61f9cad50427cf54655cdb455" +
+					"613368d42b27f94bf66f59f53c816db3e95e68e1b113443d66a99b3693bab88a" +
+					"fb556b",
+				evaluated: "0301ad453607e12d0cc11a3359332a40c3a254eaa1afc64296528d55bed07ba3" +
+					"22e72e22cf3bcb50570fd913cb54f7f09c17aff8787af75f6a7faf5640cbb2d9" +
+					"620a6e",
+				output: "ad1f76ef939042175e007738906ac0336bbd1d51e287ebaa66901abdd324ea3f" +
+					"fa40bfc5a68e7939c2845e0fd37a5a6e76dadb9907c6cc8579629757fd4d04ba",
+			},
+		},
+	},
+	{oprf.P521SHA512, oprf.ModeVOPRF,
+		"015c7fc1b4a0b1390925bae915bd9f3d72009d44d9241b962428aad5d13f2280" +
+			"3311e7102632a39addc61ea440810222715c9d2f61f03ea424ec9ab1fe5e31cf" +
+			"9238",
+		"0301505d646f6e4c9102451eb39730c4ba1c4087618641edbdba4a60896b07fd" +
+			"0c9414ce553cbf25b81dfcca50a8f6724ab7a2bc4d0cf736967a287bb6084cc0" +
+			"678ac0",
+		[]vector{
+			{
+				input: "00",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "0301d6e4fb545e043ddb6aee5d5ceeee1b44102615ab04430c27dd0f56988ded" +
+					"cb1df32ef384f160e0e76e718605f14f3f582f9357553d153b996795b4b3628a" +
+					"4f6380",
+				evaluated: "03013fdeaf887f3d3d283a79e696a54b66ff0edcb559265e204a958acf840e09" +
+					"30cc147e2a6835148d8199eebc26c03e9394c9762a1c991dde40bca0f8ca003e" +
+					"efb045",
+				proof: "0077fcc8ec6d059d7759b0a61f871e7c1dadc65333502e09a51994328f79e5bd" +
+					"a3357b9a4f410a1760a3612c2f8f27cb7cb032951c047cc66da60da583df7b24" +
+					"7edd0188e5eb99c71799af1d80d643af16ffa1545acd9e9233fbb370455b10eb" +
+					"257ea12a1667c1b4ee5b0ab7c93d50ae89602006960f083ca9adc4f6276c0ad6" +
+					"0440393c",
+				r: "015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				output: "5e003d9b2fb540b3d4bab5fedd154912246da1ee5e557afd8f56415faa1a0fad" +
+					"ff6517da802ee254437e4f60907b4cda146e7ba19e249eef7be405549f62954b",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "03005b05e656cb609ce5ff5faf063bb746d662d67bbd07c062638396f52f0392" +
+					"180cf2365cabb0ece8e19048961d35eeae5d5fa872328dce98df076ee154dd19" +
+					"1c615e",
+				evaluated: "0301b19fcf482b1fff04754e282292ed736c5f0aa080d4f42663cd3a416c6596" +
+					"f03129e8e096d8671fe5b0d19838312c511d2ce08d431e43e3ef06199d8cab74" +
+					"26238d",
+				proof: "01ec9fece444caa6a57032e8963df0e945286f88fbdf233fb5101f0924f7ea89" +
+					"c47023f5f72f240e61991fd33a299b5b38c45a5e2dd1a67b072e59dfe86708a3" +
+					"59c701e38d383c60cf6969463bcf13251bedad47b7941f52e409a3591398e279" +
+					"24410b18a301c0e19f527cad504fa08388050ac634e1b05c5216d337742f2754" +
+					"e1fc502f",
+				r: "015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				output: "fa15eebba81ecf40954f7135cb76f69ef22c6bae394d1a4362f9b03066b54b66" +
+					"04d39f2e53369ca6762a3d9787e230e832aa85955af40ecb8deebb009a8cf474",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364," +
+					"015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				blinded: "0301d6e4fb545e043ddb6aee5d5ceeee1b44102615ab04430c27dd0f56988ded" +
+					"cb1df32ef384f160e0e76e718605f14f3f582f9357553d153b996795b4b3628a" +
+					"4f6380," +
+					"0301403b597538b939b450c93586ba275f9711ba07e42364bac1d5769c6824a8" +
+					"b55be6f9a536df46d952b11ab2188363b3d6737635d9543d4dba14a6e19421b9" +
+					"245bf5",
+				evaluated: "03013fdeaf887f3d3d283a79e696a54b66ff0edcb559265e204a958acf840e09" +
+					"30cc147e2a6835148d8199eebc26c03e9394c9762a1c991dde40bca0f8ca003e" +
+					"efb045," +
+					"03001f96424497e38c46c904978c2fa1636c5c3dd2e634a85d8a7265977c5dce" +
+					"1f02c7e6c118479f0751767b91a39cce6561998258591b5d7c1bb02445a9e08e" +
+					"4f3e8d",
+				proof: "00b4d215c8405e57c7a4b53398caf55f1f1623aaeb22408ddb9ea29130909b3f" +
+					"95dbb1ff366e81e86e918f9f2fd8b80dbb344cd498c9499d112905e585417e00" +
+					"68c600fe5dea18b389ef6c4cc062935607b8ccbbb9a84fba3143868a3e8a58ef" +
+					"a0bf6ca642804d09dc06e980f64837811227c4267b217f1099a4e28b0854f4e5" +
+					"ee659796",
+				r: "01ec21c7bb69b0734cb48dfd68433dd93b0fa097e722ed2427de86966910acba" +
+					"9f5c350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c802687" +
+					"7963",
+				output: "5e003d9b2fb540b3d4bab5fedd154912246da1ee5e557afd8f56415faa1a0fad" +
+					"ff6517da802ee254437e4f60907b4cda146e7ba19e249eef7be405549f62954b" +
+					"," +
+					"fa15eebba81ecf40954f7135cb76f69ef22c6bae394d1a4362f9b03066b54b66" +
+					"04d39f2e53369ca6762a3d9787e230e832aa85955af40ecb8deebb009a8cf474",
+			},
+		},
+	},
+	{oprf.P521SHA512, oprf.ModePOPRF,
+		"014893130030ce69cf714f536498a02ff6b396888f9bb507985c32928c4427d6" +
+			"d39de10ef509aca4240e8569e3a88debc0d392e3361bcd934cb9bdd59e339dff" +
+			"7b27",
+		"0301de8ceb9ffe9237b1bba87c320ea0bebcfc3447fe6f278065c6c69886d692" +
+			"d1126b79b6844f829940ace9b52a5e26882cf7cbc9e57503d4cca3cd83458472" +
+			"9f812a",
+		[]vector{
+			{
+				input: "00",
+				info:  "7465737420696e666f",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "020095cff9d7ecf65bdfee4ea92d6e748d60b02de34ad98094f82e25d33a8bf5" +
+					"0138ccc2cc633556f1a97d7ea9438cbb394df612f041c485a515849d5ebb2238" +
+					"f2f0e2",
+				evaluated: "0301408e9c5be3ffcc1c16e5ae8f8aa68446223b0804b11962e856af5a6d1c65" +
+					"ebbb5db7278c21db4e8cc06d89a35b6804fb1738a295b691638af77aa1327253" +
+					"f26d01",
+				proof: "0106a89a61eee9dd2417d2849a8e2167bc5f56e3aed5a3ff23e22511fa1b37a2" +
+					"9ed44d1bbfd6907d99cfbc558a56aec709282415a864a281e49dc53792a4a638" +
+					"a0660034306d64be12a94dcea5a6d664cf76681911c8b9a84d49bf12d4893307" +
+					"ec14436bd05f791f82446c0de4be6c582d373627b51886f76c4788256e3da7ec" +
+					"8fa18a86",
+				r: "015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				output: "808ae5b87662eaaf0b39151dd85991b94c96ef214cb14a68bf5c143954882d33" +
+					"0da8953a80eea20788e552bc8bbbfff3100e89f9d6e341197b122c46a208733b",
+			},
+			{
+				input: "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info:  "7465737420696e666f",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364",
+				blinded: "030112ea89cf9cf589496189eafc5f9eb13c9f9e170d6ecde7c5b940541cb1a9" +
+					"c5cfeec908b67efe16b81ca00d0ce216e34b3d5f46a658d3fd8573d671bdb651" +
+					"5ed508",
+				evaluated: "0200ebc49df1e6fa61f412e6c391e6f074400ecdd2f56c4a8c03fe0f91d9b551" +
+					"f40d4b5258fd891952e8c9b28003bcfa365122e54a5714c8949d5d202767b31b" +
+					"4bf1f6",
+				proof: "0082162c71a7765005cae202d4bd14b84dae63c29067e886b82506992bd994a1" +
+					"c3aac0c1c5309222fe1af8287b6443ed6df5c2e0b0991faddd3564c73c7597ae" +
+					"cd9a003b1f1e3c65f28e58ab4e767cfb4adbcaf512441645f4c2aed8bf67d132" +
+					"d966006d35fa71a34145414bf3572c1de1a46c266a344dd9e22e7fb1e90ffba1" +
+					"caf556d9",
+				r: "015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				output: "27032e24b1a52a82ab7f4646f3c5df0f070f499db98b9c5df33972bd5af5762c" +
+					"3638afae7912a6c1acdb1ae2ab2fa670bd5486c645a0e55412e08d33a4a0d6e3",
+			},
+			{
+				input: "00," +
+					"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+				info: "7465737420696e666f",
+				blind: "00d1dccf7a51bafaf75d4a866d53d8cafe4d504650f53df8f16f686163338893" +
+					"6ea23338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7a" +
+					"d364," +
+					"015e80ae32363b32cb76ad4b95a5a34e46bb803d955f0e073a04aa5d92b3fb73" +
+					"9f56f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348" +
+					"b7b1",
+				blinded: "020095cff9d7ecf65bdfee4ea92d6e748d60b02de34ad98094f82e25d33a8bf5" +
+					"0138ccc2cc633556f1a97d7ea9438cbb394df612f041c485a515849d5ebb2238" +
+					"f2f0e2," +
+					"0201a328cf9f3fdeb86b6db242dd4cbb436b3a488b70b72d2fbbd1e5f50d7b08" +
+					"78b157d6f278c6a95c488f3ad52d6898a421658a82fe7ceb000b01aedea79675" +
+					"22d525",
+				evaluated: "0301408e9c5be3ffcc1c16e5ae8f8aa68446223b0804b11962e856af5a6d1c65" +
+					"ebbb5db7278c21db4e8cc06d89a35b6804fb1738a295b691638af77aa1327253" +
+					"f26d01," +
+					"020062ab51ac3aa829e0f5b7ae50688bcf5f63a18a83a6e0da538666b8d50c7e" +
+					"a2b4ef31f4ac669302318dbebe46660acdda695da30c22cee7ca21f6984a7205" +
+					"04502e",
+				proof: "00731738844f739bca0cca9d1c8bea204bed4fd00285785738b985763741de5c" +
+					"dfa275152d52b6a2fdf7792ef3779f39ba34581e56d62f78ecad5b7f8083f384" +
+					"961501cd4b43713253c022692669cf076b1d382ecd8293c1de69ea569737f37a" +
+					"24772ab73517983c1e3db5818754ba1f008076267b8058b6481949ae346cdc17" +
+					"a8455fe2",
+				r: "01ec21c7bb69b0734cb48dfd68433dd93b0fa097e722ed2427de86966910acba" +
+					"9f5c350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c802687" +
+					"7963",
+				output: "808ae5b87662eaaf0b39151dd85991b94c96ef214cb14a68bf5c143954882d33" +
+					"0da8953a80eea20788e552bc8bbbfff3100e89f9d6e341197b122c46a208733b" +
+					"," +
+					"27032e24b1a52a82ab7f4646f3c5df0f070f499db98b9c5df33972bd5af5762c" +
+					"3638afae7912a6c1acdb1ae2ab2fa670bd5486c645a0e55412e08d33a4a0d6e3",
+			},
+		},
+	},
+}
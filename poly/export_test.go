@@ -0,0 +1,26 @@
+package poly
+
+// MulSchool, MulKaratsuba, MulKronecker and MulNTT expose to the tests the
+// multiplication strategies among which Mul chooses by the field and the
+// length of the operands. MulKronecker and MulNTT work only over prime fields.
+func MulSchool(R Ring, p, q Poly) Poly    { return R.mulWith(R.mulSchool, p, q) }
+func MulKaratsuba(R Ring, p, q Poly) Poly { return R.mulWith(R.mul, p, q) }
+func MulKronecker(R Ring, p, q Poly) Poly { return R.mulWith(R.mulKronecker, p, q) }
+func MulNTT(R Ring, p, q Poly) Poly {
+	return R.mulWith(func(p, q Poly) Poly {
+		k := len(p) + len(q) - 1
+		n := 1
+		for n < k {
+			n *= 2
+		}
+		return R.fromInts(mulNTT(toInts(p), toInts(q), R.F.P(), n, k))
+	}, p, q)
+}
+
+func (R Ring) mulWith(mul func(p, q Poly) Poly, p, q Poly) Poly {
+	p, q = R.trim(p), R.trim(q)
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	return R.trim(mul(p, q))
+}
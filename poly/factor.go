@@ -0,0 +1,260 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Factor is an irreducible monic factor P with multiplicity E.
+type Factor struct {
+	P Poly
+	E int
+}
+
+func (f Factor) String() string {
+	if f.E == 1 {
+		return fmt.Sprintf("(%v)", f.P)
+	}
+	return fmt.Sprintf("(%v)^%v", f.P, f.E)
+}
+
+// Factor returns the factorization of p into monic irreducible polynomials,
+// sorted by degree. The leading coefficient of p is not included. It panics if
+// p is zero, or if the characteristic of the field is two.
+func (R Ring) Factor(p Poly) []Factor {
+	if R.IsZero(p) {
+		panic(errors.New("can't factor the zero polynomial"))
+	}
+	R.checkOdd()
+	var factors []Factor
+	for _, sf := range R.SquareFree(p) {
+		for _, dd := range R.DistinctDegree(sf.P) {
+			for _, g := range R.EqualDegree(dd.P, dd.E) {
+				factors = append(factors, Factor{g, sf.E})
+			}
+		}
+	}
+	R.sortFactors(factors)
+	return factors
+}
+
+// IsIrreducible returns true if p has positive degree and no non-trivial
+// factors.
+func (R Ring) IsIrreducible(p Poly) bool {
+	n := R.Deg(p)
+	if n <= 0 {
+		return false
+	}
+	// Rabin's test: x^(q^n)=x mod p and gcd(x^(q^(n/l))-x, p)=1 for each
+	// prime l dividing n.
+	p = R.Monic(p)
+	if !R.AreEqual(R.frobenius(R.X(), n, p), R.Mod(R.X(), p)) {
+		return false
+	}
+	for l := 2; l <= n; l++ {
+		if n%l != 0 || !isPrime(l) {
+			continue
+		}
+		h := R.Sub(R.frobenius(R.X(), n/l, p), R.X())
+		if R.Deg(R.Gcd(h, p)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Roots returns the distinct roots of p in the field, sorted by their
+// coefficients. It panics if p is zero, or if the characteristic of the field
+// is two.
+func (R Ring) Roots(p Poly) []GF.Elt {
+	if R.IsZero(p) {
+		panic(errors.New("the zero polynomial has all elements as roots"))
+	}
+	R.checkOdd()
+	p = R.Monic(p)
+	// g is the product of the distinct linear factors of p.
+	g := R.Gcd(p, R.Sub(R.frobenius(R.X(), 1, p), R.X()))
+	var roots []GF.Elt
+	for _, l := range R.EqualDegree(g, 1) {
+		roots = append(roots, R.F.Neg(l[0]))
+	}
+	sort.Slice(roots, func(i, j int) bool { return less(roots[i], roots[j]) })
+	return roots
+}
+
+// SquareFree returns the square-free factorization of p, that is, the pairs
+// (P,E) where the P are monic, square-free, pairwise coprime, and p is the
+// product of the P^E up to its leading coefficient.
+func (R Ring) SquareFree(p Poly) []Factor {
+	var factors []Factor
+	for _, f := range R.squareFree(R.Monic(p)) {
+		if R.Deg(f.P) > 0 {
+			factors = append(factors, f)
+		}
+	}
+	return factors
+}
+
+func (R Ring) squareFree(p Poly) []Factor {
+	if R.Deg(p) <= 0 {
+		return nil
+	}
+	dp := R.Derivative(p)
+	if R.IsZero(dp) {
+		// p = h(x^c), where c is the characteristic, so p = (h')^c where h'
+		// has the c-th roots of the coefficients of h.
+		return R.multiply(R.squareFree(R.charRoot(p)), R.charInt())
+	}
+	// Yun's algorithm, where the factors with multiplicity divisible by c are
+	// left in the last quotient.
+	var factors []Factor
+	c := R.Gcd(p, dp)
+	w := R.Div(p, c)
+	for i := 1; R.Deg(w) > 0; i++ {
+		y := R.Gcd(w, c)
+		factors = append(factors, Factor{R.Div(w, y), i})
+		w, c = y, R.Div(c, y)
+	}
+	if R.Deg(c) > 0 {
+		factors = append(factors, R.multiply(R.squareFree(R.charRoot(c)), R.charInt())...)
+	}
+	return factors
+}
+
+// multiply returns the factors with their multiplicities multiplied by c.
+func (R Ring) multiply(factors []Factor, c int) []Factor {
+	for i := range factors {
+		factors[i].E *= c
+	}
+	return factors
+}
+
+// charInt returns the characteristic of the field.
+func (R Ring) charInt() int {
+	p := R.F.P()
+	if !p.IsInt64() || p.Int64() > 1<<30 {
+		panic(errors.New("characteristic too large"))
+	}
+	return int(p.Int64())
+}
+
+// charRoot returns h such that h^c=p, where c is the characteristic of the
+// field and p is a polynomial in x^c.
+func (R Ring) charRoot(p Poly) Poly {
+	c := R.charInt()
+	// a^(1/c) = a^(q/c), as a^q=a.
+	e := new(big.Int).Quo(R.F.Order(), R.F.P())
+	h := make(Poly, (len(p)-1)/c+1)
+	for i := range h {
+		h[i] = R.F.Exp(p[i*c], e)
+	}
+	return R.trim(h)
+}
+
+// DistinctDegree returns the distinct-degree factorization of a monic
+// square-free polynomial p, that is, the pairs (P,E) where P is the product of
+// all irreducible factors of p of degree E.
+func (R Ring) DistinctDegree(p Poly) []Factor {
+	var factors []Factor
+	p = R.Monic(p)
+	h := R.Mod(R.X(), p)
+	for d := 1; 2*d <= R.Deg(p); d++ {
+		h = R.frobenius(h, 1, p)
+		if g := R.Gcd(p, R.Sub(h, R.X())); R.Deg(g) > 0 {
+			factors = append(factors, Factor{g, d})
+			p = R.Div(p, g)
+			h = R.Mod(h, p)
+		}
+	}
+	if R.Deg(p) > 0 {
+		factors = append(factors, Factor{p, R.Deg(p)})
+	}
+	return factors
+}
+
+// EqualDegree returns the irreducible factors of p, which must be monic,
+// square-free, and the product of irreducible polynomials of degree d. It uses
+// the probabilistic method of Cantor and Zassenhaus.
+func (R Ring) EqualDegree(p Poly, d int) []Poly {
+	p = R.Monic(p)
+	n := R.Deg(p)
+	if n <= 0 {
+		return nil
+	}
+	if n == d {
+		return []Poly{p}
+	}
+	R.checkOdd()
+	// e = (q^d-1)/2
+	e := new(big.Int).Exp(R.F.Order(), big.NewInt(int64(d)), nil)
+	e.Sub(e, big.NewInt(1)).Rsh(e, 1)
+	for {
+		a := make(Poly, n)
+		for i := range a {
+			a[i] = R.F.Rand(rand.Reader)
+		}
+		g := R.Gcd(p, R.Sub(R.ExpMod(a, e, p), R.One()))
+		if k := R.Deg(g); k > 0 && k < n {
+			return append(R.EqualDegree(g, d), R.EqualDegree(R.Div(p, g), d)...)
+		}
+	}
+}
+
+// frobenius returns h^(q^k) mod m.
+func (R Ring) frobenius(h Poly, k int, m Poly) Poly {
+	for i := 0; i < k; i++ {
+		h = R.ExpMod(h, R.F.Order(), m)
+	}
+	return h
+}
+
+func (R Ring) checkOdd() {
+	if R.F.P().Bit(0) == 0 {
+		panic(errors.New("fields of characteristic two are not supported"))
+	}
+}
+
+// sortFactors sorts by degree, multiplicity, and then coefficients.
+func (R Ring) sortFactors(f []Factor) {
+	sort.Slice(f, func(i, j int) bool {
+		a, b := f[i], f[j]
+		if len(a.P) != len(b.P) {
+			return len(a.P) < len(b.P)
+		}
+		if a.E != b.E {
+			return a.E < b.E
+		}
+		for k := len(a.P) - 1; k >= 0; k-- {
+			if !R.F.AreEqual(a.P[k], b.P[k]) {
+				return less(a.P[k], b.P[k])
+			}
+		}
+		return false
+	})
+}
+
+// less compares field elements by their coefficients, starting from the
+// highest degree.
+func less(a, b GF.Elt) bool {
+	pa, pb := a.Polynomial(), b.Polynomial()
+	for k := len(pa) - 1; k >= 0; k-- {
+		if c := pa[k].Cmp(pb[k]); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+func isPrime(n int) bool {
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return n > 1
+}
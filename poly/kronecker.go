@@ -0,0 +1,60 @@
+package poly
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// kroneckerThreshold is the number of coefficients from which Mul uses
+// Kronecker substitution over prime fields, below nttThreshold.
+const kroneckerThreshold = 32
+
+// mulKronecker returns the len(p)+len(q)-1 coefficients of pq over a prime
+// field. The coefficients are packed into integers, in slots large enough to
+// hold the coefficients of the product, so that the product is computed with
+// a single multiplication of integers.
+func (R Ring) mulKronecker(p, q Poly) Poly {
+	n := len(p)
+	if len(q) < n {
+		n = len(q)
+	}
+	// A coefficient of pq is a sum of n products smaller than char^2.
+	w := 2*R.F.P().BitLen() + bits.Len(uint(n)) + 1
+	w = (w + bits.UintSize - 1) / bits.UintSize
+	a := pack(p, w)
+	var c *big.Int
+	if &p[0] == &q[0] && len(p) == len(q) {
+		c = a.Mul(a, a)
+	} else {
+		c = a.Mul(a, pack(q, w))
+	}
+	return R.unpack(c, w, len(p)+len(q)-1)
+}
+
+// pack returns sum_i p[i]*2^(iwW), where W is the size in bits of a word.
+func pack(p Poly, w int) *big.Int {
+	z := make([]big.Word, len(p)*w)
+	for i := range p {
+		copy(z[i*w:(i+1)*w], p[i].Polynomial()[0].Bits())
+	}
+	return new(big.Int).SetBits(z)
+}
+
+// unpack returns the first n slots of w words of z as field elements.
+func (R Ring) unpack(z *big.Int, w, n int) Poly {
+	words := z.Bits()
+	r := make(Poly, n)
+	for i := range r {
+		lo, hi := i*w, (i+1)*w
+		if lo > len(words) {
+			lo = len(words)
+		}
+		if hi > len(words) {
+			hi = len(words)
+		}
+		c := make([]big.Word, hi-lo)
+		copy(c, words[lo:hi])
+		r[i] = R.F.Elt(new(big.Int).SetBits(c))
+	}
+	return r
+}
@@ -0,0 +1,216 @@
+package poly
+
+import (
+	"errors"
+	"math/big"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Modulus is a polynomial m of positive degree with a precomputed inverse
+// of its reversal, which reduces polynomials of degree less than 2deg(m)
+// modulo m with two multiplications instead of a long division.
+type Modulus struct {
+	R   Ring
+	m   Poly
+	inv Poly // 1/rev(m) mod x^deg(m)
+
+	// Over prime fields, the transforms of size N of m, and of size 2N of
+	// inv, for the least power of two N >= deg(m).
+	primes   []*nttPrime
+	N        int
+	tm, tinv [][]uint64
+}
+
+// NewModulus returns m as a modulus. It panics if m is constant.
+func (R Ring) NewModulus(m Poly) *Modulus {
+	m = R.trim(m)
+	if len(m) < 2 {
+		panic(errors.New("modulus must have positive degree"))
+	}
+	n := len(m) - 1
+	rev := R.reverse(m, len(m))
+	// Newton's iteration g = g(2-rev*g) doubles the precision of g.
+	g := Poly{R.F.Inv(rev[0])}
+	for k := 1; k < n; {
+		k *= 2
+		if k > n {
+			k = n
+		}
+		e := R.mulLow(R.truncate(rev, k), g, k)
+		e = R.Sub(R.Const(R.F.Elt(2)), R.trim(e))
+		g = R.trim(R.mulLow(g, e, k))
+	}
+	M := &Modulus{R: R, m: m, inv: g, N: 1}
+	for M.N < n {
+		M.N *= 2
+	}
+	if R.F.Ext() == 1 && n >= nttThreshold {
+		if M.primes = nttPrimesFor(R.F.P(), n); M.primes != nil {
+			M.tm = transform(M.primes, toInts(R.fold(m, M.N)), M.N)
+			M.tinv = transform(M.primes, toInts(g), 2*M.N)
+		}
+	}
+	return M
+}
+
+// Poly returns the polynomial m.
+func (M *Modulus) Poly() Poly { return M.m }
+
+// Deg returns the degree of m.
+func (M *Modulus) Deg() int { return len(M.m) - 1 }
+
+// reverse returns x^(n-1)p(1/x), for len(p) <= n.
+func (R Ring) reverse(p Poly, n int) Poly {
+	r := make(Poly, n)
+	for i := range r {
+		if j := n - 1 - i; j < len(p) {
+			r[i] = p[j]
+		} else {
+			r[i] = R.F.Zero()
+		}
+	}
+	return R.trim(r)
+}
+
+// truncate returns p mod x^k.
+func (R Ring) truncate(p Poly, k int) Poly {
+	if len(p) > k {
+		p = p[:k]
+	}
+	return R.trim(p)
+}
+
+// Mod returns a mod m.
+func (M *Modulus) Mod(a Poly) Poly {
+	R := M.R
+	a = R.trim(a)
+	n := M.Deg()
+	if len(a) <= n {
+		return a
+	}
+	if len(a) > 2*n {
+		return R.Mod(a, M.m)
+	}
+	// The quotient is rev(rev(a)/rev(m) mod x^k), for k=deg(a)-n+1.
+	k := len(a) - n
+	ra := R.truncate(R.reverse(a, len(a)), k)
+	if M.primes == nil || len(ra) == 0 {
+		q := R.reverse(R.trim(R.mulLow(ra, M.inv, k)), k)
+		return R.Sub(R.truncate(a, n), R.trim(R.mulLow(q, M.m, n)))
+	}
+	// As deg(ra*inv) < 2N, its first k coefficients are those modulo
+	// x^2N-1. As deg(a-qm) < n, it can be found modulo x^N-1.
+	q := R.reverse(R.trim(R.mulTransform(ra, M.tinv, M.primes, 2*M.N, k)), k)
+	if len(q) == 0 {
+		return R.truncate(a, n)
+	}
+	qm := R.mulTransform(q, M.tm, M.primes, M.N, M.N)
+	return R.truncate(R.Sub(R.fold(a, M.N), qm), n)
+}
+
+// MulMod returns pq mod m.
+func (M *Modulus) MulMod(p, q Poly) Poly { return M.Mod(M.R.Mul(p, q)) }
+
+// SqrMod returns p^2 mod m.
+func (M *Modulus) SqrMod(p Poly) Poly { return M.Mod(M.R.Sqr(p)) }
+
+// ExpMod returns p^n mod m, for n >= 0.
+func (M *Modulus) ExpMod(p Poly, n *big.Int) Poly {
+	if n.Sign() < 0 {
+		panic(errors.New("negative exponent"))
+	}
+	R := M.R
+	p = M.Mod(p)
+	z := R.One()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = M.SqrMod(z)
+		if n.Bit(i) != 0 {
+			z = M.MulMod(z, p)
+		}
+	}
+	return z
+}
+
+// Compose returns p[i](q) mod m for each polynomial p[i], using the
+// baby-step giant-step method of Brent and Kung, which takes about
+// 2sqrt(deg(p[i])) multiplications modulo m. The powers of q are shared by
+// all the polynomials.
+func (M *Modulus) Compose(q Poly, p ...Poly) []Poly {
+	R := M.R
+	n := 1
+	for i := range p {
+		if len(p[i]) > n {
+			n = len(p[i])
+		}
+	}
+	k := 1
+	for k*k < n {
+		k++
+	}
+	// Baby steps: q^i mod m for 0 <= i <= k.
+	pow := make([]Poly, k+1)
+	pow[0] = R.One()
+	q = M.Mod(q)
+	for i := 1; i <= k; i++ {
+		pow[i] = M.MulMod(pow[i-1], q)
+	}
+	comb := R.combiner(pow[:k], M.Deg())
+	// Giant steps: Horner's rule in q^k on the blocks of k coefficients.
+	z := make([]Poly, len(p))
+	for i := range p {
+		pi := R.trim(p[i])
+		for j := (len(pi) - 1) / k; j >= 0; j-- {
+			b := pi[j*k:]
+			if len(b) > k {
+				b = b[:k]
+			}
+			z[i] = R.Add(M.MulMod(z[i], pow[k]), comb(b))
+		}
+	}
+	return z
+}
+
+// combiner returns a function that computes sum_i c[i]*v[i] for polynomials
+// v[i] of less than n coefficients. Over prime fields, the sums are computed
+// on integers, and reduced once per coefficient.
+func (R Ring) combiner(v []Poly, n int) func(c []GF.Elt) Poly {
+	if R.F.Ext() != 1 {
+		return func(c []GF.Elt) Poly {
+			z := R.zeros(n)
+			for i := range c {
+				if !R.F.IsZero(c[i]) {
+					for t := range v[i] {
+						z[t] = R.F.Add(z[t], R.F.Mul(c[i], v[i][t]))
+					}
+				}
+			}
+			return R.trim(z)
+		}
+	}
+	w := make([][]*big.Int, len(v))
+	for i := range v {
+		w[i] = make([]*big.Int, len(v[i]))
+		for t := range v[i] {
+			w[i][t] = v[i][t].Polynomial()[0]
+		}
+	}
+	return func(c []GF.Elt) Poly {
+		acc := make([]big.Int, n)
+		var t0 big.Int
+		for i := range c {
+			ci := c[i].Polynomial()[0]
+			if ci.Sign() == 0 {
+				continue
+			}
+			for t := range w[i] {
+				acc[t].Add(&acc[t], t0.Mul(ci, w[i][t]))
+			}
+		}
+		z := make(Poly, n)
+		for t := range z {
+			z[t] = R.F.Elt(&acc[t])
+		}
+		return R.trim(z)
+	}
+}
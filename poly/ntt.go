@@ -0,0 +1,404 @@
+package poly
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// nttPrime is a prime p=c*2^32+1 with 2^61 < p < 2^62, for number-theoretic
+// transforms of size up to 2^32. Its elements are multiplied in Montgomery
+// form, with R=2^64.
+type nttPrime struct {
+	p    uint64
+	pinv uint64 // p^-1 mod 2^64
+	r2   uint64 // R^2 mod p
+	root uint64 // element of order 2^32
+	P    *big.Int
+
+	mu sync.Mutex
+	tw map[int][]uint64 // twiddles of the transforms of size n, and -n for inverses
+}
+
+// mul returns ab/R mod p, for a < 2^64 and b < p.
+func (m *nttPrime) mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	h, _ := bits.Mul64(lo*m.pinv, m.p)
+	r := hi - h
+	if hi < h {
+		r += m.p
+	}
+	return r
+}
+
+func (m *nttPrime) add(a, b uint64) uint64 {
+	r := a + b - m.p
+	if int64(r) < 0 {
+		r += m.p
+	}
+	return r
+}
+
+func (m *nttPrime) sub(a, b uint64) uint64 {
+	r := a - b
+	if int64(r) < 0 {
+		r += m.p
+	}
+	return r
+}
+
+// mont returns aR mod p.
+func (m *nttPrime) mont(a uint64) uint64 { return m.mul(a, m.r2) }
+
+// exp returns a^n mod p, for a in Montgomery form.
+func (m *nttPrime) exp(a uint64, n uint64) uint64 {
+	z := m.mont(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			z = m.mul(z, a)
+		}
+		a = m.mul(a, a)
+	}
+	return z
+}
+
+// twiddles returns the table of powers of an element w of order n, or of
+// its inverse, in Montgomery form. The entry h+j is w^(jn/2h), for
+// 0 <= j < h, and every power of two h < n.
+func (m *nttPrime) twiddles(n int, inverse bool) []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := n
+	if inverse {
+		key = -n
+	}
+	if tw, ok := m.tw[key]; ok {
+		return tw
+	}
+	w := m.exp(m.root, uint64(1<<32)/uint64(n))
+	if inverse {
+		w = m.exp(w, uint64(n-1))
+	}
+	tw := make([]uint64, n)
+	if n > 1 {
+		h := n / 2
+		tw[h] = m.mont(1)
+		for j := 1; j < h; j++ {
+			tw[h+j] = m.mul(tw[h+j-1], w)
+		}
+		for h /= 2; h >= 1; h /= 2 {
+			for j := 0; j < h; j++ {
+				tw[h+j] = tw[2*h+2*j]
+			}
+		}
+	}
+	if m.tw == nil {
+		m.tw = make(map[int][]uint64)
+	}
+	m.tw[key] = tw
+	return tw
+}
+
+// forward transforms a in place, leaving the result in bit-reversed order.
+func (m *nttPrime) forward(a, tw []uint64) {
+	n := len(a)
+	for h := n / 2; h >= 1; h /= 2 {
+		w := tw[h : 2*h]
+		for s := 0; s < n; s += 2 * h {
+			x, y := a[s:s+h], a[s+h:s+2*h]
+			for j := range x {
+				u, v := x[j], y[j]
+				x[j] = m.add(u, v)
+				y[j] = m.mul(m.sub(u, v), w[j])
+			}
+		}
+	}
+}
+
+// inverse undoes forward, up to a factor n, taking a in bit-reversed order.
+func (m *nttPrime) inverse(a, tw []uint64) {
+	n := len(a)
+	for h := 1; h < n; h *= 2 {
+		w := tw[h : 2*h]
+		for s := 0; s < n; s += 2 * h {
+			x, y := a[s:s+h], a[s+h:s+2*h]
+			for j := range x {
+				u, v := x[j], m.mul(y[j], w[j])
+				x[j] = m.add(u, v)
+				y[j] = m.sub(u, v)
+			}
+		}
+	}
+}
+
+// residues returns x mod p for each x, padded with zeros to n values.
+func (m *nttPrime) residues(x []*big.Int, n int) []uint64 {
+	// The word j of x is weighted by B^j, where B=2^W. In Montgomery form,
+	// B^j is shift[j].
+	B := m.mont(1) // 2^64 mod p
+	if bits.UintSize == 32 {
+		B = 1 << 32
+	}
+	B = m.mont(B)
+	shift := []uint64{m.mont(1)}
+	r := make([]uint64, n)
+	for i := range x {
+		w := x[i].Bits()
+		for len(shift) < len(w) {
+			shift = append(shift, m.mul(shift[len(shift)-1], B))
+		}
+		var s uint64
+		for j := range w {
+			s = m.add(s, m.mul(uint64(w[j]), shift[j]))
+		}
+		r[i] = s
+	}
+	return r
+}
+
+const maxNTTPrimes = 20
+
+var nttPrimes struct {
+	sync.Once
+	p []*nttPrime
+}
+
+func getNTTPrimes() []*nttPrime {
+	nttPrimes.Do(func() {
+		one := big.NewInt(1)
+		R := new(big.Int).Lsh(one, 64)
+		for c := int64(1<<30 - 1); len(nttPrimes.p) < maxNTTPrimes; c-- {
+			P := big.NewInt(c)
+			P.Lsh(P, 32).Add(P, one)
+			if !P.ProbablyPrime(20) {
+				continue
+			}
+			m := &nttPrime{p: P.Uint64(), P: P}
+			m.pinv = new(big.Int).ModInverse(P, R).Uint64()
+			m.r2 = new(big.Int).Exp(R, big.NewInt(2), P).Uint64()
+			// g^c has order 2^32 iff its power 2^31 is not one.
+			for g := int64(3); m.root == 0; g++ {
+				w := new(big.Int).Exp(big.NewInt(g), big.NewInt(c), P)
+				if new(big.Int).Exp(w, big.NewInt(1<<31), P).Cmp(one) != 0 {
+					m.root = m.mont(w.Uint64())
+				}
+			}
+			nttPrimes.p = append(nttPrimes.p, m)
+		}
+	})
+	return nttPrimes.p
+}
+
+// nttPrimesFor returns enough primes to represent the sums of m products
+// of integers smaller than bound, or nil if there are not enough of them.
+func nttPrimesFor(bound *big.Int, m int) []*nttPrime {
+	size := 2*bound.BitLen() + bits.Len(uint(m))
+	primes := getNTTPrimes()
+	np := (size + 60) / 61
+	if np > len(primes) {
+		return nil
+	}
+	return primes[:np]
+}
+
+// transform returns the transforms of size n of x modulo each prime.
+func transform(primes []*nttPrime, x []*big.Int, n int) [][]uint64 {
+	t := make([][]uint64, len(primes))
+	for i, pr := range primes {
+		t[i] = pr.residues(x, n)
+		pr.forward(t[i], pr.twiddles(n, false))
+	}
+	return t
+}
+
+// mulTransforms returns the first k coefficients of the cyclic convolution
+// whose operands have the transforms A and B. A is overwritten.
+func mulTransforms(primes []*nttPrime, A, B [][]uint64, k int) []*big.Int {
+	for i, pr := range primes {
+		a, b := A[i], B[i]
+		n := len(a)
+		for j := range a {
+			a[j] = pr.mul(a[j], b[j])
+		}
+		pr.inverse(a, pr.twiddles(n, true))
+		// The result is scaled by n/R, which is undone with n^-1 R^2.
+		s := pr.mont(pr.exp(pr.mont(uint64(n)), pr.p-2))
+		for j := 0; j < k; j++ {
+			a[j] = pr.mul(a[j], s)
+		}
+		A[i] = a[:k]
+	}
+	return crt(primes, A, k)
+}
+
+// mulNTT returns the first k coefficients of the cyclic convolution of size
+// n of the non-negative integers a and b, which are smaller than bound. It
+// returns nil if there are not enough primes to represent the results.
+func mulNTT(a, b []*big.Int, bound *big.Int, n, k int) []*big.Int {
+	m := len(a)
+	if len(b) < m {
+		m = len(b)
+	}
+	primes := nttPrimesFor(bound, m)
+	if primes == nil {
+		return nil
+	}
+	A := transform(primes, a, n)
+	B := A
+	if &a[0] != &b[0] || len(a) != len(b) {
+		B = transform(primes, b, n)
+	}
+	return mulTransforms(primes, A, B, k)
+}
+
+// crt returns the integers whose residues modulo the primes are res, using
+// Garner's algorithm.
+func crt(primes []*nttPrime, res [][]uint64, k int) []*big.Int {
+	np := len(primes)
+	// inv[i][j] is p_j^-1 mod p_i in Montgomery form, for j < i.
+	inv := make([][]uint64, np)
+	for i := range primes {
+		inv[i] = make([]uint64, i)
+		for j := 0; j < i; j++ {
+			t := new(big.Int).ModInverse(primes[j].P, primes[i].P)
+			inv[i][j] = primes[i].mont(t.Uint64())
+		}
+	}
+	out := make([]*big.Int, k)
+	v := make([]uint64, np)
+	for c := range out {
+		// x = v[0] + p_0(v[1] + p_1(v[2] + ...)), where v[i] < p_i.
+		for i, pr := range primes {
+			t := res[i][c]
+			for j := 0; j < i; j++ {
+				// As all primes lie in (2^61, 2^62), v[j] < 2p_i.
+				vj := v[j]
+				if vj >= pr.p {
+					vj -= pr.p
+				}
+				t = pr.mul(pr.sub(t, vj), inv[i][j])
+			}
+			v[i] = t
+		}
+		x := make([]uint64, 1, np)
+		x[0] = v[np-1]
+		for i := np - 2; i >= 0; i-- {
+			var carry uint64
+			for j := range x {
+				hi, lo := bits.Mul64(x[j], primes[i].p)
+				var cc uint64
+				x[j], cc = bits.Add64(lo, carry, 0)
+				carry = hi + cc
+			}
+			if carry != 0 {
+				x = append(x, carry)
+			}
+			var cc uint64
+			x[0], cc = bits.Add64(x[0], v[i], 0)
+			for j := 1; cc != 0 && j < len(x); j++ {
+				x[j], cc = bits.Add64(x[j], 0, cc)
+			}
+			if cc != 0 {
+				x = append(x, cc)
+			}
+		}
+		out[c] = fromLimbs(x)
+	}
+	return out
+}
+
+// fromLimbs returns the integer sum_i x[i]*2^(64i).
+func fromLimbs(x []uint64) *big.Int {
+	w := make([]big.Word, 0, len(x)*64/bits.UintSize)
+	for _, v := range x {
+		for s := 0; s < 64; s += bits.UintSize {
+			w = append(w, big.Word(v>>uint(s)))
+		}
+	}
+	return new(big.Int).SetBits(w)
+}
+
+// nttThreshold is the number of coefficients from which Mul uses
+// number-theoretic transforms over prime fields.
+const nttThreshold = 64
+
+// mulPrime returns the first k coefficients of pq over a prime field, for
+// non-empty p and q. Large operands are multiplied with number-theoretic
+// transforms, and the others with Kronecker substitution.
+func (R Ring) mulPrime(p, q Poly, k int) Poly {
+	square := &p[0] == &q[0] && len(p) == len(q)
+	p, q = R.truncate(p, k), R.truncate(q, k)
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	if square {
+		q = p
+	}
+	L := len(p) + len(q) - 1
+	if k > L {
+		k = L
+	}
+	n := 1
+	for n < L {
+		n *= 2
+	}
+	if c := R.mulCyclic(p, q, n); c != nil {
+		return c[:k]
+	}
+	return R.mulKronecker(p, q)[:k]
+}
+
+// mulCyclic returns the n coefficients of pq mod x^n-1, for a power of two
+// n, over a prime field. It returns nil if the operands are too short for
+// number-theoretic transforms, or if the field is too large.
+func (R Ring) mulCyclic(p, q Poly, n int) Poly {
+	if R.F.Ext() != 1 || len(p) < nttThreshold || len(q) < nttThreshold {
+		return nil
+	}
+	square := &p[0] == &q[0] && len(p) == len(q)
+	a := toInts(R.fold(p, n))
+	b := a
+	if !square {
+		b = toInts(R.fold(q, n))
+	}
+	return R.fromInts(mulNTT(a, b, R.F.P(), n, n))
+}
+
+// mulTransform returns the first k coefficients of pq mod x^n-1, where B
+// holds the transforms of size n of q modulo the primes.
+func (R Ring) mulTransform(p Poly, B [][]uint64, primes []*nttPrime, n, k int) Poly {
+	A := transform(primes, toInts(R.fold(p, n)), n)
+	return R.fromInts(mulTransforms(primes, A, B, k))
+}
+
+func (R Ring) fromInts(x []*big.Int) Poly {
+	if x == nil {
+		return nil
+	}
+	r := make(Poly, len(x))
+	for i := range r {
+		r[i] = R.F.Elt(x[i])
+	}
+	return r
+}
+
+// fold returns p mod x^n-1.
+func (R Ring) fold(p Poly, n int) Poly {
+	if len(p) <= n {
+		return p
+	}
+	r := make(Poly, n)
+	copy(r, p)
+	for i := n; i < len(p); i++ {
+		r[i%n] = R.F.Add(r[i%n], p[i])
+	}
+	return r
+}
+
+func toInts(p Poly) []*big.Int {
+	x := make([]*big.Int, len(p))
+	for i := range p {
+		x[i] = p[i].Polynomial()[0]
+	}
+	return x
+}
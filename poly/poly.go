@@ -0,0 +1,304 @@
+// Package poly provides univariate polynomials over finite fields.
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Poly is a polynomial given by its list of coefficients starting from the
+// constant term. The zero polynomial has no coefficients.
+type Poly []GF.Elt
+
+func (p Poly) String() string {
+	if len(p) == 0 {
+		return "0"
+	}
+	s := make([]string, 0, len(p))
+	for i := len(p) - 1; i >= 0; i-- {
+		switch i {
+		case 0:
+			s = append(s, fmt.Sprintf("%v", p[i]))
+		case 1:
+			s = append(s, fmt.Sprintf("%v*x", p[i]))
+		default:
+			s = append(s, fmt.Sprintf("%v*x^%v", p[i], i))
+		}
+	}
+	return strings.Join(s, " + ")
+}
+
+// Ring is the ring F[x] of univariate polynomials over a field F.
+type Ring struct{ F GF.Field }
+
+// NewRing returns the ring of polynomials over F.
+func NewRing(F GF.Field) Ring { return Ring{F} }
+
+// New returns the polynomial c[0]+c[1]x+...+c[n]x^n.
+func (R Ring) New(c ...GF.Elt) Poly {
+	p := make(Poly, len(c))
+	for i := range c {
+		p[i] = c[i].Copy()
+	}
+	return R.trim(p)
+}
+func (R Ring) Zero() Poly              { return nil }
+func (R Ring) One() Poly               { return Poly{R.F.One()} }
+func (R Ring) X() Poly                 { return Poly{R.F.Zero(), R.F.One()} }
+func (R Ring) Const(c GF.Elt) Poly     { return R.New(c) }
+func (R Ring) Deg(p Poly) int          { return len(R.trim(p)) - 1 }
+func (R Ring) IsZero(p Poly) bool      { return len(R.trim(p)) == 0 }
+func (R Ring) IsEqual(S Ring) bool     { return R.F.IsEqual(S.F) }
+func (R Ring) AreEqual(p, q Poly) bool { return R.IsZero(R.Sub(p, q)) }
+
+// LeadCoeff returns the leading coefficient of p, which must be non-zero.
+func (R Ring) LeadCoeff(p Poly) GF.Elt {
+	if p = R.trim(p); len(p) == 0 {
+		panic(errors.New("zero polynomial has no leading coefficient"))
+	}
+	return p[len(p)-1]
+}
+
+// trim removes the leading zero coefficients of p.
+func (R Ring) trim(p Poly) Poly {
+	for len(p) > 0 && R.F.IsZero(p[len(p)-1]) {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+func (R Ring) Neg(p Poly) Poly {
+	q := make(Poly, len(p))
+	for i := range p {
+		q[i] = R.F.Neg(p[i])
+	}
+	return q
+}
+func (R Ring) Add(p, q Poly) Poly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := make(Poly, len(p))
+	for i := range q {
+		r[i] = R.F.Add(p[i], q[i])
+	}
+	for i := len(q); i < len(p); i++ {
+		r[i] = p[i].Copy()
+	}
+	return R.trim(r)
+}
+func (R Ring) Sub(p, q Poly) Poly { return R.Add(p, R.Neg(q)) }
+
+// Scale returns c*p.
+func (R Ring) Scale(p Poly, c GF.Elt) Poly {
+	q := make(Poly, len(p))
+	for i := range p {
+		q[i] = R.F.Mul(p[i], c)
+	}
+	return R.trim(q)
+}
+
+// karatsubaThreshold is the number of coefficients below which Mul uses
+// schoolbook multiplication.
+const karatsubaThreshold = 16
+
+// Mul returns pq. Large operands are multiplied with number-theoretic
+// transforms or Kronecker substitution over prime fields, and with
+// Karatsuba's method otherwise.
+func (R Ring) Mul(p, q Poly) Poly {
+	p, q = R.trim(p), R.trim(q)
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	return R.trim(R.mulLow(p, q, len(p)+len(q)-1))
+}
+
+// mulLow returns the first k coefficients of pq, for trimmed p and q.
+func (R Ring) mulLow(p, q Poly, k int) Poly {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	if R.F.Ext() == 1 && len(p) >= kroneckerThreshold && len(q) >= kroneckerThreshold {
+		return R.mulPrime(p, q, k)
+	}
+	return R.truncate(R.mul(R.truncate(p, k), R.truncate(q, k)), k)
+}
+
+// mul returns the len(p)+len(q)-1 coefficients of pq, for non-empty p and q.
+func (R Ring) mul(p, q Poly) Poly {
+	if len(p) < karatsubaThreshold || len(q) < karatsubaThreshold {
+		return R.mulSchool(p, q)
+	}
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := R.zeros(len(p) + len(q) - 1)
+	m := len(p) / 2
+	p0, p1 := p[:m], p[m:]
+	if len(q) <= m {
+		// Unbalanced operands: pq = p0q + x^m*p1q.
+		R.addAt(r, R.mul(p0, q), 0)
+		R.addAt(r, R.mul(p1, q), m)
+		return r
+	}
+	q0, q1 := q[:m], q[m:]
+	z0 := R.mul(p0, q0)
+	z2 := R.mul(p1, q1)
+	// z1 = (p0+p1)(q0+q1)-z0-z2
+	z1 := R.mul(R.addPad(p0, p1), R.addPad(q0, q1))
+	R.subAt(z1, z0)
+	R.subAt(z1, z2)
+	R.addAt(r, z0, 0)
+	R.addAt(r, z1, m)
+	R.addAt(r, z2, 2*m)
+	return r
+}
+
+func (R Ring) mulSchool(p, q Poly) Poly {
+	r := R.zeros(len(p) + len(q) - 1)
+	for i := range p {
+		for j := range q {
+			r[i+j] = R.F.Add(r[i+j], R.F.Mul(p[i], q[j]))
+		}
+	}
+	return r
+}
+
+func (R Ring) zeros(n int) Poly {
+	r := make(Poly, n)
+	for i := range r {
+		r[i] = R.F.Zero()
+	}
+	return r
+}
+
+// addPad returns p+q without removing leading zeros.
+func (R Ring) addPad(p, q Poly) Poly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := make(Poly, len(p))
+	copy(r, p)
+	R.addAt(r, q, 0)
+	return r
+}
+
+// addAt adds x^k*q to r in place.
+func (R Ring) addAt(r, q Poly, k int) {
+	for i := range q {
+		r[i+k] = R.F.Add(r[i+k], q[i])
+	}
+}
+
+// subAt subtracts q from r in place.
+func (R Ring) subAt(r, q Poly) {
+	for i := range q {
+		r[i] = R.F.Sub(r[i], q[i])
+	}
+}
+func (R Ring) Sqr(p Poly) Poly { return R.Mul(p, p) }
+
+// Monic returns p divided by its leading coefficient.
+func (R Ring) Monic(p Poly) Poly {
+	if p = R.trim(p); len(p) == 0 {
+		return nil
+	}
+	return R.Scale(p, R.F.Inv(p[len(p)-1]))
+}
+
+// DivMod returns q and r such that a=qb+r and deg(r) < deg(b).
+func (R Ring) DivMod(a, b Poly) (q, r Poly) {
+	F := R.F
+	b = R.trim(b)
+	if len(b) == 0 {
+		panic(errors.New("division by zero polynomial"))
+	}
+	r = make(Poly, len(a))
+	copy(r, a)
+	r = R.trim(r)
+	if len(r) < len(b) {
+		return nil, r
+	}
+	q = make(Poly, len(r)-len(b)+1)
+	inv := F.Inv(b[len(b)-1])
+	for i := len(q) - 1; i >= 0; i-- {
+		c := F.Mul(r[i+len(b)-1], inv)
+		q[i] = c
+		for j := range b {
+			r[i+j] = F.Sub(r[i+j], F.Mul(c, b[j]))
+		}
+	}
+	return R.trim(q), R.trim(r[:len(b)-1])
+}
+func (R Ring) Div(a, b Poly) Poly { q, _ := R.DivMod(a, b); return q }
+func (R Ring) Mod(a, b Poly) Poly { _, r := R.DivMod(a, b); return r }
+
+// MulMod returns pq mod m.
+func (R Ring) MulMod(p, q, m Poly) Poly { return R.Mod(R.Mul(p, q), m) }
+
+// ExpMod returns p^n mod m, for n >= 0.
+func (R Ring) ExpMod(p Poly, n *big.Int, m Poly) Poly {
+	if n.Sign() < 0 {
+		panic(errors.New("negative exponent"))
+	}
+	p = R.Mod(p, m)
+	z := R.Mod(R.One(), m)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = R.MulMod(z, z, m)
+		if n.Bit(i) != 0 {
+			z = R.MulMod(z, p, m)
+		}
+	}
+	return z
+}
+
+// Gcd returns the monic greatest common divisor of p and q.
+func (R Ring) Gcd(p, q Poly) Poly {
+	p, q = R.trim(p), R.trim(q)
+	for len(q) > 0 {
+		p, q = q, R.Mod(p, q)
+	}
+	return R.Monic(p)
+}
+
+// InvMod returns the inverse of p modulo m, and false if they are not
+// coprime.
+func (R Ring) InvMod(p, m Poly) (Poly, bool) {
+	// Invariant: s*p = r (mod m) and t*p = u (mod m).
+	r, s := R.Mod(p, m), R.One()
+	u, t := R.trim(m), R.Zero()
+	for len(u) > 0 {
+		q, rem := R.DivMod(r, u)
+		r, u = u, rem
+		s, t = t, R.Sub(s, R.Mul(q, t))
+	}
+	if R.Deg(r) != 0 {
+		return nil, false
+	}
+	return R.Mod(R.Scale(s, R.F.Inv(r[0])), m), true
+}
+
+// Derivative returns the formal derivative of p.
+func (R Ring) Derivative(p Poly) Poly {
+	if len(p) == 0 {
+		return nil
+	}
+	q := make(Poly, len(p)-1)
+	for i := range q {
+		q[i] = R.F.Mul(R.F.Elt(i+1), p[i+1])
+	}
+	return R.trim(q)
+}
+
+// Eval returns p(x).
+func (R Ring) Eval(p Poly, x GF.Elt) GF.Elt {
+	z := R.F.Zero()
+	for i := len(p) - 1; i >= 0; i-- {
+		z = R.F.Add(R.F.Mul(z, x), p[i])
+	}
+	return z
+}
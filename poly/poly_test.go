@@ -0,0 +1,262 @@
+package poly_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/poly"
+)
+
+func randPoly(R poly.Ring, deg int) poly.Poly {
+	c := make([]GF.Elt, deg+1)
+	for i := range c {
+		c[i] = R.F.Rand(rand.Reader)
+	}
+	c[deg] = R.F.One()
+	return R.New(c...)
+}
+
+func TestDivMod(t *testing.T) {
+	R := poly.NewRing(GF.NewFp("103", 103))
+	for i := 0; i < 50; i++ {
+		a, b := randPoly(R, 2*i%13), randPoly(R, i%7)
+		q, r := R.DivMod(a, b)
+		if R.Deg(r) >= R.Deg(b) {
+			t.Fatalf("deg(r)=%v >= deg(b)=%v", R.Deg(r), R.Deg(b))
+		}
+		if got := R.Add(R.Mul(q, b), r); !R.AreEqual(got, a) {
+			t.Fatalf("got: %v\nwant: %v", got, a)
+		}
+	}
+}
+
+func TestGcdInvMod(t *testing.T) {
+	R := poly.NewRing(GF.NewFp("103", 103))
+	for i := 0; i < 50; i++ {
+		a, b, c := randPoly(R, 1+i%5), randPoly(R, i%6), randPoly(R, 1+i%4)
+		g := R.Gcd(R.Mul(a, c), R.Mul(b, c))
+		if !R.IsZero(R.Mod(g, c)) {
+			t.Fatalf("%v does not divide %v", c, g)
+		}
+		if inv, ok := R.InvMod(a, c); ok {
+			if got := R.MulMod(a, inv, c); !R.AreEqual(got, R.One()) {
+				t.Fatalf("got: %v\nwant: 1", got)
+			}
+		} else if R.Deg(R.Gcd(a, c)) == 0 {
+			t.Fatalf("%v must be invertible modulo %v", a, c)
+		}
+	}
+}
+
+func TestExpMod(t *testing.T) {
+	R := poly.NewRing(GF.NewFp("103", 103))
+	m := randPoly(R, 5)
+	p := randPoly(R, 7)
+	want := R.One()
+	for i := int64(0); i < 40; i++ {
+		if got := R.ExpMod(p, big.NewInt(i), m); !R.AreEqual(got, want) {
+			t.Fatalf("i: %v\ngot: %v\nwant: %v", i, got, want)
+		}
+		want = R.MulMod(want, p, m)
+	}
+	x := R.F.Elt(5)
+	if got, want := R.Eval(p, x), R.Eval(R.Mod(p, R.New(R.F.Neg(x), R.F.One())), x); !R.F.AreEqual(got, want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestKaratsuba(t *testing.T) {
+	// Kronecker substitution is used for large operands over prime fields.
+	for _, F := range []GF.Field{
+		GF.NewFp("103", 103),
+		GF.NewFp2("103", 103),
+		GF.NewFp("P256", "0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff"),
+	} {
+		R := poly.NewRing(F)
+		for _, n := range []int{15, 16, 17, 40, 100} {
+			for _, m := range []int{1, 7, 16, 33, 100} {
+				p, q := randPoly(R, n), randPoly(R, m)
+				pq := R.Mul(p, q)
+				if got, want := R.Deg(pq), n+m; got != want {
+					t.Fatalf("deg got: %v want: %v", got, want)
+				}
+				for i := 0; i < 5; i++ {
+					x := R.F.Rand(rand.Reader)
+					got := R.Eval(pq, x)
+					want := R.F.Mul(R.Eval(p, x), R.Eval(q, x))
+					if !R.F.AreEqual(got, want) {
+						t.Fatalf("got: %v\nwant: %v", got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKaratsubaPrime(t *testing.T) {
+	// Mul doesn't use Karatsuba's method for these lengths over prime fields.
+	for _, F := range []GF.Field{
+		GF.NewFp("103", 103),
+		GF.NewFp("P256", "0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff"),
+	} {
+		R := poly.NewRing(F)
+		for _, n := range [][2]int{
+			{16, 16}, {17, 17}, {33, 31}, {65, 65}, {101, 99},
+			{100, 17}, {17, 100}, {257, 33}, {40, 16}, {64, 1},
+		} {
+			p, q := randPoly(R, n[0]-1), randPoly(R, n[1]-1)
+			got, want := poly.MulKaratsuba(R, p, q), poly.MulSchool(R, p, q)
+			if !R.AreEqual(got, want) {
+				t.Fatalf("%v: got: %v\nwant: %v", n, got, want)
+			}
+		}
+	}
+}
+
+func TestMulStrategies(t *testing.T) {
+	for _, F := range []GF.Field{
+		GF.NewFp("103", 103),
+		GF.NewFp2("103", 103),
+		GF.NewFp("P256", "0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff"),
+	} {
+		R := poly.NewRing(F)
+		mul := map[string]func(p, q poly.Poly) poly.Poly{
+			"Mul":       R.Mul,
+			"Karatsuba": func(p, q poly.Poly) poly.Poly { return poly.MulKaratsuba(R, p, q) },
+		}
+		if F.Ext() == 1 {
+			mul["Kronecker"] = func(p, q poly.Poly) poly.Poly { return poly.MulKronecker(R, p, q) }
+			mul["NTT"] = func(p, q poly.Poly) poly.Poly { return poly.MulNTT(R, p, q) }
+		}
+		lengths := []int{1, 2, 15, 16, 17, 31, 32, 33, 63, 64, 65, 130}
+		for _, n := range lengths {
+			for _, m := range lengths {
+				p, q := randPoly(R, n-1), randPoly(R, m-1)
+				want := poly.MulSchool(R, p, q)
+				for name, f := range mul {
+					if got := f(p, q); !R.AreEqual(got, want) {
+						t.Fatalf("%v %v*%v over %v:\ngot: %v\nwant: %v", name, n, m, F, got, want)
+					}
+				}
+			}
+			// The operands are the same slice when squaring.
+			p := randPoly(R, n-1)
+			want := poly.MulSchool(R, p, p)
+			for name, f := range mul {
+				if got := f(p, p); !R.AreEqual(got, want) {
+					t.Fatalf("%v %v^2 over %v:\ngot: %v\nwant: %v", name, n, F, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestModulus(t *testing.T) {
+	for _, F := range []GF.Field{
+		GF.NewFp("103", 103),
+		GF.NewFp2("103", 103),
+		GF.NewFp("P256", "0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff"),
+	} {
+		R := poly.NewRing(F)
+		for _, n := range []int{1, 2, 5, 40, 64, 77} {
+			m := R.Scale(randPoly(R, n), F.Elt(3))
+			M := R.NewModulus(m)
+			for _, k := range []int{0, n - 1, n, 2*n - 1, 3 * n} {
+				a := randPoly(R, k)
+				if got, want := M.Mod(a), R.Mod(a, m); !R.AreEqual(got, want) {
+					t.Fatalf("got: %v\nwant: %v", got, want)
+				}
+			}
+			p, q := R.Mod(randPoly(R, n+3), m), randPoly(R, n+1)
+			e := big.NewInt(1000003)
+			if got, want := M.ExpMod(p, e), R.ExpMod(p, e, m); !R.AreEqual(got, want) {
+				t.Fatalf("got: %v\nwant: %v", got, want)
+			}
+			// p(q) = sum_i p_i q^i.
+			ps := []poly.Poly{p, randPoly(R, 2*n), R.Zero()}
+			got := M.Compose(q, ps...)
+			for j := range ps {
+				want, qi := R.Zero(), R.One()
+				for i := 0; i <= R.Deg(ps[j]); i++ {
+					want = R.Add(want, R.Scale(qi, ps[j][i]))
+					qi = R.MulMod(qi, q, m)
+				}
+				if !R.AreEqual(got[j], R.Mod(want, m)) {
+					t.Fatalf("got: %v\nwant: %v", got[j], want)
+				}
+			}
+		}
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	R := poly.NewRing(GF.NewFp("103", 103))
+	p, q := randPoly(R, 9), randPoly(R, 5)
+	// (pq)' = p'q+pq'
+	got := R.Derivative(R.Mul(p, q))
+	want := R.Add(R.Mul(R.Derivative(p), q), R.Mul(p, R.Derivative(q)))
+	if !R.AreEqual(got, want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestFactor(t *testing.T) {
+	for _, F := range []GF.Field{
+		GF.NewFp("7", 7),
+		GF.NewFp("103", 103),
+		GF.NewFp2("7", 7),
+	} {
+		R := poly.NewRing(F)
+		for i := 0; i < 20; i++ {
+			// p = a*b^2*c^7, where c^7 has zero derivative in characteristic 7.
+			a, b, c := randPoly(R, 1+i%6), randPoly(R, i%4), randPoly(R, i%2)
+			p := R.Mul(a, R.Mul(R.Sqr(b), R.Sqr(R.Sqr(c))))
+			p = R.Mul(p, R.Mul(R.Sqr(c), c))
+			p = R.Scale(p, F.Elt(3))
+
+			factors := R.Factor(p)
+			got := R.One()
+			for _, f := range factors {
+				if !R.IsIrreducible(f.P) {
+					t.Fatalf("%v is not irreducible", f.P)
+				}
+				for j := 0; j < f.E; j++ {
+					got = R.Mul(got, f.P)
+				}
+			}
+			if want := R.Monic(p); !R.AreEqual(got, want) {
+				t.Fatalf("got: %v\nwant: %v", factors, want)
+			}
+			for _, r := range R.Roots(p) {
+				if !F.IsZero(R.Eval(p, r)) {
+					t.Fatalf("%v is not a root of %v", r, p)
+				}
+			}
+		}
+	}
+}
+
+func TestRoots(t *testing.T) {
+	F := GF.NewFp("103", 103)
+	R := poly.NewRing(F)
+	// (x^2+1)(x-1)^2(x-2)(x-5) has roots 1, 2 and 5, as -1 is not a square.
+	p := R.New(F.One(), F.Zero(), F.One())
+	for _, r := range []int{1, 1, 2, 5} {
+		p = R.Mul(p, R.New(F.Elt(-r), F.One()))
+	}
+	got := R.Roots(p)
+	want := []GF.Elt{F.Elt(1), F.Elt(2), F.Elt(5)}
+	if len(got) != len(want) {
+		t.Fatalf("got: %v\nwant: %v", got, want)
+	}
+	for i := range got {
+		if !F.AreEqual(got[i], want[i]) {
+			t.Fatalf("got: %v\nwant: %v", got, want)
+		}
+	}
+	if R.IsIrreducible(p) || !R.IsIrreducible(R.New(F.One(), F.Zero(), F.One())) {
+		t.Fatal("wrong irreducibility test")
+	}
+}
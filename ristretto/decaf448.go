@@ -0,0 +1,94 @@
+package ristretto
+
+import (
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/eddsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Decaf448 returns the decaf448 group, built on edwards448.
+func Decaf448() *Group {
+	E, _ := eddsa.Edwards448()
+	F := E.Field()
+	r := &d448{
+		F:             F,
+		d:             E.(C.T).D,
+		sqrtMinusD:    F.Elt("98944233647732219769177004876929019128417576295529901074099889598043702116001257856802131563896515373927712232092845883226922417596214"),
+		invSqrtMinusD: F.Elt("315019913931389607337177038330951043522456072897266928557328499619017160722351061360252776265186336876723201881398623946864393857820716"),
+	}
+	gen := make([]byte, 56)
+	for i := range gen {
+		gen[i] = 0x66
+		if i >= 28 {
+			gen[i] = 0x33
+		}
+	}
+	return &Group{E: E, name: "decaf448", size: 56, gen: gen,
+		encode: func(P C.Point) []byte { return eltToLE(r.encode(P), 56) },
+		decode: func(s GF.Elt) (C.Point, error) { return r.decode(E, s) },
+		isEqual: func(P, Q C.Point) bool {
+			// x1y2=y1x2
+			return F.AreEqual(F.Mul(P.X(), Q.Y()), F.Mul(P.Y(), Q.X()))
+		},
+		mapElt: func(t GF.Elt) C.Point { return r.mapElt(E, t) },
+	}
+}
+
+// d448 holds the constants of decaf448.
+type d448 struct {
+	F             GF.Field
+	d             GF.Elt
+	sqrtMinusD    GF.Elt // sqrt(-d)
+	invSqrtMinusD GF.Elt // 1/sqrt(-d)
+}
+
+func (r *d448) sqrtRatio(u, v GF.Elt) (bool, GF.Elt) { return sqrtRatio(r.F, u, v, r.F.Elt(-1)) }
+
+// encode returns the field element s whose encoding is that of P, following
+// Section 5.3.2 of RFC 9496 with Z0=1.
+func (r *d448) encode(P C.Point) GF.Elt {
+	F := r.F
+	x0, y0 := P.X(), P.Y()
+	t0 := F.Mul(x0, y0)
+	oneMinusD := F.Sub(F.One(), r.d)
+	u1 := F.Mul(F.Add(x0, t0), F.Sub(x0, t0)) // (x0+t0)(x0-t0)
+	_, invsqrt := r.sqrtRatio(F.One(), F.Mul(F.Mul(u1, oneMinusD), F.Sqr(x0)))
+	ratio := abs(F, F.Mul(F.Mul(invsqrt, u1), r.sqrtMinusD))
+	u2 := F.Sub(F.Mul(r.invSqrtMinusD, ratio), t0)
+	return abs(F, F.Mul(F.Mul(F.Mul(oneMinusD, invsqrt), x0), u2))
+}
+
+// decode follows Section 5.3.1 of RFC 9496.
+func (r *d448) decode(E C.EllCurve, s GF.Elt) (C.Point, error) {
+	F := r.F
+	ss := F.Sqr(s)
+	u1 := F.Add(F.One(), ss)
+	u2 := F.Sub(F.Sqr(u1), F.Mul(F.Mul(F.Elt(4), r.d), ss)) // u1^2-4dss
+	wasSquare, invsqrt := r.sqrtRatio(F.One(), F.Mul(u2, F.Sqr(u1)))
+	u3 := abs(F, F.Mul(F.Mul(F.Mul(F.Add(s, s), invsqrt), u1), r.sqrtMinusD))
+	x := F.Mul(F.Mul(F.Mul(u3, invsqrt), u2), r.invSqrtMinusD)
+	y := F.Mul(F.Mul(F.Sub(F.One(), ss), invsqrt), u1)
+	if !wasSquare {
+		return nil, errDecoding
+	}
+	return E.NewPoint(x, y), nil
+}
+
+// mapElt is the one-way map of Section 5.3.4 of RFC 9496.
+func (r *d448) mapElt(E C.EllCurve, t GF.Elt) C.Point {
+	F := r.F
+	one := F.One()
+	oneMinusTwoD := F.Sub(one, F.Add(r.d, r.d))
+	rr := F.Neg(F.Sqr(t))                      // r = -t^2
+	u0 := F.Mul(r.d, F.Sub(rr, one))           // d(r-1)
+	u1 := F.Mul(F.Add(u0, one), F.Sub(u0, rr)) // (u0+1)(u0-r)
+	wasSquare, v := r.sqrtRatio(oneMinusTwoD, F.Mul(F.Add(rr, one), u1))
+	vPrime := F.CMov(F.Mul(t, v), v, wasSquare)
+	sgn := F.CMov(F.Neg(one), one, wasSquare)
+	s := F.Mul(vPrime, F.Add(rr, one))
+	w0 := F.Add(abs(F, s), abs(F, s))
+	w1 := F.Add(F.Sqr(s), one)
+	w2 := F.Sub(F.Sqr(s), one)
+	w3 := F.Add(F.Mul(F.Mul(F.Mul(vPrime, s), F.Sub(rr, one)), oneMinusTwoD), sgn)
+	return point(E, F.Mul(w0, w3), F.Mul(w2, w1), F.Mul(w1, w3))
+}
@@ -0,0 +1,143 @@
+// Package ristretto implements the prime-order groups ristretto255 and
+// decaf448 of RFC 9496, built on the edwards25519 and edwards448 curves.
+//
+// Elements are represented by points of the underlying curve. Distinct points
+// may represent the same element, so elements must be compared with IsEqual,
+// and are canonically encoded with Encode.
+package ristretto
+
+import (
+	"errors"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Group is a prime-order group whose elements are represented by points of a
+// twisted Edwards curve E. The group operations are those of E.
+type Group struct {
+	E C.EllCurve
+
+	name    string
+	size    int    // length of encoded elements
+	gen     []byte // encoding of the generator
+	encode  func(P C.Point) []byte
+	decode  func(s GF.Elt) (C.Point, error)
+	isEqual func(P, Q C.Point) bool
+	mapElt  func(t GF.Elt) C.Point
+}
+
+var errDecoding = errors.New("invalid element encoding")
+
+func (g *Group) String() string { return g.name }
+
+// Order returns the prime order of the group.
+func (g *Group) Order() *big.Int { return g.E.Order() }
+
+// Identity returns the identity element.
+func (g *Group) Identity() C.Point { return g.E.Identity() }
+
+// Generator returns the generator of the group.
+func (g *Group) Generator() C.Point {
+	P, err := g.Decode(g.gen)
+	if err != nil {
+		panic(err)
+	}
+	return P
+}
+
+// EncodingLen returns the length of encoded elements.
+func (g *Group) EncodingLen() int { return g.size }
+
+// UniformLen returns the length of the input of FromUniformBytes.
+func (g *Group) UniformLen() int { return 2 * g.size }
+
+// Encode returns the canonical encoding of the element represented by P.
+func (g *Group) Encode(P C.Point) []byte { return g.encode(P) }
+
+// Decode returns a point representing the element encoded in b. It returns
+// an error if b is not the canonical encoding of an element.
+func (g *Group) Decode(b []byte) (C.Point, error) {
+	if len(b) != g.size {
+		return nil, errDecoding
+	}
+	F := g.E.Field()
+	s := leToInt(b)
+	if s.Cmp(F.P()) >= 0 {
+		return nil, errDecoding
+	}
+	ss := F.Elt(s)
+	if isNegative(F, ss) {
+		return nil, errDecoding
+	}
+	return g.decode(ss)
+}
+
+// IsEqual returns true if P and Q represent the same element.
+func (g *Group) IsEqual(P, Q C.Point) bool { return g.isEqual(P, Q) }
+
+// FromUniformBytes returns the element derived from UniformLen() uniformly
+// random bytes, as the sum of the images of both halves under a one-way map.
+func (g *Group) FromUniformBytes(b []byte) (C.Point, error) {
+	if len(b) != g.UniformLen() {
+		return nil, errors.New("wrong length of uniform bytes")
+	}
+	F := g.E.Field()
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(F.P().BitLen()))
+	t0 := leToInt(b[:g.size])
+	t1 := leToInt(b[g.size:])
+	P0 := g.mapElt(F.Elt(t0.Mod(t0, mod)))
+	P1 := g.mapElt(F.Elt(t1.Mod(t1, mod)))
+	return g.E.Add(P0, P1), nil
+}
+
+// isNegative returns true if the least significant bit of x is set.
+func isNegative(F GF.Field, x GF.Elt) bool { return F.Sgn0(x) == 1 }
+
+// abs returns x or -x, whichever is non-negative.
+func abs(F GF.Field, x GF.Elt) GF.Elt { return F.CMov(x, F.Neg(x), isNegative(F, x)) }
+
+// sqrtRatio returns (true, sqrt(u/v)) if u/v is a square, and otherwise
+// (false, sqrt(z*u/v)), where z is a fixed non-square; both roots are
+// non-negative. If v=0, it returns (u=0, 0). This is SQRT_RATIO_M1 of RFC 9496.
+func sqrtRatio(F GF.Field, u, v, z GF.Elt) (bool, GF.Elt) {
+	if F.IsZero(v) {
+		return F.IsZero(u), F.Zero()
+	}
+	x := F.Mul(u, F.Inv(v))
+	if F.IsZero(x) {
+		return true, x
+	}
+	wasSquare := F.IsSquare(x)
+	if !wasSquare {
+		x = F.Mul(z, x)
+	}
+	return wasSquare, abs(F, F.Sqrt(x))
+}
+
+// point returns the point with projective coordinates (X:Y:Z).
+func point(E C.EllCurve, X, Y, Z GF.Elt) C.Point {
+	F := E.Field()
+	invZ := F.Inv(Z)
+	return E.NewPoint(F.Mul(X, invZ), F.Mul(Y, invZ))
+}
+
+func leToInt(b []byte) *big.Int {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(r)
+}
+
+func intToLE(x *big.Int, n int) []byte {
+	b := x.Bytes()
+	r := make([]byte, n)
+	for i := range b {
+		r[i] = b[len(b)-1-i]
+	}
+	return r
+}
+
+func eltToLE(x GF.Elt, n int) []byte { return intToLE(x.Polynomial()[0], n) }
@@ -0,0 +1,108 @@
+package ristretto
+
+import (
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/eddsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Ristretto255 returns the ristretto255 group, built on edwards25519.
+func Ristretto255() *Group {
+	E, _ := eddsa.Edwards25519()
+	F := E.Field()
+	r := &r255{
+		F:              F,
+		d:              E.(C.T).D,
+		sqrtM1:         F.Elt("19681161376707505956807079304988542015446066515923890162744021073123829784752"),
+		sqrtADMinusOne: F.Elt("25063068953384623474111414158702152701244531502492656460079210482610430750235"),
+		invSqrtAMinusD: F.Elt("54469307008909316920995813868745141605393597292927456921205312896311721017578"),
+	}
+	return &Group{E: E, name: "ristretto255", size: 32,
+		gen: []byte{
+			0xe2, 0xf2, 0xae, 0x0a, 0x6a, 0xbc, 0x4e, 0x71, 0xa8, 0x84, 0xa9, 0x61, 0xc5, 0x00, 0x51, 0x5f,
+			0x58, 0xe3, 0x0b, 0x6a, 0xa5, 0x82, 0xdd, 0x8d, 0xb6, 0xa6, 0x59, 0x45, 0xe0, 0x8d, 0x2d, 0x76,
+		},
+		encode: func(P C.Point) []byte { return eltToLE(r.encode(P), 32) },
+		decode: func(s GF.Elt) (C.Point, error) { return r.decode(E, s) },
+		isEqual: func(P, Q C.Point) bool {
+			// x1y2=y1x2 or y1y2=x1x2
+			return F.AreEqual(F.Mul(P.X(), Q.Y()), F.Mul(P.Y(), Q.X())) ||
+				F.AreEqual(F.Mul(P.Y(), Q.Y()), F.Mul(P.X(), Q.X()))
+		},
+		mapElt: func(t GF.Elt) C.Point { return r.mapElt(E, t) },
+	}
+}
+
+// r255 holds the constants of ristretto255.
+type r255 struct {
+	F              GF.Field
+	d              GF.Elt
+	sqrtM1         GF.Elt // sqrt(-1)
+	sqrtADMinusOne GF.Elt // sqrt(ad-1)
+	invSqrtAMinusD GF.Elt // 1/sqrt(a-d)
+}
+
+func (r *r255) sqrtRatio(u, v GF.Elt) (bool, GF.Elt) { return sqrtRatio(r.F, u, v, r.sqrtM1) }
+
+// encode returns the field element s whose encoding is that of P, following
+// Section 4.3.2 of RFC 9496 with Z0=1.
+func (r *r255) encode(P C.Point) GF.Elt {
+	F := r.F
+	x0, y0 := P.X(), P.Y()
+	t0 := F.Mul(x0, y0)
+	u1 := F.Mul(F.Add(F.One(), y0), F.Sub(F.One(), y0)) // (1+y0)(1-y0)
+	u2 := t0                                            // x0y0
+	_, invsqrt := r.sqrtRatio(F.One(), F.Mul(u1, F.Sqr(u2)))
+	den1 := F.Mul(invsqrt, u1)
+	den2 := F.Mul(invsqrt, u2)
+	zInv := F.Mul(F.Mul(den1, den2), t0)
+	ix0 := F.Mul(x0, r.sqrtM1)
+	iy0 := F.Mul(y0, r.sqrtM1)
+	enchantedDen := F.Mul(den1, r.invSqrtAMinusD)
+	rotate := isNegative(F, F.Mul(t0, zInv))
+	x := F.CMov(x0, iy0, rotate)
+	y := F.CMov(y0, ix0, rotate)
+	denInv := F.CMov(den2, enchantedDen, rotate)
+	y = F.CMov(y, F.Neg(y), isNegative(F, F.Mul(x, zInv)))
+	return abs(F, F.Mul(denInv, F.Sub(F.One(), y)))
+}
+
+// decode follows Section 4.3.1 of RFC 9496.
+func (r *r255) decode(E C.EllCurve, s GF.Elt) (C.Point, error) {
+	F := r.F
+	ss := F.Sqr(s)
+	u1 := F.Sub(F.One(), ss)
+	u2 := F.Add(F.One(), ss)
+	u2Sqr := F.Sqr(u2)
+	v := F.Sub(F.Neg(F.Mul(r.d, F.Sqr(u1))), u2Sqr) // -(du1^2)-u2^2
+	wasSquare, invsqrt := r.sqrtRatio(F.One(), F.Mul(v, u2Sqr))
+	denX := F.Mul(invsqrt, u2)
+	denY := F.Mul(F.Mul(invsqrt, denX), v)
+	x := abs(F, F.Mul(F.Add(s, s), denX))
+	y := F.Mul(u1, denY)
+	if !wasSquare || isNegative(F, F.Mul(x, y)) || F.IsZero(y) {
+		return nil, errDecoding
+	}
+	return E.NewPoint(x, y), nil
+}
+
+// mapElt is the one-way map of Section 4.3.4 of RFC 9496.
+func (r *r255) mapElt(E C.EllCurve, t GF.Elt) C.Point {
+	F := r.F
+	one := F.One()
+	oneMinusDSq := F.Sub(one, F.Sqr(r.d))   // 1-d^2
+	dMinusOneSq := F.Sqr(F.Sub(r.d, one))   // (d-1)^2
+	rr := F.Mul(r.sqrtM1, F.Sqr(t))         // r = sqrt(-1)t^2
+	u := F.Mul(F.Add(rr, one), oneMinusDSq) // (r+1)(1-d^2)
+	v := F.Mul(F.Sub(F.Neg(one), F.Mul(rr, r.d)), F.Add(rr, r.d))
+	wasSquare, s := r.sqrtRatio(u, v)
+	sPrime := F.Neg(abs(F, F.Mul(s, t)))
+	s = F.CMov(sPrime, s, wasSquare)
+	c := F.CMov(rr, F.Neg(one), wasSquare)
+	N := F.Sub(F.Mul(F.Mul(c, F.Sub(rr, one)), dMinusOneSq), v)
+	w0 := F.Mul(F.Add(s, s), v)
+	w1 := F.Mul(N, r.sqrtADMinusOne)
+	w2 := F.Sub(one, F.Sqr(s))
+	w3 := F.Add(one, F.Sqr(s))
+	return point(E, F.Mul(w0, w3), F.Mul(w2, w1), F.Mul(w1, w3))
+}
@@ -0,0 +1,332 @@
+package ristretto_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/ristretto"
+)
+
+// Multiples [0]B, ..., [15]B of the generator, from Appendix A of RFC 9496.
+var multiples = map[string][]string{
+	"ristretto255": {
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+		"e882b131016b52c1d3337080187cf768423efccbb517bb495ab812c4160ff44e",
+		"f64746d3c92b13050ed8d80236a7f0007c3b3f962f5ba793d19a601ebb1df403",
+		"44f53520926ec81fbd5a387845beb7df85a96a24ece18738bdcfa6a7822a176d",
+		"903293d8f2287ebe10e2374dc1a53e0bc887e592699f02d077d5263cdd55601c",
+		"02622ace8f7303a31cafc63f8fc48fdc16e1c8c8d234b2f0d6685282a9076031",
+		"20706fd788b2720a1ed2a5dad4952b01f413bcf0e7564de8cdc816689e2db95f",
+		"bce83f8ba5dd2fa572864c24ba1810f9522bc6004afe95877ac73241cafdab42",
+		"e4549ee16b9aa03099ca208c67adafcafa4c3f3e4e5303de6026e3ca8ff84460",
+		"aa52e000df2e16f55fb1032fc33bc42742dad6bd5a8fc0be0167436c5948501f",
+		"46376b80f409b29dc2b5f6f0c52591990896e5716f41477cd30085ab7f10301e",
+		"e0c418f7c8d9c4cdd7395b93ea124f3ad99021bb681dfc3302a9d99a2e53e64e",
+	},
+	"decaf448": {
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+			"000000000000000000000000000000000000000000000000",
+		"6666666666666666666666666666666666666666666666666666666633333333" +
+			"333333333333333333333333333333333333333333333333",
+		"c898eb4f87f97c564c6fd61fc7e49689314a1f818ec85eeb3bd5514ac816d387" +
+			"78f69ef347a89fca817e66defdedce178c7cc709b2116e75",
+		"a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa3" +
+			"08763db15468323b11cf6e4aeb8c18fe44678f44545a69bc",
+		"b46f1836aa287c0a5a5653f0ec5ef9e903f436e21c1570c29ad9e5f596da97ee" +
+			"af17150ae30bcb3174d04bc2d712c8c7789d7cb4fda138f4",
+		"1c5bbecf4741dfaae79db72dface00eaaac502c2060934b6eaaeca6a20bd3da9" +
+			"e0be8777f7d02033d1b15884232281a41fc7f80eed04af5e",
+		"86ff0182d40f7f9edb7862515821bd67bfd6165a3c44de95d7df79b8779ccf64" +
+			"60e3c68b70c16aaa280f2d7b3f22d745b97a89906cfc476c",
+		"502bcb6842eb06f0e49032bae87c554c031d6d4d2d7694efbf9c468d48220c50" +
+			"f8ca28843364d70cee92d6fe246e61448f9db9808b3b2408",
+		"0c9810f1e2ebd389caa789374d78007974ef4d17227316f40e578b336827da3f" +
+			"6b482a4794eb6a3975b971b5e1388f52e91ea2f1bcb0f912",
+		"20d41d85a18d5657a29640321563bbd04c2ffbd0a37a7ba43a4f7d263ce26faf" +
+			"4e1f74f9f4b590c69229ae571fe37fa639b5b8eb48bd9a55",
+		"e6b4b8f408c7010d0601e7eda0c309a1a42720d6d06b5759fdc4e1efe22d076d" +
+			"6c44d42f508d67be462914d28b8edce32e7094305164af17",
+		"be88bbb86c59c13d8e9d09ab98105f69c2d1dd134dbcd3b0863658f53159db64" +
+			"c0e139d180f3c89b8296d0ae324419c06fa87fc7daaf34c1",
+		"a456f9369769e8f08902124a0314c7a06537a06e32411f4f93415950a17badfa" +
+			"7442b6217434a3a05ef45be5f10bd7b2ef8ea00c431edec5",
+		"186e452c4466aa4383b4c00210d52e7922dbf9771e8b47e229a9b7b73c8d10fd" +
+			"7ef0b6e41530f91f24a3ed9ab71fa38b98b2fe4746d51d68",
+		"4ae7fdcae9453f195a8ead5cbe1a7b9699673b52c40ab27927464887be53237f" +
+			"7f3a21b938d40d0ec9e15b1d5130b13ffed81373a53e2b43",
+		"841981c3bfeec3f60cfeca75d9d8dc17f46cf0106f2422b59aec580a58f34227" +
+			"2e3a5e575a055ddb051390c54c24c6ecb1e0aceb075f6056",
+	},
+}
+
+func groups() []*ristretto.Group {
+	return []*ristretto.Group{ristretto.Ristretto255(), ristretto.Decaf448()}
+}
+
+func TestMultiples(t *testing.T) {
+	for _, g := range groups() {
+		B := g.Generator()
+		P := g.Identity()
+		for i, want := range multiples[g.String()] {
+			if got := hex.EncodeToString(g.Encode(P)); got != want {
+				t.Fatalf("%v: [%v]B\ngot:  %v\nwant: %v", g, i, got, want)
+			}
+			Q, err := g.Decode(mustHex(want))
+			if err != nil || !g.IsEqual(P, Q) {
+				t.Fatalf("%v: [%v]B: decoding failed: %v", g, i, err)
+			}
+			P = g.E.Add(P, B)
+		}
+		if !g.IsEqual(g.E.ScalarMult(B, g.Order()), g.Identity()) {
+			t.Fatalf("%v: generator must have prime order", g)
+		}
+	}
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	// Bad encodings of ristretto255, from Appendix A.2 of RFC 9496.
+	g := ristretto.Ristretto255()
+	for _, v := range []string{
+		// Non-canonical field encodings.
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Negative field elements.
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"ed57ffd8c914fb201471d1c3d245ce3c746fcbe63a3679d51b6a516ebebe0e20",
+		"c34c4e1826e5d403b78e246e88aa051c36ccf0aafebffe137d148a2bf9104562",
+		"c940e5a4404157cfb1628b108db051a8d439e1a421394ec4ebccb9ec92a8ac78",
+		"47cfc5497c53dc8e61c91d17fd626ffb1c49e2bca94eed052281b510b1117a24",
+		"f1c6165d33367351b0da8f6e4511010c68174a03b6581212c71c0e1d026c3c72",
+		"87260f7a2f12495118360f02c26a470f450dadf34a413d21042b43b9d93e1309",
+		// Non-square x^2.
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+		"4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f",
+		"de6a7b00deadc788eb6b6c8d20c0ae96c2f2019078fa604fee5b87d6e989ad7b",
+		"bcab477be20861e01e4a0e295284146a510150d9817763caf1a6f4b422d67042",
+		"2a292df7e32cababbd9de088d1d1abec9fc0440f637ed2fba145094dc14bea08",
+		"f4a9e534fc0d216c44b218fa0c42d99635a0127ee2e53c712f70609649fdff22",
+		"8268436f8c4126196cf64b3c7ddbda90746a378625f9813dd9b8457077256731",
+		"2810e5cbc2cc4d4eece54f61c6f69758e289aa7ab440b3cbeaa21995c2f4232b",
+		// Negative xy value.
+		"3eb858e78f5a7254d8c9731174a94f76755fd3941c0ac93735c07ba14579630e",
+		"a45fdc55c76448c049a1ab33f17023edfb2be3581e9c7aade8a6125215e04220",
+		"d483fe813c6ba647ebbfd3ec41adca1c6130c2beeee9d9bf065c8d151c5f396e",
+		"8a2e1d30050198c65a54483123960ccc38aef6848e1ec8f5f780e8523769ba32",
+		"32888462f8b486c68ad7dd9610be5192bbeaf3b443951ac1a8118419d9fa097b",
+		"227142501b9d4355ccba290404bde41575b037693cef1f438c47f8fbf35d1165",
+		"5c37cc491da847cfeb9281d407efc41e15144c876e0170b499a96a22ed31e01e",
+		"445425117cb8c90edcbc7c1cc0e74f747f2c1efa5630a967c64f287792a48a4b",
+		// s = -1, which causes y = 0.
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	} {
+		if _, err := g.Decode(mustHex(v)); err == nil {
+			t.Fatalf("%v: invalid encoding accepted: %v", g, v)
+		}
+	}
+
+	// Bad encodings of decaf448 in the categories of Appendix B.2 of RFC 9496:
+	// non-canonical field encodings, negative field elements, and s such that
+	// u2 = (1+s^2)^2-4Ds^2 is not a square, which are found from the formulas
+	// of Section 5.3.1 without using the decoder.
+	g = ristretto.Decaf448()
+	p := g.E.Field().P()
+	bad := []*big.Int{
+		new(big.Int).Add(p, big.NewInt(2)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 448), big.NewInt(1)),
+		big.NewInt(3),
+		new(big.Int).Sub(p, big.NewInt(2)),
+	}
+	pm1 := new(big.Int).Sub(p, big.NewInt(1))
+	half := new(big.Int).Rsh(pm1, 1)
+	d4 := big.NewInt(4 * 39081) // -4D
+	for x, n := int64(2), 0; n < 4; x += 2 {
+		ss := big.NewInt(x * x)
+		u2 := new(big.Int).Add(ss, big.NewInt(1))
+		u2.Mul(u2, u2).Add(u2, new(big.Int).Mul(d4, ss)).Mod(u2, p)
+		if new(big.Int).Exp(u2, half, p).Cmp(pm1) == 0 {
+			bad = append(bad, big.NewInt(x))
+			n++
+		}
+	}
+	for _, v := range bad {
+		if _, err := g.Decode(intToLE(v, g.EncodingLen())); err == nil {
+			t.Fatalf("%v: invalid encoding accepted: %x", g, v)
+		}
+	}
+
+	for _, g := range groups() {
+		// p, and the negative element 1.
+		p := intToLE(g.E.Field().P(), g.EncodingLen())
+		one := make([]byte, g.EncodingLen())
+		one[0] = 1
+		for _, b := range [][]byte{p, one, one[1:]} {
+			if _, err := g.Decode(b); err == nil {
+				t.Fatalf("%v: invalid encoding accepted: %x", g, b)
+			}
+		}
+	}
+}
+
+func TestFromUniformBytes(t *testing.T) {
+	// Element derivation of ristretto255, from Appendix A.3 of RFC 9496.
+	g := ristretto.Ristretto255()
+	for _, v := range []struct{ in, out string }{
+		{"5d1be09e3d0c82fc538112490e35701979d99e06ca3e2b5b54bffe8b4dc772c1" +
+			"4d98b696a1bbfb5ca32c436cc61c16563790306c79eaca7705668b47dffe5bb6",
+			"3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46"},
+		{"f116b34b8f17ceb56e8732a60d913dd10cce47a6d53bee9204be8b44f6678b27" +
+			"0102a56902e2488c46120e9276cfe54638286b9e4b3cdb470b542d46c2068d38",
+			"f26e5b6f7d362d2d2a94c5d0e7602cb4773c95a2e5c31a64f133189fa76ed61b"},
+		{"8422e1bbdaab52938b81fd602effb6f89110e1e57208ad12d9ad767e2e25510c" +
+			"27140775f9337088b982d83d7fcf0b2fa1edffe51952cbe7365e95c86eaf325c",
+			"006ccd2a9e6867e6a2c5cea83d3302cc9de128dd2a9a57dd8ee7b9d7ffe02826"},
+		{"ac22415129b61427bf464e17baee8db65940c233b98afce8d17c57beeb7876c2" +
+			"150d15af1cb1fb824bbd14955f2b57d08d388aab431a391cfc33d5bafb5dbbaf",
+			"f8f0c87cf237953c5890aec3998169005dae3eca1fbb04548c635953c817f92a"},
+		{"165d697a1ef3d5cf3c38565beefcf88c0f282b8e7dbd28544c483432f1cec767" +
+			"5debea8ebb4e5fe7d6f6e5db15f15587ac4d4d4a1de7191e0c1ca6664abcc413",
+			"ae81e7dedf20a497e10c304a765c1767a42d6e06029758d2d7e8ef7cc4c41179"},
+		{"a836e6c9a9ca9f1e8d486273ad56a78c70cf18f0ce10abb1c7172ddd605d7fd2" +
+			"979854f47ae1ccf204a33102095b4200e5befc0465accc263175485f0e17ea5c",
+			"e2705652ff9f5e44d3e841bf1c251cf7dddb77d140870d1ab2ed64f1a9ce8628"},
+		{"2cdc11eaeb95daf01189417cdddbf95952993aa9cb9c640eb5058d09702c7462" +
+			"2c9965a697a3b345ec24ee56335b556e677b30e6f90ac77d781064f866a3c982",
+			"80bd07262511cdde4863f8a7434cef696750681cb9510eea557088f76d9e5065"},
+	} {
+		P, err := g.FromUniformBytes(mustHex(v.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(g.Encode(P)); got != v.out {
+			t.Fatalf("got:  %v\nwant: %v", got, v.out)
+		}
+	}
+	// Element derivation of decaf448, from Appendix B.3 of RFC 9496.
+	g = ristretto.Decaf448()
+	for _, v := range []struct{ in, out string }{
+		{"cbb8c991fd2f0b7e1913462d6463e4fd2ce4ccdd28274dc2ca1f4165d5ee6cdc" +
+			"cea57be3416e166fd06718a31af45a2f8e987e301be59ae6673e963001dbbda8" +
+			"0df47014a21a26d6c7eb4ebe0312aa6fffb8d1b26bc62ca40ed51f8057a635a0" +
+			"2c2b8c83f48fa6a2d70f58a1185902c0",
+			"0c709c9607dbb01c94513358745b7c23953d03b33e39c7234e268d1d6e24f340" +
+				"14ccbc2216b965dd231d5327e591dc3c0e8844ccfd568848"},
+		{"b6d8da654b13c3101d6634a231569e6b85961c3f4b460a08ac4a5857069576b6" +
+			"4428676584baa45b97701be6d0b0ba18ac28d443403b45699ea0fbd1164f5893" +
+			"d39ad8f29e48e399aec5902508ea95e33bc1e9e4620489d684eb5c26bc1ad1e0" +
+			"9aba61fabc2cdfee0b6b6862ffc8e55a",
+			"76ab794e28ff1224c727fa1016bf7f1d329260b7218a39aea2fdb17d8bd91190" +
+				"17b093d641cedf74328c327184dc6f2a64bd90eddccfcdab"},
+		{"36a69976c3e5d74e4904776993cbac27d10f25f5626dd45c51d15dcf7b3e6a54" +
+			"46a6649ec912a56895d6baa9dc395ce9e34b868d9fb2c1fc72eb6495702ea4f4" +
+			"46c9b7a188a4e0826b1506b0747a6709f37988ff1aeb5e3d5a51e7d4de09a3dc" +
+			"36e30b1fb6c8c69eef3a8ba5b5fe8fcb",
+			"d48580742907202d9e625817e6bdacd22becba0289a3fa0bde83262301f1e509" +
+				"3942335c1ff267821137b4d5054ccfdc77a5290b0df03f3f"},
+		{"d5938acbba432ecd5617c555a6a777734494f176259bff9dab844c81aadcf8f7" +
+			"abd1a9001d89c7008c1957272c1786a4293bb0ee7cb37cf3988e2513b14e1b75" +
+			"249a5343643d3c5e5545a0c1a2a4d3c685927c38bc5e5879d68745464e2589e0" +
+			"00b31301f1dfb7471a4f1300d6fd0f99",
+			"62beffc6b8ee11ccd79dbaac8f0252c750eb052b192f41eeecb12f2979713b56" +
+				"3caf7d22588eca5e80995241ef963e7ad7cb7962f343a973"},
+		{"4dec58199a35f531a5f0a9f71a53376d7b4bdd6bbd2904234a8ea65bbacbce2a" +
+			"542291378157a8f4be7b6a092672a34d85e473b26ccfbd4cdc6739783dc3f4f6" +
+			"ee3537b7aed81df898c7ea0ae89a15b5559596c2a5eeacf8b2b362f3db2940e3" +
+			"798b63203cae77c4683ebaed71533e51",
+			"f4ccb31d263731ab88bed634304956d2603174c66da38742053fa37dd902346c" +
+				"3862155d68db63be87439e3d68758ad7268e239d39c4fd3b"},
+		{"df2aa1536abb4acab26efa538ce07fd7bca921b13e17bc5ebcba7d1b6b733ded" +
+			"a1d04c220f6b5ab35c61b6bcb15808251cab909a01465b8ae3fc770850c66246" +
+			"d5a9eae9e2877e0826e2b8dc1bc08009590bc6933c4e9b3a5b8ae86dac54f7aa" +
+			"f2d4536c7ab0e2ab8aa6bc0eda7f3f20",
+			"9afcc84ed4b2960a76f02405a5492e601095952153bf092a4b8d8cf1ea13ea72" +
+				"364826e90715d01980ae75c6ffbbfdc15aa2d8e3d7eaf82a"},
+		{"e9fb440282e07145f1f7f5ecf3c273212cd3d26b836b41b02f108431488e5e84" +
+			"bd15f2418b3d92a3380dd66a374645c2a995976a015632d36a6c2189f202fc76" +
+			"6e1c82f50ad9189be190a1f0e8f9b9e69c9c18cc98fdd885608f68bf0fdedd7b" +
+			"894081a63f70016a8abf04953affbefa",
+			"20b171cb16be977f15e013b9752cf86c54c631c4fc8cbf7c03c4d3ac9b8e8640" +
+				"e7b0e9300b987fe0ab5044669314f6ed1650ae037db853f1"},
+	} {
+		P, err := g.FromUniformBytes(mustHex(v.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(g.Encode(P)); got != v.out {
+			t.Fatalf("%v: got:  %v\nwant: %v", g, got, v.out)
+		}
+	}
+	// Both groups are also checked for consistency.
+	for _, g := range groups() {
+		b := make([]byte, g.UniformLen())
+		for i := 0; i < 8; i++ {
+			_, _ = rand.Read(b)
+			P, err := g.FromUniformBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			Q, err := g.Decode(g.Encode(P))
+			if err != nil || !g.IsEqual(P, Q) || !bytes.Equal(g.Encode(P), g.Encode(Q)) {
+				t.Fatalf("%v: round trip failed: %v", g, err)
+			}
+		}
+		if _, err := g.FromUniformBytes(b[1:]); err == nil {
+			t.Fatalf("%v: wrong length accepted", g)
+		}
+	}
+}
+
+func TestEquality(t *testing.T) {
+	// Elements are represented by points of [2]E, and points differing by a
+	// torsion point of [2]E represent the same element.
+	for _, g := range groups() {
+		B := g.Generator()
+		T := g.E.Identity()
+		for T.IsIdentity() {
+			R, err := C.RandomPoint(g.E, rand.Reader, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			T = g.E.ScalarMult(R, new(big.Int).Lsh(g.Order(), 1))
+		}
+		P := g.E.Identity()
+		for i := 1; i < 8; i++ {
+			P = g.E.Add(P, B)
+			Q := g.E.Add(P, T)
+			if !g.IsEqual(P, Q) || !bytes.Equal(g.Encode(P), g.Encode(Q)) {
+				t.Fatalf("%v: [%v]B+T must equal [%v]B", g, i, i)
+			}
+			if g.IsEqual(P, g.E.Neg(P)) {
+				t.Fatalf("%v: [%v]B must differ from its negative", g, i)
+			}
+			T = g.E.Add(T, T)
+		}
+	}
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func intToLE(x *big.Int, n int) []byte {
+	b := x.Bytes()
+	r := make([]byte, n)
+	for i := range b {
+		r[i] = b[len(b)-1-i]
+	}
+	return r
+}
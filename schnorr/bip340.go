@@ -0,0 +1,149 @@
+package schnorr
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+)
+
+// Secp256k1 returns the curve y^2=x^3+7 over GF(2^256-2^32-977), and its
+// base point of prime order.
+func Secp256k1() (C.EllCurve, C.Point) {
+	F := GF.NewFp("2^256-2^32-977", "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	N, _ := new(big.Int).SetString("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 0)
+	E := C.Weierstrass.New("secp256k1", F, F.Zero(), F.Elt(7), N, big.NewInt(1))
+	G := E.NewPoint(
+		F.Elt("0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		F.Elt("0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"))
+	return E, G
+}
+
+// BIP340 is the Schnorr signature scheme for secp256k1 of BIP-340. Public keys
+// are the 32-byte x-coordinate of the point with even y, and signatures are
+// the 32-byte x-coordinate of R, which also has even y, followed by s.
+type BIP340 struct{ s *Scheme }
+
+// NewBIP340 returns the BIP-340 signature scheme.
+func NewBIP340() *BIP340 {
+	E, G := Secp256k1()
+	s, err := New(E, G, crypto.SHA256)
+	if err != nil {
+		panic(err)
+	}
+	s.Challenge = func(R, Q C.Point, msg []byte) *big.Int {
+		e := new(big.Int).SetBytes(taggedHash("BIP0340/challenge", xOnly(R), xOnly(Q), msg))
+		return e.Mod(e, s.N)
+	}
+	return &BIP340{s}
+}
+
+// Scheme returns the underlying Schnorr scheme, whose challenge is the tagged
+// hash of BIP-340.
+func (b *BIP340) Scheme() *Scheme { return b.s }
+
+// PublicKey returns the x-only public key of a 32-byte secret key.
+func (b *BIP340) PublicKey(sk []byte) ([]byte, error) {
+	key, err := b.privateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return xOnly(key.Q), nil
+}
+
+// Sign returns the 64-byte signature of msg under the 32-byte secret key sk,
+// where aux is 32 bytes of auxiliary randomness.
+func (b *BIP340) Sign(sk, msg, aux []byte) ([]byte, error) {
+	if len(aux) != 32 {
+		return nil, errors.New("auxiliary randomness must have 32 bytes")
+	}
+	key, err := b.privateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	d := b.evenY(key.Q, key.D)
+	t := fixedBytes(d, 32)
+	for i, v := range taggedHash("BIP0340/aux", aux) {
+		t[i] ^= v
+	}
+	k := new(big.Int).SetBytes(taggedHash("BIP0340/nonce", t, xOnly(key.Q), msg))
+	k.Mod(k, b.s.N)
+	if k.Sign() == 0 {
+		return nil, errors.New("nonce is zero")
+	}
+	k = b.evenY(b.s.E.ScalarMult(b.s.G, k), k)
+	sig := b.s.sign(d, key.Q, k, msg)
+	out := append(xOnly(sig.R), fixedBytes(sig.S, 32)...)
+	if !b.Verify(xOnly(key.Q), msg, out) {
+		return nil, errors.New("signature failed to verify")
+	}
+	return out, nil
+}
+
+// Verify returns true if sig is a valid signature of msg under the x-only
+// public key pk.
+func (b *BIP340) Verify(pk, msg, sig []byte) bool {
+	if len(pk) != 32 || len(sig) != 64 {
+		return false
+	}
+	Q, err := b.liftX(pk)
+	if err != nil {
+		return false
+	}
+	R, err := b.liftX(sig[:32])
+	if err != nil {
+		return false
+	}
+	S := new(big.Int).SetBytes(sig[32:])
+	return b.s.Verify(&PublicKey{Q}, msg, &Signature{R, S})
+}
+
+// privateKey returns the key pair of a 32-byte secret key in [1, N-1].
+func (b *BIP340) privateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != 32 {
+		return nil, errors.New("secret key must have 32 bytes")
+	}
+	return b.s.NewPrivateKey(new(big.Int).SetBytes(sk))
+}
+
+// evenY returns k if the y-coordinate of P=[k]G is even, and N-k otherwise.
+func (b *BIP340) evenY(P C.Point, k *big.Int) *big.Int {
+	if b.s.E.Field().Sgn0(P.Y()) == 0 {
+		return k
+	}
+	return new(big.Int).Sub(b.s.N, k)
+}
+
+// liftX returns the point with even y-coordinate whose x-coordinate is the
+// 32-byte big-endian integer x < p.
+func (b *BIP340) liftX(x []byte) (C.Point, error) {
+	F := b.s.E.Field()
+	xx := new(big.Int).SetBytes(x)
+	if xx.Cmp(F.P()) >= 0 {
+		return nil, errors.New("x-coordinate is not a field element")
+	}
+	return C.LiftX(b.s.E, F.Elt(xx), 0)
+}
+
+// xOnly returns the 32-byte x-coordinate of P.
+func xOnly(P C.Point) []byte {
+	if P.IsIdentity() {
+		return make([]byte, 32)
+	}
+	return fixedBytes(P.X().Polynomial()[0], 32)
+}
+
+// taggedHash returns SHA256(SHA256(tag)||SHA256(tag)||data).
+func taggedHash(tag string, data ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	_, _ = h.Write(t[:])
+	_, _ = h.Write(t[:])
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
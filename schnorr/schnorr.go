@@ -0,0 +1,173 @@
+// Package schnorr implements Schnorr signatures over elliptic curve groups of
+// prime order, and their BIP-340 instantiation on secp256k1.
+package schnorr
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+// Scheme is a Schnorr signature scheme over the group of prime order N
+// generated by G. A signature of msg under Q=[d]G is (R,s), where R=[k]G for a
+// nonce k, s=k+ed mod N, and e=Challenge(R,Q,msg).
+type Scheme struct {
+	E C.EllCurve
+	G C.Point
+	N *big.Int
+	// Challenge returns the challenge e in [0,N) for a commitment R, a public
+	// key Q and a message.
+	Challenge func(R, Q C.Point, msg []byte) *big.Int
+	// Hash is used by the default challenge and to derive nonces.
+	Hash crypto.Hash
+}
+
+// Signature is a Schnorr signature (R,s).
+type Signature struct {
+	R C.Point
+	S *big.Int
+}
+
+// PublicKey is a Schnorr public key Q=[d]G.
+type PublicKey struct{ Q C.Point }
+
+// PrivateKey is a Schnorr private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// New returns a Schnorr scheme over E with generator G of order E.Order(). The
+// challenge is the hash of the uncompressed encodings of R and Q followed by
+// the message, reduced modulo N.
+func New(E C.EllCurve, G C.Point, h crypto.Hash) (*Scheme, error) {
+	N := E.Order()
+	if N == nil || !N.ProbablyPrime(20) {
+		return nil, errors.New("order of the generator must be prime")
+	}
+	if G.IsIdentity() || !E.IsOnCurve(G) || !E.ScalarMult(G, N).IsIdentity() {
+		return nil, errors.New("generator doesn't have order E.Order()")
+	}
+	if !h.Available() {
+		return nil, errors.New("hash function is not available")
+	}
+	s := &Scheme{E: E, G: G, N: new(big.Int).Set(N), Hash: h}
+	s.Challenge = func(R, Q C.Point, msg []byte) *big.Int {
+		return s.hashToScalar(s.encode(R), s.encode(Q), msg)
+	}
+	return s, nil
+}
+
+// GenerateKey returns a private key with d sampled uniformly from [1, N-1].
+func (s *Scheme) GenerateKey(rnd io.Reader) (*PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	nm1 := new(big.Int).Sub(s.N, big.NewInt(1))
+	d, err := rand.Int(rnd, nm1)
+	if err != nil {
+		return nil, err
+	}
+	return s.NewPrivateKey(d.Add(d, big.NewInt(1)))
+}
+
+// NewPrivateKey returns the private key with scalar d in [1, N-1].
+func (s *Scheme) NewPrivateKey(d *big.Int) (*PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(s.N) >= 0 {
+		return nil, errors.New("private scalar out of range")
+	}
+	return &PrivateKey{PublicKey{s.E.ScalarMult(s.G, d)}, new(big.Int).Set(d)}, nil
+}
+
+// nonceDST is the domain separation tag of the nonces derived by Sign.
+const nonceDST = "TOZAN-ECC-SCHNORR-NONCE"
+
+// Sign returns a signature of msg. The nonce is derived from the private key,
+// 32 bytes read from rnd, and the message, using expand_message_xmd of RFC
+// 9380 with s.Hash; if rnd is nil, the signature is deterministic.
+func (s *Scheme) Sign(key *PrivateKey, msg []byte, rnd io.Reader) (*Signature, error) {
+	var z [32]byte
+	if rnd != nil {
+		if _, err := io.ReadFull(rnd, z[:]); err != nil {
+			return nil, err
+		}
+	}
+	// k = expand(d||z||msg) mod (N-1) + 1, where d has the byte length of N
+	// and the expansion has 64 bits more than N, so that k is close to
+	// uniform whatever the size of the hash.
+	n := (s.N.BitLen() + 7) / 8
+	in := append(append(fixedBytes(key.D, n), z[:]...), msg...)
+	b, err := group.ExpandMessageXMD(s.Hash, in, []byte(nonceDST), (s.N.BitLen()+64+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(b)
+	k.Mod(k, new(big.Int).Sub(s.N, big.NewInt(1))).Add(k, big.NewInt(1))
+	return s.sign(key.D, key.Q, k, msg), nil
+}
+
+// sign returns (R,s) with R=[k]G and s=k+ed.
+func (s *Scheme) sign(d *big.Int, Q C.Point, k *big.Int, msg []byte) *Signature {
+	R := s.E.ScalarMult(s.G, k)
+	e := s.Challenge(R, Q, msg)
+	S := new(big.Int).Mul(e, d)
+	S.Add(S, k).Mod(S, s.N)
+	return &Signature{R, S}
+}
+
+// Verify returns true if sig is a valid signature of msg under key, that is,
+// if [s]G=R+[e]Q.
+func (s *Scheme) Verify(key *PublicKey, msg []byte, sig *Signature) bool {
+	Q := key.Q
+	if sig == nil || sig.R == nil || sig.S == nil || sig.S.Sign() < 0 || sig.S.Cmp(s.N) >= 0 {
+		return false
+	}
+	if Q.IsIdentity() || !s.E.IsOnCurve(Q) || !s.E.IsOnCurve(sig.R) {
+		return false
+	}
+	e := s.Challenge(sig.R, Q, msg)
+	lhs := s.E.ScalarMult(s.G, sig.S)
+	rhs := s.E.Add(sig.R, s.E.ScalarMult(Q, e))
+	return lhs.IsEqual(rhs)
+}
+
+// hashToScalar returns the hash of the data reduced modulo N.
+func (s *Scheme) hashToScalar(data ...[]byte) *big.Int {
+	h := s.Hash.New()
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	e := new(big.Int).SetBytes(h.Sum(nil))
+	return e.Mod(e, s.N)
+}
+
+// encode returns a byte 0 for the identity, and otherwise a byte 4 followed
+// by the coefficients of the affine coordinates x and y as big-endian strings
+// of the byte length of the characteristic.
+func (s *Scheme) encode(P C.Point) []byte {
+	if P.IsIdentity() {
+		return []byte{0}
+	}
+	n := (s.E.Field().P().BitLen() + 7) / 8
+	b := []byte{4}
+	for _, c := range []GF.Elt{P.X(), P.Y()} {
+		for _, x := range c.Polynomial() {
+			b = append(b, fixedBytes(x, n)...)
+		}
+	}
+	return b
+}
+
+// fixedBytes returns x as a big-endian string of n bytes.
+func fixedBytes(x *big.Int, n int) []byte {
+	b := make([]byte, n)
+	xb := x.Bytes()
+	copy(b[len(b)-len(xb):], xb)
+	return b
+}
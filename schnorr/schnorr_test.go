@@ -0,0 +1,312 @@
+package schnorr_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/curve/toy"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/schnorr"
+)
+
+func TestSchnorr(t *testing.T) {
+	E, g, _ := toy.W0.New()
+	toyScheme, err := schnorr.New(E, E.ClearCofactor(g), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schnorr.New(E, g, crypto.SHA256); err == nil {
+		t.Fatal("generator of composite order must be rejected")
+	}
+	// The nonces of P-521 are longer than the output of SHA-256.
+	p := elliptic.P521().Params()
+	F := GF.NewFp(p.Name, p.P)
+	P521 := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	p521Scheme, err := schnorr.New(P521, P521.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy)), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []*schnorr.Scheme{
+		toyScheme,
+		p521Scheme,
+		schnorr.NewBIP340().Scheme(),
+	} {
+		key, err := s.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 16; i++ {
+			msg := []byte{byte(i)}
+			sig, err := s.Sign(key, msg, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !s.Verify(&key.PublicKey, msg, sig) {
+				t.Fatal("signature must be valid")
+			}
+			sig.S.Add(sig.S, big.NewInt(1)).Mod(sig.S, s.N)
+			if s.Verify(&key.PublicKey, msg, sig) {
+				t.Fatal("modified signature accepted")
+			}
+		}
+		msg := []byte("deterministic")
+		sig0, _ := s.Sign(key, msg, nil)
+		sig1, _ := s.Sign(key, msg, nil)
+		if !sig0.R.IsEqual(sig1.R) || sig0.S.Cmp(sig1.S) != 0 {
+			t.Fatal("signatures without randomness must be deterministic")
+		}
+	}
+}
+
+func TestBIP340(t *testing.T) {
+	// Test vectors of BIP-340 (test-vectors.csv): secret key, public key,
+	// auxiliary randomness, message, signature, and verification result.
+	vectors := []struct {
+		sk, pk, aux, msg, sig string
+		valid                 bool
+	}{
+		{ // 0
+			"0000000000000000000000000000000000000000000000000000000000000003",
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA8215" +
+				"25F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			true,
+		},
+		{ // 1
+			"B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE3341" +
+				"8906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			true,
+		},
+		{ // 2
+			"C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+			"DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+			"C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+			"7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+			"5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1B" +
+				"AB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+			true,
+		},
+		{ // 3
+			"0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+			"25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+			"7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC" +
+				"97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+			true,
+		},
+		{ // 4
+			"",
+			"D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+			"",
+			"4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+			"00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C63" +
+				"76AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+			true,
+		},
+		{ // 5: public key not on the curve
+			"",
+			"EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+				"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+		{ // 6: has_even_y(R) is false
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A1460297556" +
+				"3CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+			false,
+		},
+		{ // 7: negated message
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F" +
+				"28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+			false,
+		},
+		{ // 8: negated s
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+				"961764B3AA9B2FFCB6EF947B6887A226E8D7C93E00C5ED0C1834FF0D0C2E6DA6",
+			false,
+		},
+		{ // 9: sG-eP is the point at infinity
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+				"123DDA8328AF9C23A94C1FEECFD123BA4FB73476F0D594DCB65C6425BD186051",
+			false,
+		},
+		{ // 10: sG-eP is the point at infinity
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+				"7615FBAF5AE28864013C099742DEADB4DBA87F11AC6754F93780D5A1837CF197",
+			false,
+		},
+		{ // 11: r is not the x-coordinate of a point
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D" +
+				"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+		{ // 12: r is the field size
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F" +
+				"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+		{ // 13: s is the curve order
+			"",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+				"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+			false,
+		},
+		{ // 14: public key exceeds the field size
+			"",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+			"",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+				"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+		{ // 15: empty message
+			"0340034003400340034003400340034003400340034003400340034003400340",
+			"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"",
+			"71535DB165ECD9FBBC046E5FFAEA61186BB6AD436732FCCC25291A55895464CF" +
+				"6069CE26BF03466228F19A3A62DB8A649F2D560FAC652827D1AF0574E427AB63",
+			true,
+		},
+		{ // 16: one-byte message
+			"0340034003400340034003400340034003400340034003400340034003400340",
+			"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"11",
+			"08A20A0AFEF64124649232E0693C583AB1B9934AE63B4C3511F3AE1134C6A303" +
+				"EA3173BFEA6683BD101FA5AA5DBC1996FE7CACFC5A577D33EC14564CEC2BACBF",
+			true,
+		},
+		{ // 17: 17-byte message
+			"0340034003400340034003400340034003400340034003400340034003400340",
+			"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0102030405060708090A0B0C0D0E0F1011",
+			"5130F39A4059B43BC7CAC09A19ECE52B5D8699D1A71E3C52DA9AFDB6B50AC370" +
+				"C4A482B77BF960F8681540E25B6771ECE1E5A37FD80E5A51897C5566A97EA5A5",
+			true,
+		},
+		{ // 18: 100-byte message
+			"0340034003400340034003400340034003400340034003400340034003400340",
+			"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			strings.Repeat("99", 100),
+			"403B12B0D8555A344175EA7EC746566303321E5DBFA8BE6F091635163ECA79A8" +
+				"585ED3E3170807E7C03B720FC54C7B23897FCBA0E9D0B4A06894CFD249F22367",
+			true,
+		},
+	}
+	s := schnorr.NewBIP340()
+	for i, v := range vectors {
+		pk, msg, sig := mustHex(v.pk), mustHex(v.msg), mustHex(v.sig)
+		if v.sk != "" {
+			sk, aux := mustHex(v.sk), mustHex(v.aux)
+			got, err := s.PublicKey(sk)
+			if err != nil || !bytes.Equal(got, pk) {
+				t.Fatalf("vector %v: got: %X\nwant: %X", i, got, pk)
+			}
+			got, err = s.Sign(sk, msg, aux)
+			if err != nil || !bytes.Equal(got, sig) {
+				t.Fatalf("vector %v: got: %X\nwant: %X", i, got, sig)
+			}
+		}
+		if got := s.Verify(pk, msg, sig); got != v.valid {
+			t.Fatalf("vector %v: got: %v want: %v", i, got, v.valid)
+		}
+	}
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestOddR(t *testing.T) {
+	// A signature that is valid for the generic scheme, but whose R has odd y,
+	// must be rejected by BIP-340 since R is lifted with even y.
+	b := schnorr.NewBIP340()
+	s := b.Scheme()
+	F := s.E.Field()
+	key, err := s.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if F.Sgn0(key.Q.Y()) != 0 {
+		key.D.Sub(s.N, key.D)
+		key.Q = s.E.Neg(key.Q)
+	}
+	msg := []byte("odd R")
+	for i := 0; i < 16; i++ {
+		sig, err := s.Sign(key, msg, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.Verify(&key.PublicKey, msg, sig) {
+			t.Fatal("signature must be valid")
+		}
+		enc := append(xOnly(sig.R.X().Polynomial()[0]), xOnly(sig.S)...)
+		pk := xOnly(key.Q.X().Polynomial()[0])
+		if even := F.Sgn0(sig.R.Y()) == 0; b.Verify(pk, msg, enc) != even {
+			t.Fatalf("R has even y: %v, accepted: %v", even, !even)
+		}
+	}
+}
+
+func xOnly(x *big.Int) []byte {
+	b := make([]byte, 32)
+	xb := x.Bytes()
+	copy(b[32-len(xb):], xb)
+	return b
+}
@@ -0,0 +1,60 @@
+package sigma
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// Instance is a proof of a relation, together with the transcript under
+// which it was produced.
+type Instance struct {
+	Relation   *LinearRelation
+	Transcript *Transcript
+	Proof      *Proof
+}
+
+// BatchVerify reports whether all the proofs are valid. All relations must
+// be on the same curve. The equations of every proof are combined with
+// random weights read from rnd into a single multi-scalar multiplication,
+// which equals the identity when all proofs are valid, and is different from
+// it with overwhelming probability otherwise. As in Verify, the transcripts
+// are updated with the proofs.
+func BatchVerify(rnd io.Reader, instances []Instance) bool {
+	if len(instances) == 0 {
+		return true
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	E := instances[0].Relation.E
+	N := E.Order()
+	var P []C.Point
+	var k []*big.Int
+	for _, in := range instances {
+		R, p := in.Relation, in.Proof
+		if !R.E.IsEqual(E) || !R.wellFormed(p) {
+			return false
+		}
+		c := R.challenge(in.Transcript, p.T)
+		for i, row := range R.G {
+			// rho(sum_j [S[j]]G[i][j] - [c]Y[i] - T[i])
+			rho, err := rand.Int(rnd, N)
+			if err != nil {
+				return false
+			}
+			for j, G := range row {
+				P = append(P, G)
+				k = append(k, new(big.Int).Mul(rho, p.S[j]))
+			}
+			P = append(P, R.Y[i], p.T[i])
+			k = append(k, new(big.Int).Neg(new(big.Int).Mul(rho, c)), new(big.Int).Neg(rho))
+		}
+	}
+	for i := range k {
+		k[i].Mod(k[i], N)
+	}
+	return C.MultiScalarMult(E, P, k).IsIdentity()
+}
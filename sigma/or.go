@@ -0,0 +1,108 @@
+package sigma
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// OrProof proves the knowledge of a witness of at least one of several
+// relations, without revealing which one, as in the composition of Cramer,
+// Damgård and Schoenmakers. The challenges C of the branches add up to the
+// Fiat-Shamir challenge, and all but one of them are chosen by the prover.
+type OrProof struct {
+	C      []*big.Int
+	Proofs []*Proof
+}
+
+// ProveOr returns a proof of knowledge of the witness x of rels[index], for
+// which the other branches are simulated. All relations must be on curves of
+// the same order.
+func ProveOr(tr *Transcript, rels []*LinearRelation, index int, x []*big.Int, rnd io.Reader) (*OrProof, error) {
+	if index < 0 || index >= len(rels) || !sameOrder(rels) {
+		return nil, errors.New("invalid branch or relations of different orders")
+	}
+	R := rels[index]
+	if len(x) != len(R.G[0]) || !R.holds(x) {
+		return nil, errors.New("witness doesn't satisfy the relation")
+	}
+	N := R.E.Order()
+	p := &OrProof{C: make([]*big.Int, len(rels)), Proofs: make([]*Proof, len(rels))}
+	// The challenges and responses of the other branches are chosen first.
+	sum := new(big.Int)
+	for i, Ri := range rels {
+		if i == index {
+			continue
+		}
+		c, err := randomScalars(rnd, N, 1)
+		if err != nil {
+			return nil, err
+		}
+		S, err := randomScalars(rnd, N, len(Ri.G[0]))
+		if err != nil {
+			return nil, err
+		}
+		p.C[i] = c[0]
+		p.Proofs[i] = &Proof{Ri.simulate(c[0], S), S}
+		sum.Add(sum, c[0])
+	}
+	k, err := randomScalars(rnd, N, len(x))
+	if err != nil {
+		return nil, err
+	}
+	p.Proofs[index] = &Proof{T: R.eval(k)}
+	c := orChallenge(tr, rels, p.Proofs)
+	p.C[index] = c.Sub(c, sum).Mod(c, N)
+	S := make([]*big.Int, len(x))
+	for j := range S {
+		S[j] = new(big.Int).Mul(p.C[index], x[j])
+		S[j].Add(S[j], k[j]).Mod(S[j], N)
+	}
+	p.Proofs[index].S = S
+	return p, nil
+}
+
+// VerifyOr reports whether p is a valid proof of one of the relations under
+// the transcript.
+func VerifyOr(tr *Transcript, rels []*LinearRelation, p *OrProof) bool {
+	if p == nil || len(rels) == 0 || len(p.C) != len(rels) || len(p.Proofs) != len(rels) || !sameOrder(rels) {
+		return false
+	}
+	N := rels[0].E.Order()
+	sum := new(big.Int)
+	for i, R := range rels {
+		if !R.wellFormed(p.Proofs[i]) || p.C[i] == nil {
+			return false
+		}
+		sum.Add(sum, p.C[i])
+	}
+	c := orChallenge(tr, rels, p.Proofs)
+	if sum.Mod(sum, N).Cmp(c) != 0 {
+		return false
+	}
+	for i, R := range rels {
+		if !R.checkResponses(p.C[i], p.Proofs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// orChallenge appends every branch to the transcript, and returns the
+// challenge.
+func orChallenge(tr *Transcript, rels []*LinearRelation, proofs []*Proof) *big.Int {
+	tr.AppendScalar("branches", big.NewInt(int64(len(rels))))
+	for i, R := range rels {
+		R.appendCommitments(tr, proofs[i].T)
+	}
+	return tr.ChallengeScalar("c", rels[0].E.Order())
+}
+
+func sameOrder(rels []*LinearRelation) bool {
+	for _, R := range rels {
+		if R.E.Order().Cmp(rels[0].E.Order()) != 0 {
+			return false
+		}
+	}
+	return true
+}
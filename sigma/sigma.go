@@ -0,0 +1,201 @@
+// Package sigma implements Schnorr-style sigma protocols on any elliptic
+// curve of the library, made non-interactive with the Fiat-Shamir transform.
+//
+// Every statement is a linear relation between points, which covers proofs
+// of knowledge of a discrete logarithm, proofs of equality of discrete
+// logarithms (Chaum-Pedersen), and openings of Pedersen commitments. Proofs
+// can be composed with OR, and verified in batches with a single
+// multi-scalar multiplication.
+//
+// All points must belong to the subgroup of prime order E.Order() of the
+// curve; checking this is left to the caller.
+package sigma
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+)
+
+// LinearRelation is the statement that the prover knows scalars x[0..m-1]
+// such that Y[i] = [x[0]]G[i][0]+...+[x[m-1]]G[i][m-1] for every i. Unused
+// terms of an equation are given by the identity.
+type LinearRelation struct {
+	E C.EllCurve
+	G [][]C.Point
+	Y []C.Point
+}
+
+// NewLinearRelation returns the relation Y[i] = sum_j [x[j]]G[i][j]. All rows
+// of G must have the same length, the number of witnesses.
+func NewLinearRelation(E C.EllCurve, G [][]C.Point, Y []C.Point) (*LinearRelation, error) {
+	if len(G) == 0 || len(G) != len(Y) || len(G[0]) == 0 {
+		return nil, errors.New("relation needs one row of G per point of Y")
+	}
+	for _, row := range G {
+		if len(row) != len(G[0]) {
+			return nil, errors.New("rows of G have different lengths")
+		}
+	}
+	return &LinearRelation{E, G, Y}, nil
+}
+
+// DLog returns the relation Y=[x]G.
+func DLog(E C.EllCurve, G, Y C.Point) *LinearRelation {
+	return &LinearRelation{E, [][]C.Point{{G}}, []C.Point{Y}}
+}
+
+// DLEQ returns the relation Y=[x]G and Z=[x]H, as in the protocol of Chaum
+// and Pedersen.
+func DLEQ(E C.EllCurve, G, Y, H, Z C.Point) *LinearRelation {
+	return &LinearRelation{E, [][]C.Point{{G}, {H}}, []C.Point{Y, Z}}
+}
+
+// Representation returns the relation Y=[x[0]]G[0]+...+[x[m-1]]G[m-1], such
+// as the opening of a Pedersen commitment.
+func Representation(E C.EllCurve, G []C.Point, Y C.Point) *LinearRelation {
+	return &LinearRelation{E, [][]C.Point{G}, []C.Point{Y}}
+}
+
+// Proof consists of the commitments T of the prover, one per equation, and
+// its responses S, one per witness.
+type Proof struct {
+	T []C.Point
+	S []*big.Int
+}
+
+// Prove returns a proof of knowledge of the witness x. The relation and the
+// commitments are appended to the transcript before the challenge is
+// derived. It fails if x doesn't satisfy the relation.
+func (R *LinearRelation) Prove(tr *Transcript, x []*big.Int, rnd io.Reader) (*Proof, error) {
+	if len(x) != len(R.G[0]) || !R.holds(x) {
+		return nil, errors.New("witness doesn't satisfy the relation")
+	}
+	N := R.E.Order()
+	k, err := randomScalars(rnd, N, len(x))
+	if err != nil {
+		return nil, err
+	}
+	T := R.eval(k)
+	c := R.challenge(tr, T)
+	S := make([]*big.Int, len(x))
+	for j := range S {
+		// s = k+cx
+		S[j] = new(big.Int).Mul(c, x[j])
+		S[j].Add(S[j], k[j]).Mod(S[j], N)
+	}
+	return &Proof{T, S}, nil
+}
+
+// Verify reports whether p is a valid proof of the relation under the
+// transcript.
+func (R *LinearRelation) Verify(tr *Transcript, p *Proof) bool {
+	if !R.wellFormed(p) {
+		return false
+	}
+	c := R.challenge(tr, p.T)
+	return R.checkResponses(c, p)
+}
+
+// checkResponses reports whether T[i] = sum_j [S[j]]G[i][j] - [c]Y[i].
+func (R *LinearRelation) checkResponses(c *big.Int, p *Proof) bool {
+	T := R.simulate(c, p.S)
+	for i := range T {
+		if !T[i].IsEqual(p.T[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// simulate returns the commitments that make the challenge c and the
+// responses S an accepting conversation.
+func (R *LinearRelation) simulate(c *big.Int, S []*big.Int) []C.Point {
+	T := make([]C.Point, len(R.Y))
+	negC := new(big.Int).Neg(c)
+	for i, row := range R.G {
+		P := append(append([]C.Point{}, row...), R.Y[i])
+		T[i] = C.MultiScalarMult(R.E, P, append(append([]*big.Int{}, S...), negC))
+	}
+	return T
+}
+
+// eval returns the points sum_j [x[j]]G[i][j].
+func (R *LinearRelation) eval(x []*big.Int) []C.Point {
+	Y := make([]C.Point, len(R.G))
+	for i, row := range R.G {
+		Y[i] = C.MultiScalarMult(R.E, row, x)
+	}
+	return Y
+}
+
+func (R *LinearRelation) holds(x []*big.Int) bool {
+	for i, Y := range R.eval(x) {
+		if !Y.IsEqual(R.Y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (R *LinearRelation) wellFormed(p *Proof) bool {
+	if p == nil || len(p.T) != len(R.Y) || len(p.S) != len(R.G[0]) {
+		return false
+	}
+	for _, T := range p.T {
+		if T == nil {
+			return false
+		}
+	}
+	for _, s := range p.S {
+		if s == nil || s.Sign() < 0 || s.Cmp(R.E.Order()) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendTo appends the relation to the transcript.
+func (R *LinearRelation) appendTo(tr *Transcript) {
+	tr.AppendScalar("equations", big.NewInt(int64(len(R.G))))
+	tr.AppendScalar("witnesses", big.NewInt(int64(len(R.G[0]))))
+	for i, row := range R.G {
+		for _, G := range row {
+			tr.AppendPoint("G", G)
+		}
+		tr.AppendPoint("Y", R.Y[i])
+	}
+}
+
+// appendCommitments appends the relation and the commitments of the prover
+// to the transcript.
+func (R *LinearRelation) appendCommitments(tr *Transcript, T []C.Point) {
+	R.appendTo(tr)
+	for _, Ti := range T {
+		tr.AppendPoint("T", Ti)
+	}
+}
+
+// challenge appends the relation and the commitments to the transcript, and
+// returns the challenge.
+func (R *LinearRelation) challenge(tr *Transcript, T []C.Point) *big.Int {
+	R.appendCommitments(tr, T)
+	return tr.ChallengeScalar("c", R.E.Order())
+}
+
+func randomScalars(rnd io.Reader, N *big.Int, n int) ([]*big.Int, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	k := make([]*big.Int, n)
+	for i := range k {
+		var err error
+		if k[i], err = rand.Int(rnd, N); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
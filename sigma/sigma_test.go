@@ -0,0 +1,166 @@
+package sigma_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/armfazh/tozan-ecc/commitment"
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/eddsa"
+	GF "github.com/armfazh/tozan-ecc/field"
+	"github.com/armfazh/tozan-ecc/sigma"
+)
+
+const domain = "sigma test"
+
+func p256() (C.EllCurve, C.Point) {
+	p := elliptic.P256().Params()
+	F := GF.NewFp(p.Name, p.P)
+	E := C.Weierstrass.New(p.Name, F, F.Elt(-3), F.Elt(p.B), p.N, big.NewInt(1))
+	return E, E.NewPoint(F.Elt(p.Gx), F.Elt(p.Gy))
+}
+
+var curves = []func() (C.EllCurve, C.Point){p256, eddsa.Edwards25519}
+
+func randomScalar(t *testing.T, E C.EllCurve) *big.Int {
+	k, err := rand.Int(rand.Reader, E.Order())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// relations returns statements of each kind with their witnesses.
+func relations(t *testing.T, E C.EllCurve, G C.Point) ([]*sigma.LinearRelation, [][]*big.Int) {
+	x, m, r := randomScalar(t, E), randomScalar(t, E), randomScalar(t, E)
+	H, err := C.HashToPoint(E, []byte("H"), []byte(domain), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := commitment.New(E, G, 1, []byte(domain))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*sigma.LinearRelation{
+		sigma.DLog(E, G, E.ScalarMult(G, x)),
+		sigma.DLEQ(E, G, E.ScalarMult(G, x), H, E.ScalarMult(H, x)),
+		sigma.Representation(E, []C.Point{pp.G[0], pp.H}, pp.Commit(m, r)),
+	}, [][]*big.Int{
+		{x}, {x}, {m, r},
+	}
+}
+
+func TestProof(t *testing.T) {
+	for _, curve := range curves {
+		E, G := curve()
+		rels, wits := relations(t, E, G)
+		for i, R := range rels {
+			p, err := R.Prove(sigma.NewTranscript(domain), wits[i], rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !R.Verify(sigma.NewTranscript(domain), p) {
+				t.Fatalf("%v: valid proof rejected", E)
+			}
+			if R.Verify(sigma.NewTranscript("other domain"), p) {
+				t.Fatalf("%v: proof accepted under another transcript", E)
+			}
+			bad := &sigma.Proof{T: p.T, S: append([]*big.Int{}, p.S...)}
+			bad.S[0] = new(big.Int).Add(bad.S[0], big.NewInt(1))
+			if R.Verify(sigma.NewTranscript(domain), bad) {
+				t.Fatalf("%v: modified proof accepted", E)
+			}
+			if R.Verify(sigma.NewTranscript(domain), &sigma.Proof{T: p.T}) {
+				t.Fatalf("%v: malformed proof accepted", E)
+			}
+
+			wrong := append([]*big.Int{}, wits[i]...)
+			wrong[0] = new(big.Int).Add(wrong[0], big.NewInt(1))
+			if _, err := R.Prove(sigma.NewTranscript(domain), wrong, rand.Reader); err == nil {
+				t.Fatalf("%v: proof of a false statement", E)
+			}
+		}
+
+		// The second equation of DLEQ is bound to the same witness.
+		R := rels[1]
+		y := randomScalar(t, E)
+		R2, _ := sigma.NewLinearRelation(E, R.G, []C.Point{R.Y[0], E.ScalarMult(R.G[1][0], y)})
+		p, _ := R.Prove(sigma.NewTranscript(domain), wits[1], rand.Reader)
+		if R2.Verify(sigma.NewTranscript(domain), p) {
+			t.Fatalf("%v: proof of unequal discrete logs accepted", E)
+		}
+	}
+}
+
+func TestOr(t *testing.T) {
+	E, G := p256()
+	rels, wits := relations(t, E, G)
+	for i := range rels {
+		p, err := sigma.ProveOr(sigma.NewTranscript(domain), rels, i, wits[i], rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sigma.VerifyOr(sigma.NewTranscript(domain), rels, p) {
+			t.Fatalf("branch %v: valid proof rejected", i)
+		}
+		c := p.C[0]
+		p.C[0] = new(big.Int).Add(c, big.NewInt(1))
+		if sigma.VerifyOr(sigma.NewTranscript(domain), rels, p) {
+			t.Fatalf("branch %v: modified challenge accepted", i)
+		}
+		p.C[0] = c
+		if sigma.VerifyOr(sigma.NewTranscript(domain), rels[:2], p) {
+			t.Fatalf("branch %v: proof accepted for other relations", i)
+		}
+	}
+	if _, err := sigma.ProveOr(sigma.NewTranscript(domain), rels, 0, wits[2], rand.Reader); err == nil {
+		t.Fatal("proof of a false branch")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	E, G := p256()
+	rels, wits := relations(t, E, G)
+	var instances []sigma.Instance
+	for i, R := range rels {
+		p, err := R.Prove(sigma.NewTranscript(domain), wits[i], rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		instances = append(instances, sigma.Instance{Relation: R, Proof: p})
+	}
+	reset := func() {
+		for i := range instances {
+			instances[i].Transcript = sigma.NewTranscript(domain)
+		}
+	}
+	reset()
+	if !sigma.BatchVerify(rand.Reader, instances) {
+		t.Fatal("valid batch rejected")
+	}
+	// A proof for another statement makes the batch fail.
+	reset()
+	instances[1].Relation = sigma.DLEQ(E, G, rels[1].Y[0], rels[1].G[1][0], rels[0].Y[0])
+	if sigma.BatchVerify(rand.Reader, instances) {
+		t.Fatal("invalid batch accepted")
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	N := big.NewInt(1000003)
+	t1, t2 := sigma.NewTranscript(domain), sigma.NewTranscript(domain)
+	for _, tr := range []*sigma.Transcript{t1, t2} {
+		tr.AppendMessage("a", []byte("bc"))
+	}
+	t3 := t1.Clone()
+	t3.AppendMessage("ab", []byte("c"))
+	c1, c2, c3 := t1.ChallengeScalar("c", N), t2.ChallengeScalar("c", N), t3.ChallengeScalar("c", N)
+	if c1.Cmp(c2) != 0 || c1.Cmp(c3) == 0 {
+		t.Fatal("challenges must depend only on the messages")
+	}
+	if t1.ChallengeScalar("c", N).Cmp(c1) == 0 {
+		t.Fatal("challenges must depend on the previous challenges")
+	}
+}
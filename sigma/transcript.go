@@ -0,0 +1,82 @@
+package sigma
+
+import (
+	"crypto"
+	_ "crypto/sha256" // for expand_message_xmd
+	"encoding/binary"
+	"math/big"
+
+	C "github.com/armfazh/tozan-ecc/curve"
+	"github.com/armfazh/tozan-ecc/group"
+)
+
+// Transcript is a Fiat-Shamir transcript with the interface of Merlin: the
+// prover and the verifier append the same labeled messages, and challenges
+// are derived from everything appended before. Challenges are computed with
+// expand_message_xmd of RFC 9380 and SHA-256, using the domain separator of
+// the transcript as DST.
+type Transcript struct {
+	dst  []byte
+	data []byte
+}
+
+// NewTranscript returns an empty transcript for a protocol identified by
+// domain.
+func NewTranscript(domain string) *Transcript {
+	return &Transcript{dst: []byte(domain)}
+}
+
+// Clone returns a copy of the transcript, which evolves independently.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{dst: t.dst, data: append([]byte{}, t.data...)}
+}
+
+// AppendMessage appends a labeled message. The label and the message are
+// prefixed with their lengths, so that distinct sequences of messages give
+// distinct transcripts.
+func (t *Transcript) AppendMessage(label string, msg []byte) {
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(label)))
+	t.data = append(append(t.data, n[:]...), label...)
+	binary.BigEndian.PutUint32(n[:], uint32(len(msg)))
+	t.data = append(append(t.data, n[:]...), msg...)
+}
+
+// AppendPoint appends a labeled point, encoded as 0x00 for the identity and
+// 0x04 followed by the coefficients of x and y otherwise, each one prefixed
+// with its length.
+func (t *Transcript) AppendPoint(label string, P C.Point) {
+	t.AppendMessage(label, encodePoint(P))
+}
+
+// AppendScalar appends a labeled scalar as a big-endian integer.
+func (t *Transcript) AppendScalar(label string, k *big.Int) {
+	t.AppendMessage(label, k.Bytes())
+}
+
+// ChallengeScalar returns a challenge in [0,n), with a bias below 2^-128.
+// The label and the challenge are appended to the transcript.
+func (t *Transcript) ChallengeScalar(label string, n *big.Int) *big.Int {
+	t.AppendMessage(label, nil)
+	L := (n.BitLen() + 128 + 7) / 8
+	b, err := group.ExpandMessageXMD(crypto.SHA256, t.data, t.dst, L)
+	if err != nil {
+		panic(err)
+	}
+	c := new(big.Int).SetBytes(b)
+	c.Mod(c, n)
+	t.AppendScalar("challenge", c)
+	return c
+}
+
+func encodePoint(P C.Point) []byte {
+	if P.IsIdentity() {
+		return []byte{0x00}
+	}
+	b := []byte{0x04}
+	for _, c := range append(P.X().Polynomial(), P.Y().Polynomial()...) {
+		cb := c.Bytes()
+		b = append(append(b, byte(len(cb)>>8), byte(len(cb))), cb...)
+	}
+	return b
+}